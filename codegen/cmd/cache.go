@@ -0,0 +1,42 @@
+/*
+Copyright © 2024 ngicks <yknt.bsl@gmail.com>
+*/
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/ngicks/go-codegen/codegen/pkg/gencache"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func noCacheFlag(fset *pflag.FlagSet) {
+	fset.Bool(
+		"no-cache",
+		false,
+		`If set, every file is generated from scratch.
+By default, outputs are cached under the user cache directory and reused for files whose types,
+including their dependencies, are unchanged since the last run.`,
+	)
+}
+
+// openCache opens the generation cache for generator configured as described by config.
+// It returns nil, which disables caching, if cmd has no --no-cache flag or it is set.
+// Failing to locate the cache directory is not an error; generation just goes without cache.
+func openCache(cmd *cobra.Command, generator string, config string) *gencache.Cache {
+	if f := cmd.Flags().Lookup("no-cache"); f == nil || f.Value.String() == "true" {
+		return nil
+	}
+	dir, err := gencache.DefaultDir()
+	if err == nil {
+		var cache *gencache.Cache
+		cache, err = gencache.New(dir, generator, config)
+		if err == nil {
+			return cache
+		}
+	}
+	slog.Default().DebugContext(cmd.Context(), fmt.Sprintf("generation cache disabled: %v", err))
+	return nil
+}
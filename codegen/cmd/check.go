@@ -0,0 +1,290 @@
+/*
+Copyright © 2024 ngicks <yknt.bsl@gmail.com>
+*/
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ngicks/go-codegen/codegen/generator/undgen"
+	"github.com/ngicks/go-codegen/codegen/internal/config"
+	"github.com/ngicks/go-codegen/codegen/internal/linediff"
+	"github.com/ngicks/go-codegen/codegen/pkg/directive"
+	"github.com/ngicks/go-codegen/codegen/pkg/genreport"
+	"github.com/ngicks/go-codegen/codegen/pkg/suffixwriter"
+	"github.com/spf13/cobra"
+	"golang.org/x/tools/go/packages"
+)
+
+var checkTargets = map[string]generatorTarget{
+	string(config.GeneratorCloner):          targetOf(config.GeneratorCloner, generateCloner),
+	string(config.GeneratorUndgenPatch):     targetOf(config.GeneratorUndgenPatch, generateUndPatchOnDisk),
+	string(config.GeneratorUndgenPlain):     targetOf(config.GeneratorUndgenPlain, generateUndPlain),
+	string(config.GeneratorUndgenValidator): targetOf(config.GeneratorUndgenValidator, generateUndValidator),
+}
+
+func init() {
+	fset := checkCmd.Flags()
+	commonFlags(checkCmd, fset, true)
+	_ = fset.MarkHidden("dry")
+	_ = fset.MarkHidden("no-type-check")
+	_ = fset.MarkHidden("report")
+
+	fset.StringSlice(
+		"gen",
+		nil,
+		"[required] a comma separated list of generators to check. "+
+			"one or more of "+strings.Join(slices.Sorted(maps.Keys(checkTargets)), ", "),
+	)
+	_ = checkCmd.MarkFlagRequired("gen")
+
+	clonerFlags(fset)
+	patchFlags(fset)
+
+	rootCmd.AddCommand(checkCmd)
+}
+
+// checkCmd represents the check command
+var checkCmd = &cobra.Command{
+	Use:   "check [flags] --gen cloner,undgen-plain --pkg ./...",
+	Short: "check reports generated files which are out of date.",
+	Long: `check runs generators without writing anything and compares results with files on disk.
+
+It fails if
+1) a generated file differs from what is on disk,
+2) a file is to be generated but it does not exist on disk, or
+3) a generated file (a file suffixed with generator's suffix and starting with generation notice) exists on disk
+   but the generator no longer produces it, e.g. the source type or the source file is removed.
+Generated test files, e.g. *.clone_test.go written by cloner with --test, are checked as well.
+
+A unified diff is printed for each of them.
+
+Flags for cloner, e.g. --chan-disallow, are accepted and passed to cloner as they are for the cloner command.
+So are --deep, --diff and --json-patch for undgen-patch.
+undgen-patch regenerates patches only for types that already have one in *.und_patch.go files on disk.
+
+Intended to be used in CI to detect that someone edited a type and forgot to rerun code generators.
+`,
+	RunE: runCheck,
+}
+
+func runCheck(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	fset := cmd.Flags()
+
+	dir, buildFlags, pkg, verbose, ignoreGenerated, _, err := commonOpts(fset, true)
+	if err != nil {
+		return err
+	}
+
+	jobs, err := fset.GetInt("jobs")
+	if err != nil {
+		return err
+	}
+
+	gens, err := fset.GetStringSlice("gen")
+	if err != nil {
+		return err
+	}
+	for _, gen := range gens {
+		if _, ok := checkTargets[gen]; !ok {
+			return fmt.Errorf("unknown generator %q: must be one of %v", gen, slices.Sorted(maps.Keys(checkTargets)))
+		}
+	}
+
+	pkgs, _, err := loadPkgs(ctx, dir, buildFlags, pkg, true, verbose, ignoreGenerated)
+	if err != nil {
+		return err
+	}
+
+	var stale []string
+	for _, gen := range gens {
+		s, err := checkGenerated(cmd, checkTargets[gen], dir, verbose, jobs, pkgs, cmd.OutOrStdout())
+		if err != nil {
+			return fmt.Errorf("checking %s: %w", gen, err)
+		}
+		stale = append(stale, s...)
+	}
+
+	if len(stale) > 0 {
+		return fmt.Errorf("%d generated file(s) are out of date:\n\t%s", len(stale), strings.Join(stale, "\n\t"))
+	}
+	return nil
+}
+
+// checkGenerated runs target without writing files then prints diff between results and files on disk to out.
+// It returns names of files that differ, relative to dir.
+func checkGenerated(
+	cmd *cobra.Command,
+	target generatorTarget,
+	dir string,
+	verbose bool,
+	jobs int,
+	pkgs []*packages.Package,
+	out io.Writer,
+) (stale []string, err error) {
+	testWriter := suffixwriter.NewTestWriter(
+		target.suffix,
+		suffixwriter.WithCwd(dir),
+		suffixwriter.WithPrefix(generationPrefix(target.subcommand)),
+		suffixwriter.WithJobs(jobs),
+	)
+	err = target.generate(cmd, testWriter.Writer, verbose, pkgs, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	results := testWriter.Results()
+
+	names := slices.Collect(maps.Keys(results))
+	for _, pkgDir := range packageDirs(pkgs) {
+		onDisk, err := listGenerated(pkgDir, target.suffix)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, onDisk...)
+	}
+	slices.Sort(names)
+	names = slices.Compact(names)
+
+	for _, name := range names {
+		current, err := os.ReadFile(name)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		generated, ok := results[name]
+
+		rel, err := filepath.Rel(dir, name)
+		if err != nil {
+			rel = name
+		}
+		rel = filepath.ToSlash(rel)
+
+		oldName, newName := "a/"+rel, "b/"+rel
+		switch {
+		case !ok:
+			newName = "/dev/null"
+		case current == nil:
+			oldName = "/dev/null"
+		}
+
+		if ok && bytes.Equal(current, generated) {
+			continue
+		}
+		stale = append(stale, rel)
+		_, err = io.WriteString(out, linediff.Unified(oldName, newName, current, generated, 3))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return stale, nil
+}
+
+func packageDirs(pkgs []*packages.Package) []string {
+	var dirs []string
+	for _, pkg := range pkgs {
+		if pkg.Dir != "" {
+			dirs = append(dirs, pkg.Dir)
+		}
+	}
+	slices.Sort(dirs)
+	return slices.Compact(dirs)
+}
+
+// generateUndPatchOnDisk is like generateUndPatch
+// but generates patches for types whose patch exists in *.und_patch.go files on disk.
+// Type names are qualified by the package path so that each is only looked up in its own package.
+func generateUndPatchOnDisk(
+	cmd *cobra.Command,
+	writer *suffixwriter.Writer,
+	verbose bool,
+	pkgs []*packages.Package,
+	args []string,
+	report *genreport.Run,
+) error {
+	var qualified []string
+	for _, pkg := range pkgs {
+		typeNames, err := patchedTypeNames(pkg.Dir)
+		if err != nil {
+			return err
+		}
+		for _, name := range typeNames {
+			qualified = append(qualified, pkg.PkgPath+"."+name)
+		}
+	}
+	if len(qualified) == 0 {
+		return nil
+	}
+	return undgen.GeneratePatcher(
+		writer,
+		verbose,
+		pkgs,
+		undgen.ConstUnd.Imports,
+		qualified,
+		append(patchOptions(), undgen.WithReport(report))...,
+	)
+}
+
+// patchedTypeNames lists names of types whose patch type is found in generated *.und_patch.go files under dir.
+//
+// Names are read from the generated FromValue methods, func (p *XPatch) FromValue(v X),
+// rather than from names of patch types, since patch types are not the only types the files may declare
+// and target types may themselves end with "Patch".
+func patchedTypeNames(dir string) ([]string, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	files, err := listGenerated(dir, ".und_patch")
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, name := range files {
+		f, err := parser.ParseFile(token.NewFileSet(), name, nil, parser.ParseComments|parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range f.Decls {
+			if name, ok := patchedTypeName(decl); ok {
+				names = append(names, name)
+			}
+		}
+	}
+	slices.Sort(names)
+	return slices.Compact(names), nil
+}
+
+// patchedTypeName returns X if decl is the generated method func (p *XPatch) FromValue(v X).
+func patchedTypeName(decl ast.Decl) (string, bool) {
+	fn, ok := decl.(*ast.FuncDecl)
+	if !ok || fn.Recv == nil || fn.Name.Name != "FromValue" || len(fn.Type.Params.List) != 1 {
+		return "", false
+	}
+	direction, ok, err := directive.ParseDirectiveComment(fn.Doc)
+	if err != nil || !ok || !direction.IsGenerated() {
+		return "", false
+	}
+	ty := fn.Type.Params.List[0].Type
+	switch x := ty.(type) {
+	case *ast.IndexExpr:
+		ty = x.X
+	case *ast.IndexListExpr:
+		ty = x.X
+	}
+	ident, ok := ty.(*ast.Ident)
+	if !ok {
+		return "", false
+	}
+	return ident.Name, true
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func Test_patchedTypeNames(t *testing.T) {
+	dir := t.TempDir()
+	src := generationNotice + `
+
+package foo
+
+//codegen:generated
+type ReleasePatch struct{}
+
+//codegen:generated
+func (p *ReleasePatch) FromValue(v Release) {}
+
+//codegen:generated
+type ReleasePatchPatch struct{}
+
+//codegen:generated
+func (p *ReleasePatchPatch) FromValue(v ReleasePatch) {}
+
+//codegen:generated
+type PairPatch[T, U any] struct{}
+
+//codegen:generated
+func (p *PairPatch[T, U]) FromValue(v Pair[T, U]) {}
+
+//codegen:generated
+type BoxPatch[T any] struct{}
+
+//codegen:generated
+func (p *BoxPatch[T]) FromValue(v Box[T]) {}
+
+// not a patch type.
+type helperPatch struct{}
+
+func (p *helperPatch) FromValue(v int) {}
+`
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "foo.und_patch.go"), []byte(src), 0o644))
+
+	names, err := patchedTypeNames(dir)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []string{"Box", "Pair", "Release", "ReleasePatch"}, names)
+}
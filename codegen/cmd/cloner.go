@@ -4,11 +4,14 @@ Copyright © 2024 ngicks <yknt.bsl@gmail.com>
 package cmd
 
 import (
+	"fmt"
 	"log/slog"
 
 	"github.com/ngicks/go-codegen/codegen/generator/cloner"
+	"github.com/ngicks/go-codegen/codegen/pkg/genreport"
 	"github.com/ngicks/go-codegen/codegen/pkg/suffixwriter"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"golang.org/x/tools/go/packages"
 )
 
@@ -28,6 +31,20 @@ var (
 
 	interfaceIgnore bool
 	interfaceCopy   bool
+	interfaceClone  bool
+
+	atomicIgnore   bool
+	atomicDisallow bool
+	atomicCopy     bool
+	atomicClone    bool
+
+	reflectFallback bool
+
+	equal bool
+	memo  bool
+	into  bool
+
+	test bool
 )
 
 func init() {
@@ -35,6 +52,13 @@ func init() {
 
 	commonFlags(clonerCmd, fset, true)
 
+	clonerFlags(fset)
+
+	rootCmd.AddCommand(clonerCmd)
+}
+
+// clonerFlags defines flags that configure cloner.MatcherConfig on fset.
+func clonerFlags(fset *pflag.FlagSet) {
 	fset.BoolVar(&noCopyIgnore, "no-copy-ignore", false, "sets global option that ignores no-copy object. Clone methods just simply leave fields zero value.")
 	fset.BoolVar(&noCopyDisallow, "no-copy-disallow", false, "sets global option that disallow no-copy object. Types that contain no-copy type fields are not generation target.")
 	fset.BoolVar(&noCopyCopy, "no-copy-copy", false, "sets global option that copy pointer of no-copy object. Clone methods copy no-copy object if and only if field is pointer type.")
@@ -50,8 +74,22 @@ func init() {
 
 	fset.BoolVar(&interfaceIgnore, "interface-ignore", false, "sets global option that ignores interface fields. func literal or named function type.")
 	fset.BoolVar(&interfaceCopy, "interface-copy", false, "sets global option that copies interface fields")
+	fset.BoolVar(&interfaceClone, "interface-clone", false, "sets global option that clones interface fields by type switch over implementors with Clone methods in loaded packages. "+
+		"Values of other types are cloned by cloners registered to github.com/ngicks/go-codegen/pkg/cloner/runtime, or copied if none is registered.")
 
-	rootCmd.AddCommand(clonerCmd)
+	fset.BoolVar(&atomicIgnore, "atomic-ignore", false, "sets global option that ignores values of sync/atomic types, e.g. atomic.Int64, atomic.Value and atomic.Pointer[T]. Without --atomic-* options they are handled as no-copy object.")
+	fset.BoolVar(&atomicDisallow, "atomic-disallow", false, "sets global option that disallows values of sync/atomic types.")
+	fset.BoolVar(&atomicCopy, "atomic-copy", false, "sets global option that copies pointers of sync/atomic types.")
+	fset.BoolVar(&atomicClone, "atomic-clone", false, "sets global option that clones values of sync/atomic types by Load and Store. Pointees of atomic.Pointer[T] are also cloned if T is clone-able.")
+
+	fset.BoolVar(&reflectFallback, "reflect-fallback", false, "sets global option that clones values which otherwise would be ignored, e.g. types of other packages with unexported fields but no Clone method, "+
+		"by reflection-based DeepClone of github.com/ngicks/go-codegen/pkg/cloner/runtime.")
+
+	fset.BoolVar(&equal, "equal", false, "generates Equal methods, or EqualFunc for generic types, alongside clone methods.")
+	fset.BoolVar(&memo, "memo", false, "generates CloneWithMemo, or CloneFuncWithMemo for generic types, which preserve aliasing and cycles of pointers. Clone methods delegate to them.")
+	fset.BoolVar(&into, "into", false, "generates CloneInto, or CloneFuncInto for generic types, which clone into an existing value reusing its slices, maps and pointers.")
+
+	fset.BoolVar(&test, "test", false, "generates <name>.clone_test.go files along generated files, which fuzz and benchmark generated clone methods by github.com/ngicks/go-codegen/pkg/cloner/runtime/clonetest.")
 }
 
 // clonerCmd represents the cloner command
@@ -73,11 +111,32 @@ func (c C[T, U]) CloneFunc(cloneT func(T) T, cloneU func(U) U) C[T, U] {
 	// ...
 }
 
+With --memo, they delegate to CloneWithMemo(memo map[any]any) and CloneFuncWithMemo(memo, cloneT, ...)
+which clone each pointer only once per memo, thus preserving aliasing and cycles of pointers.
+The memo is keyed by typed pointers, not by unsafe.Pointer, since a struct and its first field share an address.
+With --into, CloneInto(dst *T) and CloneFuncInto(dst *T, cloneT, ...) are also generated.
+They clone into dst reusing its slices, maps and pointers to reduce allocations.
+With --test, <name>.clone_test.go files are written along generated files.
+They have a fuzz test and a benchmark for each non-generic type, which check that cloned values are deeply equal to
+and share no memory with originals by "github.com/ngicks/go-codegen/pkg/cloner/runtime/clonetest".
+With --interface-clone, interface fields are cloned by a type switch over types in loaded packages
+which implement the interface and have Clone methods, generated or hand-written.
+Values of types not found there are cloned by cloners registered to
+"github.com/ngicks/go-codegen/pkg/cloner/runtime", or copied as is.
+With --atomic-clone, values of sync/atomic types, which are otherwise handled as no-copy objects,
+are cloned by Load and Store. Pointees of atomic.Pointer[T] are cloned as well if T is clone-able.
+Fields of atomic values can not be read without copying in slices, arrays and maps, thus they are still handled as no-copy objects.
+Types holding atomic values should have //cloner:ptr since methods with value receivers copy them.
+With --reflect-fallback, values which otherwise would be ignored since they can not be cloned statically,
+e.g. types of other packages with unexported fields but no Clone method, are cloned by DeepClone of the same package.
+
 The cloner sub command, as other commands do, loads and parses Go source code files
 by using "golang.org/x/tools/go/packages".Load
 then it examines types defined in them whether if they are clone-able or not.
 Multiple packages can be loaded and processed at once.
 The type dependency chain is allowed to span across multiple packages and generated Clone method considers it.
+Types with unexported fields in other packages are cloned by calling Clone methods generated in their own packages,
+thus those packages should be loaded together. Otherwise the cloner warns that such fields are left zero value.
 
 The specified package path must be relative to the cwd, which can be changed by --dir option,
 to limit the target packages to which the process can write generated code safely.
@@ -114,60 +173,135 @@ func (v Foo) Clone() Foo {
 }
 
 Without the comment, the cloner command ignores the type Foo since it has no clone-able fields other than that.
+
+//cloner:reflect makes the field cloned by reflection-based DeepClone of "github.com/ngicks/go-codegen/pkg/cloner/runtime".
+
+Types can be configured by comma-separated directives in their doc comments as well.
+
+//cloner:generate    generates methods for the struct type even if no field needs cloning.
+//cloner:ptr         generated methods have pointer receivers.
+//cloner:name=Copy   renames generated methods, e.g. Copy, CopyFunc and CopyInto.
+//cloner:chan=make   overrides the global option for fields of the type. no-copy=, func=, interface= and atomic= are also accepted.
 `,
 	RunE: runCommand(
 		"cloner",
 		".clone",
 		true,
-		func(
-			cmd *cobra.Command,
-			writer *suffixwriter.Writer,
-			verbose bool,
-			pkgs []*packages.Package,
-			args []string,
-		) error {
-			cfg := &cloner.Config{
-				MatcherConfig: &cloner.MatcherConfig{},
-			}
-			if verbose {
-				cfg.Logger = slog.Default()
-			}
-
-			switch {
-			case noCopyIgnore:
-				cfg.MatcherConfig.NoCopyHandle = cloner.CopyHandleIgnore
-			case noCopyDisallow:
-				cfg.MatcherConfig.NoCopyHandle = cloner.CopyHandleDisallow
-			case noCopyCopy:
-				cfg.MatcherConfig.NoCopyHandle = cloner.CopyHandleCopyPointer
-			}
-			switch {
-			case chanIgnore:
-				cfg.MatcherConfig.ChannelHandle = cloner.CopyHandleIgnore
-			case chanDisallow:
-				cfg.MatcherConfig.ChannelHandle = cloner.CopyHandleDisallow
-			case chanCopy:
-				cfg.MatcherConfig.ChannelHandle = cloner.CopyHandleCopyPointer
-			case chanMake:
-				cfg.MatcherConfig.ChannelHandle = cloner.CopyHandleMake
-			}
-			switch {
-			case funcIgnore:
-				cfg.MatcherConfig.FuncHandle = cloner.CopyHandleIgnore
-			case funcDisallow:
-				cfg.MatcherConfig.FuncHandle = cloner.CopyHandleDisallow
-			case funcCopy:
-				cfg.MatcherConfig.FuncHandle = cloner.CopyHandleCopyPointer
-			}
-
-			switch {
-			case interfaceIgnore:
-				cfg.MatcherConfig.InterfaceHandle = cloner.CopyHandleIgnore
-			case interfaceCopy:
-				cfg.MatcherConfig.InterfaceHandle = cloner.CopyHandleCopyPointer
-			}
-
-			return cfg.Generate(cmd.Context(), writer, pkgs)
-		},
+		generateCloner,
 	),
 }
+
+func generateCloner(
+	cmd *cobra.Command,
+	writer *suffixwriter.Writer,
+	verbose bool,
+	pkgs []*packages.Package,
+	args []string,
+	report *genreport.Run,
+) error {
+	return runCloner(
+		cmd,
+		writer,
+		verbose,
+		pkgs,
+		cloner.Config{
+			MatcherConfig: clonerMatcherConfig(),
+			GenerateEqual: equal,
+			Memo:          memo,
+			GenerateInto:  into,
+			GenerateTest:  test,
+		},
+		report,
+	)
+}
+
+// clonerMatcherConfig builds *cloner.MatcherConfig from flags defined by clonerFlags.
+func clonerMatcherConfig() *cloner.MatcherConfig {
+	matcherConfig := &cloner.MatcherConfig{}
+
+	switch {
+	case noCopyIgnore:
+		matcherConfig.NoCopyHandle = cloner.CopyHandleIgnore
+	case noCopyDisallow:
+		matcherConfig.NoCopyHandle = cloner.CopyHandleDisallow
+	case noCopyCopy:
+		matcherConfig.NoCopyHandle = cloner.CopyHandleCopyPointer
+	}
+	switch {
+	case chanIgnore:
+		matcherConfig.ChannelHandle = cloner.CopyHandleIgnore
+	case chanDisallow:
+		matcherConfig.ChannelHandle = cloner.CopyHandleDisallow
+	case chanCopy:
+		matcherConfig.ChannelHandle = cloner.CopyHandleCopyPointer
+	case chanMake:
+		matcherConfig.ChannelHandle = cloner.CopyHandleMake
+	}
+	switch {
+	case funcIgnore:
+		matcherConfig.FuncHandle = cloner.CopyHandleIgnore
+	case funcDisallow:
+		matcherConfig.FuncHandle = cloner.CopyHandleDisallow
+	case funcCopy:
+		matcherConfig.FuncHandle = cloner.CopyHandleCopyPointer
+	}
+
+	switch {
+	case interfaceIgnore:
+		matcherConfig.InterfaceHandle = cloner.CopyHandleIgnore
+	case interfaceCopy:
+		matcherConfig.InterfaceHandle = cloner.CopyHandleCopyPointer
+	case interfaceClone:
+		matcherConfig.InterfaceHandle = cloner.CopyHandleClone
+	}
+
+	switch {
+	case atomicIgnore:
+		matcherConfig.AtomicHandle = cloner.CopyHandleIgnore
+	case atomicDisallow:
+		matcherConfig.AtomicHandle = cloner.CopyHandleDisallow
+	case atomicCopy:
+		matcherConfig.AtomicHandle = cloner.CopyHandleCopyPointer
+	case atomicClone:
+		matcherConfig.AtomicHandle = cloner.CopyHandleClone
+	}
+
+	matcherConfig.ReflectFallback = reflectFallback
+
+	return matcherConfig
+}
+
+// runCloner runs the cloner configured by cfg.
+// Only MatcherConfig, GenerateEqual, Memo, GenerateInto and GenerateTest of cfg are used; other fields are set from cmd and args.
+func runCloner(
+	cmd *cobra.Command,
+	writer *suffixwriter.Writer,
+	verbose bool,
+	pkgs []*packages.Package,
+	cfg cloner.Config,
+	report *genreport.Run,
+) error {
+	matcherConfig := cfg.MatcherConfig
+	cfg.Cache = openCache(
+		cmd,
+		"cloner",
+		fmt.Sprintf(
+			"no-copy=%d chan=%d func=%d interface=%d atomic=%d reflect=%t equal=%t memo=%t into=%t test=%t handlers=%q",
+			matcherConfig.NoCopyHandle,
+			matcherConfig.ChannelHandle,
+			matcherConfig.FuncHandle,
+			matcherConfig.InterfaceHandle,
+			matcherConfig.AtomicHandle,
+			matcherConfig.ReflectFallback,
+			cfg.GenerateEqual,
+			cfg.Memo,
+			cfg.GenerateInto,
+			cfg.GenerateTest,
+			matcherConfig.CustomHandlers.Names(),
+		),
+	)
+	cfg.Report = report
+	// warnings are always shown. debug logs are only shown if verbose.
+	cfg.Logger = slog.Default()
+	return cfg.Generate(cmd.Context(), writer, pkgs)
+}
@@ -0,0 +1,124 @@
+/*
+Copyright © 2024 ngicks <yknt.bsl@gmail.com>
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/types"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/ngicks/go-codegen/codegen/generator/cloner"
+	"github.com/ngicks/go-codegen/codegen/generator/undgen"
+	"github.com/ngicks/go-codegen/codegen/internal/config"
+	"github.com/ngicks/go-codegen/codegen/pkg/genreport"
+	"github.com/ngicks/go-codegen/codegen/pkg/typegraph"
+	"github.com/spf13/cobra"
+	"golang.org/x/tools/go/packages"
+)
+
+type explainFunc func(pkgs []*packages.Package, ident typegraph.Ident) (*genreport.Explanation, error)
+
+var explainTargets = map[string]func() explainFunc{
+	string(config.GeneratorCloner): func() explainFunc {
+		return (&cloner.Config{MatcherConfig: clonerMatcherConfig()}).Explain
+	},
+	string(config.GeneratorUndgenPlain):     func() explainFunc { return undgen.ExplainPlain },
+	string(config.GeneratorUndgenValidator): func() explainFunc { return undgen.ExplainValidator },
+}
+
+func init() {
+	fset := explainCmd.Flags()
+	commonFlags(explainCmd, fset, false)
+	for _, name := range []string{"dry", "no-type-check", "jobs", "no-cache", "report"} {
+		_ = fset.MarkHidden(name)
+	}
+
+	fset.String(
+		"gen",
+		"",
+		"[required] the generator whose decision is explained. "+
+			"one of "+strings.Join(slices.Sorted(maps.Keys(explainTargets)), ", "),
+	)
+	_ = explainCmd.MarkFlagRequired("gen")
+	fset.Bool("json", false, "prints the explanation as JSON instead of text")
+
+	clonerFlags(fset)
+
+	rootCmd.AddCommand(explainCmd)
+}
+
+// explainCmd represents the explain command
+var explainCmd = &cobra.Command{
+	Use:   "explain [flags] --gen cloner --pkg ./ TypeName",
+	Short: "explain shows why a type is or is not a target of a generator.",
+	Long: `explain builds the type graph of the package in the same way as the generator specified by --gen does
+and prints how the type named by the argument is decided.
+
+The output shows
+1) whether the type is matched, or dependant (it refers to matched types), or not a target at all,
+2) reasons why it is not a target, e.g. the field which caused the rejection and the handle which disallowed it,
+3) each examined field (or the element for array, slice and map types) along with its route and the decision made on it,
+4) references to other target types and whether the generator follows them.
+
+Flags for cloner, e.g. --chan-disallow, are accepted and change the decision as they do for the cloner command.
+Nothing is written to files.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: runExplain,
+}
+
+func runExplain(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	fset := cmd.Flags()
+
+	dir, buildFlags, pkg, verbose, ignoreGenerated, _, err := commonOpts(fset, false)
+	if err != nil {
+		return err
+	}
+	gen, err := fset.GetString("gen")
+	if err != nil {
+		return err
+	}
+	target, ok := explainTargets[gen]
+	if !ok {
+		return fmt.Errorf(
+			"unknown generator %q: must be one of %s",
+			gen, strings.Join(slices.Sorted(maps.Keys(explainTargets)), ", "),
+		)
+	}
+	asJSON, err := fset.GetBool("json")
+	if err != nil {
+		return err
+	}
+
+	pkgs, _, err := loadPkgs(ctx, dir, buildFlags, pkg, false, verbose, ignoreGenerated)
+	if err != nil {
+		return err
+	}
+
+	typeName := args[0]
+	obj := pkgs[0].Types.Scope().Lookup(typeName)
+	if _, isTypeName := obj.(*types.TypeName); !isTypeName {
+		return fmt.Errorf("type %s is not defined in %s", typeName, pkgs[0].PkgPath)
+	}
+
+	explanation, err := target()(pkgs, typegraph.Ident{PkgPath: pkgs[0].PkgPath, TypeName: typeName})
+	if err != nil {
+		if _, isNamed := obj.Type().(*types.Named); !isNamed {
+			return fmt.Errorf("%s is an alias or not a named type: %w", typeName, err)
+		}
+		return fmt.Errorf("%s is excluded by a directive, e.g. //%s%s: %w", typeName, cloner.DirectivePrefix, cloner.DirectiveCommentIgnore, err)
+	}
+
+	if asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(explanation)
+	}
+	return explanation.WriteText(os.Stdout)
+}
@@ -0,0 +1,162 @@
+/*
+Copyright © 2024 ngicks <yknt.bsl@gmail.com>
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/ngicks/go-codegen/codegen/generator/cloner"
+	"github.com/ngicks/go-codegen/codegen/generator/undgen"
+	"github.com/ngicks/go-codegen/codegen/internal/config"
+	"github.com/ngicks/go-codegen/codegen/pkg/typegraph"
+	"github.com/spf13/cobra"
+	"golang.org/x/tools/go/packages"
+)
+
+type graphFunc func(pkgs []*packages.Package) (*typegraph.Graph, error)
+
+var graphTargets = map[string]func() graphFunc{
+	string(config.GeneratorCloner): func() graphFunc {
+		return (&cloner.Config{MatcherConfig: clonerMatcherConfig()}).Graph
+	},
+	string(config.GeneratorUndgenPlain):     func() graphFunc { return undgen.PlainGraph },
+	string(config.GeneratorUndgenValidator): func() graphFunc { return undgen.ValidatorGraph },
+}
+
+func init() {
+	fset := graphCmd.Flags()
+	commonFlags(graphCmd, fset, true)
+	for _, name := range []string{"dry", "no-type-check", "jobs", "no-cache", "report"} {
+		_ = fset.MarkHidden(name)
+	}
+
+	fset.String(
+		"gen",
+		"",
+		"[required] the generator whose matcher builds the graph. "+
+			"one of "+strings.Join(slices.Sorted(maps.Keys(graphTargets)), ", "),
+	)
+	_ = graphCmd.MarkFlagRequired("gen")
+	fset.String("format", "dot", "output format. dot or json")
+	fset.StringSlice(
+		"from",
+		nil,
+		`a comma separated list of types. If set, only the subgraph reachable from them is printed.
+Each of them is either a type name, which matches types of that name in all loaded packages,
+or a package path and a type name joined by a dot, e.g. example.com/foo.Bar.`,
+	)
+
+	clonerFlags(fset)
+
+	rootCmd.AddCommand(graphCmd)
+}
+
+// graphCmd represents the graph command
+var graphCmd = &cobra.Command{
+	Use:   "graph [flags] --gen cloner --pkg ./...",
+	Short: "graph prints the type dependency graph built by a generator.",
+	Long: `graph builds the type dependency graph of packages in the same way as the generator specified by --gen does
+and prints it to stdout in Graphviz DOT language or JSON.
+
+Nodes are named types defined in loaded packages and external types the generator matched.
+In the DOT output, they are filled with colors based on how they are matched:
+palegreen for matched, lightblue for dependant (it refers to matched types), lightgrey for external, and white otherwise.
+Edges are directed from a type to types it refers to and are labelled with their route, e.g. "Field []*map".
+
+Flags for cloner, e.g. --chan-disallow, are accepted and change the graph as they do for the cloner command.
+
+Example:
+
+codegen graph --gen cloner --pkg ./... --from Foo | dot -Tsvg > graph.svg
+`,
+	RunE: runGraph,
+}
+
+func runGraph(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	fset := cmd.Flags()
+
+	dir, buildFlags, pkg, verbose, ignoreGenerated, _, err := commonOpts(fset, true)
+	if err != nil {
+		return err
+	}
+	gen, err := fset.GetString("gen")
+	if err != nil {
+		return err
+	}
+	target, ok := graphTargets[gen]
+	if !ok {
+		return fmt.Errorf(
+			"unknown generator %q: must be one of %s",
+			gen, strings.Join(slices.Sorted(maps.Keys(graphTargets)), ", "),
+		)
+	}
+	format, err := fset.GetString("format")
+	if err != nil {
+		return err
+	}
+	if format != "dot" && format != "json" {
+		return fmt.Errorf("unknown format %q: must be dot or json", format)
+	}
+	from, err := fset.GetStringSlice("from")
+	if err != nil {
+		return err
+	}
+
+	pkgs, _, err := loadPkgs(ctx, dir, buildFlags, pkg, true, verbose, ignoreGenerated)
+	if err != nil {
+		return err
+	}
+
+	graph, err := target()(pkgs)
+	if err != nil {
+		return err
+	}
+	if len(from) > 0 {
+		idents, err := resolveIdents(pkgs, from)
+		if err != nil {
+			return err
+		}
+		graph = graph.Subgraph(idents...)
+	}
+
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(graph)
+	}
+	return graph.WriteDOT(os.Stdout)
+}
+
+// resolveIdents converts names to idents of types defined in pkgs.
+// A name is either a type name or a package path and a type name joined by a dot.
+func resolveIdents(pkgs []*packages.Package, names []string) ([]typegraph.Ident, error) {
+	var idents []typegraph.Ident
+	for _, name := range names {
+		pkgPath, typeName := "", name
+		if i := strings.LastIndex(name, "."); i >= 0 {
+			pkgPath, typeName = name[:i], name[i+1:]
+		}
+		var found bool
+		for _, pkg := range pkgs {
+			if pkgPath != "" && pkg.PkgPath != pkgPath {
+				continue
+			}
+			if pkg.Types.Scope().Lookup(typeName) == nil {
+				continue
+			}
+			found = true
+			idents = append(idents, typegraph.Ident{PkgPath: pkg.PkgPath, TypeName: typeName})
+		}
+		if !found {
+			return nil, fmt.Errorf("type %s is not defined in loaded packages", name)
+		}
+	}
+	return idents, nil
+}
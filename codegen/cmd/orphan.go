@@ -0,0 +1,176 @@
+/*
+Copyright © 2024 ngicks <yknt.bsl@gmail.com>
+*/
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"maps"
+	"os"
+	"strings"
+
+	"github.com/ngicks/go-codegen/codegen/pkg/suffixwriter"
+	"golang.org/x/tools/go/packages"
+)
+
+// listGenerated lists files in dir that are suffixed with suffix and start with the generation notice.
+// Test files are included since generators may write them along generated files, e.g. foo.clone_test.go by cloner --test.
+func listGenerated(dir, suffix string) ([]string, error) {
+	return suffixwriter.ListSuffixed(dir, suffix, []byte(generationNotice))
+}
+
+// sourceExists reports whether the source file of the generated file name still exists.
+//
+// Packages are loaded without tests, thus generated test files are written for non-test source files;
+// the source file of foo.clone_test.go is foo.go.
+func sourceExists(name, suffix string) (bool, error) {
+	source, ok := suffixwriter.SourceFilename(name, suffix)
+	if !ok {
+		return false, nil
+	}
+	if base, isTest := strings.CutSuffix(source, "_test.go"); isTest {
+		source = base + ".go"
+	}
+	_, err := os.Stat(source)
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, fs.ErrNotExist):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// findDeadGenerated finds generated files of any generator whose source files no longer exist
+// in directories of packages matched by patterns.
+// Those files likely refer to removed types and would prevent packages from loading.
+//
+// Returned map can be passed to packages.Config.Overlay; each file is replaced with its package clause.
+func findDeadGenerated(
+	ctx context.Context,
+	dir string,
+	buildFlags []string,
+	patterns []string,
+) (map[string][]byte, error) {
+	cfg := &packages.Config{
+		Mode:       packages.NeedName | packages.NeedFiles,
+		Context:    ctx,
+		Dir:        dir,
+		BuildFlags: buildFlags,
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	dead := make(map[string][]byte)
+	for _, pkgDir := range packageDirs(pkgs) {
+		for _, out := range generatorOutputs {
+			files, err := listGenerated(pkgDir, out.suffix)
+			if err != nil {
+				return nil, err
+			}
+			for _, name := range files {
+				exists, err := sourceExists(name, out.suffix)
+				if err != nil {
+					return nil, err
+				}
+				if exists {
+					continue
+				}
+				blank, err := packageClauseOnly(name)
+				if err != nil {
+					return nil, err
+				}
+				dead[name] = blank
+			}
+		}
+	}
+	return dead, nil
+}
+
+// findOrphans finds generated files in directories of pkgs that are not in generated.
+//
+// A file is orphaned if its source file no longer exists.
+// Unless partial is true, a file whose source file exists is also orphaned
+// since the generator processed all types in the source file and found no target type.
+// partial must be true if the generator was only given part of types, e.g. undgen patch with type names.
+func findOrphans(pkgs []*packages.Package, suffix string, generated map[string][]byte, partial bool) ([]string, error) {
+	var orphans []string
+	for _, pkgDir := range packageDirs(pkgs) {
+		files, err := listGenerated(pkgDir, suffix)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range files {
+			if _, ok := generated[name]; ok {
+				continue
+			}
+			if partial {
+				exists, err := sourceExists(name, suffix)
+				if err != nil {
+					return nil, err
+				}
+				if exists {
+					continue
+				}
+			}
+			orphans = append(orphans, name)
+		}
+	}
+	return orphans, nil
+}
+
+// blankOut returns an overlay that replaces each of files with its package clause,
+// as if they were removed.
+func blankOut(files []string) (map[string][]byte, error) {
+	overlay := make(map[string][]byte, len(files))
+	for _, name := range files {
+		blank, err := packageClauseOnly(name)
+		if err != nil {
+			return nil, err
+		}
+		overlay[name] = blank
+	}
+	return overlay, nil
+}
+
+func packageClauseOnly(name string) ([]byte, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), name, nil, parser.PackageClauseOnly)
+	if err != nil {
+		return nil, err
+	}
+	return []byte("package " + f.Name.Name + "\n"), nil
+}
+
+// removeOrphans removes orphans. If dry is true, it only prints them.
+func removeOrphans(orphans []string, verbose bool, dry bool) error {
+	for _, name := range orphans {
+		if dry {
+			fmt.Printf("orphaned: %s\n", name)
+			continue
+		}
+		if verbose {
+			fmt.Printf("remove: %s\n", name)
+		}
+		if err := os.Remove(name); err != nil {
+			return fmt.Errorf("removing orphaned file: %w", err)
+		}
+	}
+	return nil
+}
+
+// mergeOverlay merges overlays into a new map. Later ones take precedence.
+func mergeOverlay(overlays ...map[string][]byte) map[string][]byte {
+	merged := make(map[string][]byte)
+	for _, overlay := range overlays {
+		maps.Copy(merged, overlay)
+	}
+	return merged
+}
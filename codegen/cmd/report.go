@@ -0,0 +1,77 @@
+/*
+Copyright © 2024 ngicks <yknt.bsl@gmail.com>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ngicks/go-codegen/codegen/pkg/genreport"
+	"github.com/ngicks/go-codegen/codegen/pkg/suffixwriter"
+	"github.com/spf13/pflag"
+	"golang.org/x/tools/go/packages"
+)
+
+func reportFlag(fset *pflag.FlagSet) {
+	fset.String(
+		"report",
+		"",
+		`If set to json, a report of what each generator did is printed to stdout as JSON after generation:
+loaded packages, every examined type with how it is matched or why it is not, and files written, skipped or removed.
+Combining with --verbose or --dry mixes their outputs with the report.`,
+	)
+}
+
+// newReport returns a report if requested by the --report flag, nil otherwise.
+func newReport(fset *pflag.FlagSet, dry bool) (*genreport.Report, error) {
+	format, err := fset.GetString("report")
+	if err != nil {
+		return nil, err
+	}
+	switch format {
+	case "":
+		return nil, nil
+	case "json":
+		return &genreport.Report{Dry: dry}, nil
+	}
+	return nil, fmt.Errorf("unknown report format %q: only json is supported", format)
+}
+
+func writeReport(report *genreport.Report) error {
+	if report == nil {
+		return nil
+	}
+	return report.WriteJSON(os.Stdout)
+}
+
+// reportFiles records source files of pkgs to run as written or skipped by looking up generated,
+// and orphans as removed.
+func reportFiles(
+	run *genreport.Run,
+	pkgs []*packages.Package,
+	suffix string,
+	generated map[string][]byte,
+	orphans []string,
+) {
+	if run == nil {
+		return
+	}
+	for _, pkg := range pkgs {
+		for _, f := range pkg.Syntax {
+			source := pkg.Fset.Position(f.FileStart).Filename
+			if suffixwriter.IsSuffixed(source, suffix) {
+				continue
+			}
+			output := suffixwriter.SuffixFilename(source, suffix)
+			if _, ok := generated[output]; ok {
+				run.AddFile(genreport.File{Source: source, Output: output, Status: genreport.FileWritten})
+			} else {
+				run.AddFile(genreport.File{Source: source, Status: genreport.FileSkipped})
+			}
+		}
+	}
+	for _, name := range orphans {
+		run.AddFile(genreport.File{Output: name, Status: genreport.FileRemoved})
+	}
+}
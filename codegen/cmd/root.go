@@ -5,6 +5,7 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"maps"
@@ -12,7 +13,9 @@ import (
 	"path/filepath"
 	"slices"
 
+	"github.com/ngicks/go-codegen/codegen/internal/config"
 	"github.com/ngicks/go-codegen/codegen/pkg/astutil"
+	"github.com/ngicks/go-codegen/codegen/pkg/genreport"
 	"github.com/ngicks/go-codegen/codegen/pkg/pkgsutil"
 	"github.com/ngicks/go-codegen/codegen/pkg/suffixwriter"
 	"github.com/spf13/cobra"
@@ -40,17 +43,42 @@ func Execute(ctx context.Context) {
 	}
 }
 
+type generatorFunc func(
+	cmd *cobra.Command,
+	writer *suffixwriter.Writer,
+	verbose bool,
+	pkgs []*packages.Package,
+	args []string,
+	report *genreport.Run,
+) error
+
+// generatorTarget is a generator along with the subcommand name and the suffix of files it generates.
+type generatorTarget struct {
+	subcommand string
+	suffix     string
+	generate   generatorFunc
+}
+
+var generatorOutputs = map[config.Generator]struct {
+	subcommand string
+	suffix     string
+}{
+	config.GeneratorCloner:          {"cloner", ".clone"},
+	config.GeneratorUndgenPatch:     {"undgen patch", ".und_patch"},
+	config.GeneratorUndgenPlain:     {"undgen plain", ".und_plain"},
+	config.GeneratorUndgenValidator: {"undgen validator", ".und_validator"},
+}
+
+func targetOf(g config.Generator, generate generatorFunc) generatorTarget {
+	out := generatorOutputs[g]
+	return generatorTarget{subcommand: out.subcommand, suffix: out.suffix, generate: generate}
+}
+
 func runCommand(
 	name string,
 	suffix string,
 	multiplePkg bool,
-	command func(
-		cmd *cobra.Command,
-		writer *suffixwriter.Writer,
-		verbose bool,
-		pkgs []*packages.Package,
-		args []string,
-	) error,
+	command generatorFunc,
 ) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
 		ctx := cmd.Context()
@@ -62,16 +90,61 @@ func runCommand(
 		if err != nil {
 			return err
 		}
+		noTypeCheck, err := fset.GetBool("no-type-check")
+		if err != nil {
+			return err
+		}
+		jobs, err := fset.GetInt("jobs")
+		if err != nil {
+			return err
+		}
+		report, err := newReport(fset, dry)
+		if err != nil {
+			return err
+		}
 
-		pkgs, err := loadPkgs(ctx, dir, buildFlags, pkg, multiplePkg, verbose, ignoreGenerated)
+		pkgs, dead, err := loadPkgs(ctx, dir, buildFlags, pkg, multiplePkg, verbose, ignoreGenerated)
 		if err != nil {
 			return err
 		}
+		run := report.NewRun(name)
+		run.AddPackages(pkgs)
 
-		writer, deferred := createWriter(dir, suffix, name, verbose, dry)
+		writer, overlay, commit, deferred := createWriter(dir, suffix, name, verbose, dry, jobs)
 		defer deferred()
 
-		return command(cmd, writer, verbose, pkgs, args)
+		err = command(cmd, writer, verbose, pkgs, args, run)
+		if err != nil {
+			return err
+		}
+
+		generated := overlay()
+		// undgen patch only regenerates types specified by args.
+		partial := suffix == ".und_patch" && !slices.Equal(args, []string{"..."})
+		orphans, err := findOrphans(pkgs, suffix, generated, partial)
+		if err != nil {
+			return err
+		}
+
+		if !noTypeCheck {
+			removed, err := blankOut(orphans)
+			if err != nil {
+				return err
+			}
+			err = typeCheckGenerated(ctx, dir, buildFlags, pkg, verbose, mergeOverlay(dead, removed, generated))
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := commit(); err != nil {
+			return err
+		}
+		if err := removeOrphans(orphans, verbose, dry); err != nil {
+			return err
+		}
+		reportFiles(run, pkgs, suffix, generated, orphans)
+		return writeReport(report)
 	}
 }
 
@@ -102,6 +175,26 @@ If set, the type checker ignores ast nodes with comment //codegen:generated atta
 Useful for internal debugging. `,
 	)
 	fset.Bool("dry", false, "enables dry run mode. any files will not be removed nor generated.")
+	fset.Bool(
+		"no-type-check",
+		false,
+		`If set, generated code is written without being type-checked.
+By default, generated code is kept in memory and type-checked with target packages
+then written to files only if no type error is found.`,
+	)
+	jobsFlag(fset)
+	noCacheFlag(fset)
+	reportFlag(fset)
+}
+
+func jobsFlag(fset *pflag.FlagSet) {
+	fset.IntP(
+		"jobs",
+		"j",
+		0,
+		`the maximum number of files generated and formatted concurrently.
+0 or less means runtime.GOMAXPROCS(0). Output does not depend on this value.`,
+	)
 }
 
 func commonOpts(fset *pflag.FlagSet, multiplePkg bool) (dir string, buildFlags []string, pkg []string, verbose bool, ignoreGenerated bool, dry bool, err error) {
@@ -170,6 +263,9 @@ func commonOpts(fset *pflag.FlagSet, multiplePkg bool) (dir string, buildFlags [
 	return
 }
 
+// loadPkgs loads packages matched by pkg.
+// Generated files whose source file is removed are ignored as if they were also removed;
+// they are returned as dead, an overlay that replaces each of them with its package clause.
 func loadPkgs(
 	ctx context.Context,
 	dir string,
@@ -178,7 +274,18 @@ func loadPkgs(
 	multiplePkg bool,
 	verbose bool,
 	ignoreGenerated bool,
-) ([]*packages.Package, error) {
+) (targetPkgs []*packages.Package, dead map[string][]byte, err error) {
+	dead, err = findDeadGenerated(ctx, dir, buildFlags, pkg)
+	if err != nil {
+		return nil, nil, err
+	}
+	if verbose && len(dead) > 0 {
+		fmt.Printf("ignoring generated files whose source file is removed:\n")
+		for _, name := range slices.Sorted(maps.Keys(dead)) {
+			fmt.Printf("\t%s\n", name)
+		}
+	}
+
 	cfg := &packages.Config{
 		Mode: packages.NeedName |
 			packages.NeedImports |
@@ -190,6 +297,7 @@ func loadPkgs(
 		Context:    ctx,
 		Dir:        dir,
 		BuildFlags: buildFlags,
+		Overlay:    dead,
 	}
 	if verbose {
 		cfg.Logf = func(format string, args ...interface{}) {
@@ -200,12 +308,12 @@ func loadPkgs(
 		cfg.ParseFile = astutil.NewParser(cfg.Dir).ParseFile
 	}
 
-	targetPkgs, err := packages.Load(cfg, pkg...)
+	targetPkgs, err = packages.Load(cfg, pkg...)
 	if err != nil {
-		return targetPkgs, err
+		return targetPkgs, dead, err
 	}
 	if err := pkgsutil.CheckLoadError(targetPkgs); err != nil {
-		return targetPkgs, err
+		return targetPkgs, dead, err
 	}
 
 	if verbose {
@@ -220,23 +328,73 @@ func loadPkgs(
 	}
 
 	if len(targetPkgs) == 0 {
-		return targetPkgs, fmt.Errorf("package not loaded: wrong import pattern?")
+		return targetPkgs, dead, fmt.Errorf("package not loaded: wrong import pattern?")
 	}
 	if !multiplePkg && len(targetPkgs) >= 2 {
-		return targetPkgs, fmt.Errorf("loaded more than a package: must be single")
+		return targetPkgs, dead, fmt.Errorf("loaded more than a package: must be single")
 	}
 
-	return targetPkgs, nil
+	return targetPkgs, dead, nil
 }
 
-func createWriter(dir string, suffix string, subcommand string, verbose bool, dry bool) (writer *suffixwriter.Writer, deferred func()) {
+func typeCheckGenerated(
+	ctx context.Context,
+	dir string,
+	buildFlags []string,
+	pkg []string,
+	verbose bool,
+	overlay map[string][]byte,
+) error {
+	if verbose {
+		fmt.Printf("type-checking generated code: len(files) == %d\n", len(overlay))
+	}
+
+	cfg := &packages.Config{
+		Context:    ctx,
+		Dir:        dir,
+		BuildFlags: buildFlags,
+	}
+	typeErrs, err := pkgsutil.TypeCheckOverlay(cfg, overlay, pkg...)
+	if err != nil {
+		return fmt.Errorf("type-checking generated code: %w", err)
+	}
+	if len(typeErrs) == 0 {
+		return nil
+	}
+
+	errs := make([]error, len(typeErrs))
+	for i, e := range typeErrs {
+		errs[i] = e
+	}
+	return fmt.Errorf("generated code has type errors, nothing is written:\n%w", errors.Join(errs...))
+}
+
+// generationPrefix returns the header placed on top of every file generated by subcommand.
+func generationPrefix(subcommand string) []byte {
+	return []byte(
+		generationNotice +
+			"// to regenerate the code, refer to help by invoking\n" +
+			"// go run github.com/ngicks/go-codegen/codegen " + subcommand + " --help\n\n",
+	)
+}
+
+func createWriter(
+	dir string,
+	suffix string,
+	subcommand string,
+	verbose bool,
+	dry bool,
+	jobs int,
+) (
+	writer *suffixwriter.Writer,
+	overlay func() map[string][]byte,
+	commit func() error,
+	deferred func(),
+) {
 	writerOpts := []suffixwriter.Option{
 		suffixwriter.WithCwd(dir),
-		suffixwriter.WithPrefix([]byte(
-			generationNotice +
-				"// to regenerate the code, refer to help by invoking\n" +
-				"// go run github.com/ngicks/go-codegen/codegen " + subcommand + " --help\n\n",
-		)),
+		suffixwriter.WithPrefix(generationPrefix(subcommand)),
+		suffixwriter.WithJobs(jobs),
 	}
 	if verbose {
 		writerOpts = append(
@@ -249,12 +407,11 @@ func createWriter(dir string, suffix string, subcommand string, verbose bool, dr
 		)
 	}
 
-	writer = suffixwriter.New(suffix, writerOpts...)
-	deferred = func() {}
-
 	if dry {
 		testWriter := suffixwriter.NewTestWriter(suffix, writerOpts...)
 		writer = testWriter.Writer
+		overlay = testWriter.Results
+		commit = func() error { return nil }
 		deferred = func() {
 			results := testWriter.Results()
 			fmt.Printf("generated result:\n")
@@ -263,7 +420,13 @@ func createWriter(dir string, suffix string, subcommand string, verbose bool, dr
 				fmt.Printf("%q:\n\n%s\n\n\nj", k, result)
 			}
 		}
+		return
 	}
 
+	overlayWriter := suffixwriter.NewOverlayWriter(suffix, writerOpts...)
+	writer = overlayWriter.Writer
+	overlay = overlayWriter.Overlay
+	commit = overlayWriter.Commit
+	deferred = func() {}
 	return
 }
@@ -0,0 +1,321 @@
+/*
+Copyright © 2024 ngicks <yknt.bsl@gmail.com>
+*/
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+
+	"github.com/ngicks/go-codegen/codegen/generator/cloner"
+	"github.com/ngicks/go-codegen/codegen/generator/undgen"
+	"github.com/ngicks/go-codegen/codegen/internal/config"
+	"github.com/ngicks/go-codegen/codegen/pkg/genreport"
+	"github.com/ngicks/go-codegen/codegen/pkg/pkgsutil"
+	"github.com/ngicks/go-codegen/codegen/pkg/suffixwriter"
+	"github.com/spf13/cobra"
+	"golang.org/x/tools/go/packages"
+)
+
+func init() {
+	fset := runCmd.Flags()
+	fset.StringP(
+		"config",
+		"c",
+		config.DefaultFilename,
+		`path to the config file.
+Package patterns in the config file are evaluated under the directory where the file is placed.`,
+	)
+	fset.BoolP("verbose", "v", false, "verbose logs")
+	fset.Bool(
+		"ignore-generated",
+		false,
+		`You do not need this option.
+If set, the type checker ignores ast nodes with comment //codegen:generated attached.
+Useful for internal debugging. `,
+	)
+	fset.Bool("dry", false, "enables dry run mode. any files will not be removed nor generated.")
+	fset.Bool(
+		"no-type-check",
+		false,
+		`If set, generated code is written without being type-checked.
+By default, generated code is kept in memory and type-checked with target packages
+then written to files only if no type error is found.`,
+	)
+	jobsFlag(fset)
+	noCacheFlag(fset)
+	reportFlag(fset)
+	rootCmd.AddCommand(runCmd)
+}
+
+// runCmd represents the run command
+var runCmd = &cobra.Command{
+	Use:   "run [flags]",
+	Short: "run runs every generator job declared in the config file.",
+	Long: `run runs every generator job declared in the config file, codegen.yaml by default.
+
+Packages matched by jobs are loaded only once per distinct set of build flags,
+by a single "golang.org/x/tools/go/packages".Load call for each,
+then each job runs its generator against the packages matched by its own patterns.
+Outputs of all jobs are type-checked together, with each set of build flags,
+then written to files only if no type error is found.
+
+Example codegen.yaml:
+
+build-flags: ["-tags", "integration"]
+jobs:
+  - generator: cloner          # one of cloner, undgen-patch, undgen-plain, undgen-validator
+    pkg: ["./..."]             # patterns relative to the directory where codegen.yaml is placed.
+    cloner:                    # equivalent of flags of the cloner command.
+      no-copy: copy            # ignore, disallow or copy
+      chan: disallow           # ignore, disallow, copy or make
+      func: copy               # ignore, disallow or copy
+      interface: copy          # ignore, copy or clone
+      atomic: clone            # ignore, disallow, copy or clone. handled as no-copy if empty
+      reflect: true            # clones values which can not be cloned statically by reflection
+      equal: true              # also generates Equal methods
+      memo: true               # also generates CloneWithMemo methods
+      into: true               # also generates CloneInto methods
+      test: true               # also generates fuzz tests and benchmarks of clone methods
+      handlers:                # custom handlers, which take precedence over built-in ones.
+        - type: github.com/shopspring/decimal.Decimal
+          clone: assign        # assign or a function, e.g. example.com/mypkg.CloneDecimal
+  - generator: undgen-plain
+    pkg: ["./types/..."]
+    build-flags: ["-tags", "linux"] # overrides the top level build-flags for this job.
+  - generator: undgen-patch
+    pkg: ["./types/..."]
+    types: [User, Group]       # or ["..."] to generate for all types. names can be qualified, e.g. example.com/types.User.
+    patch:
+      deep: true               # patches nested struct fields by their patch types.
+      json-patch: true         # also generates ToJSONPatch and FromJSONPatch methods, and Diff<Type> functions.
+      diff: true               # also generates Diff<Type> functions.
+`,
+	RunE: runRun,
+}
+
+func runRun(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	fset := cmd.Flags()
+
+	configPath, err := fset.GetString("config")
+	if err != nil {
+		return err
+	}
+	configPath, err = filepath.Abs(configPath)
+	if err != nil {
+		return err
+	}
+	verbose, err := fset.GetBool("verbose")
+	if err != nil {
+		return err
+	}
+	ignoreGenerated, err := fset.GetBool("ignore-generated")
+	if err != nil {
+		return err
+	}
+	dry, err := fset.GetBool("dry")
+	if err != nil {
+		return err
+	}
+	noTypeCheck, err := fset.GetBool("no-type-check")
+	if err != nil {
+		return err
+	}
+	jobs, err := fset.GetInt("jobs")
+	if err != nil {
+		return err
+	}
+	report, err := newReport(fset, dry)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(configPath)
+	groups := cfg.LoadGroups()
+
+	var (
+		dead    = make(map[string][]byte)
+		jobPkgs = make([][]*packages.Package, len(cfg.Jobs))
+	)
+	for _, group := range groups {
+		if verbose {
+			fmt.Printf("loading packages: build flags %q: jobs %v\n", group.BuildFlags, group.Jobs)
+		}
+		pkgs, groupDead, err := loadPkgs(ctx, dir, group.BuildFlags, group.Patterns, true, verbose, ignoreGenerated)
+		if err != nil {
+			return err
+		}
+		maps.Copy(dead, groupDead)
+		for _, i := range group.Jobs {
+			jobPkgs[i] = pkgsutil.FilterByPattern(pkgs, dir, cfg.Jobs[i].Pkg...)
+		}
+	}
+
+	var (
+		overlays    []func() map[string][]byte
+		commits     []func() error
+		orphans     []string
+		reportFuncs []func()
+	)
+	for i, job := range cfg.Jobs {
+		jobPkgs := jobPkgs[i]
+		if len(jobPkgs) == 0 {
+			return fmt.Errorf("jobs[%d]: %s: no package matched to %v", i, job.Generator, job.Pkg)
+		}
+		target, err := jobTarget(job)
+		if err != nil {
+			return fmt.Errorf("jobs[%d]: %s: %w", i, job.Generator, err)
+		}
+
+		if verbose {
+			fmt.Printf("running jobs[%d]: %s: len(pkgs) == %d\n", i, job.Generator, len(jobPkgs))
+		}
+
+		writer, overlay, commit, deferred := createWriter(dir, target.suffix, target.subcommand, verbose, dry, jobs)
+		defer deferred()
+
+		run := report.NewRun(string(job.Generator))
+		run.AddPackages(jobPkgs)
+
+		err = target.generate(cmd, writer, verbose, jobPkgs, job.Types, run)
+		if err != nil {
+			return fmt.Errorf("jobs[%d]: %s: %w", i, job.Generator, err)
+		}
+		partial := job.Generator == config.GeneratorUndgenPatch && !slices.Equal(job.Types, []string{"..."})
+		jobOrphans, err := findOrphans(jobPkgs, target.suffix, overlay(), partial)
+		if err != nil {
+			return err
+		}
+
+		overlays = append(overlays, overlay)
+		commits = append(commits, commit)
+		orphans = append(orphans, jobOrphans...)
+		reportFuncs = append(reportFuncs, func() {
+			// orphans are finalized after all jobs run.
+			jobOrphans = slices.DeleteFunc(jobOrphans, func(name string) bool {
+				_, found := slices.BinarySearch(orphans, name)
+				return !found
+			})
+			reportFiles(run, jobPkgs, target.suffix, overlay(), jobOrphans)
+		})
+	}
+
+	merged := make(map[string][]byte)
+	for _, overlay := range overlays {
+		for name, content := range overlay() {
+			if _, ok := merged[name]; ok {
+				return fmt.Errorf("%q is generated by more than one job: patterns of jobs for a same generator must not overlap", name)
+			}
+			merged[name] = content
+		}
+	}
+
+	slices.Sort(orphans)
+	orphans = slices.Compact(orphans)
+	// a file orphaned for a job may be generated by another job
+	orphans = slices.DeleteFunc(orphans, func(name string) bool {
+		_, ok := merged[name]
+		return ok
+	})
+
+	if !noTypeCheck {
+		removed, err := blankOut(orphans)
+		if err != nil {
+			return err
+		}
+		overlay := mergeOverlay(dead, removed, merged)
+		for _, group := range groups {
+			err = typeCheckGenerated(ctx, dir, group.BuildFlags, group.Patterns, verbose, overlay)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, commit := range commits {
+		if err := commit(); err != nil {
+			return err
+		}
+	}
+	if err := removeOrphans(orphans, verbose, dry); err != nil {
+		return err
+	}
+	for _, f := range reportFuncs {
+		f()
+	}
+	return writeReport(report)
+}
+
+// jobTarget converts job into a generatorTarget.
+// args passed to the returned target are job.Types.
+func jobTarget(job config.Job) (generatorTarget, error) {
+	switch job.Generator {
+	case config.GeneratorCloner:
+		matcherConfig, err := job.Cloner.MatcherConfig()
+		if err != nil {
+			return generatorTarget{}, err
+		}
+		return targetOf(
+			job.Generator,
+			func(
+				cmd *cobra.Command,
+				writer *suffixwriter.Writer,
+				verbose bool,
+				pkgs []*packages.Package,
+				args []string,
+				report *genreport.Run,
+			) error {
+				return runCloner(
+					cmd,
+					writer,
+					verbose,
+					pkgs,
+					cloner.Config{
+						MatcherConfig: matcherConfig,
+						GenerateEqual: job.Cloner.GenerateEqual(),
+						Memo:          job.Cloner.GenerateMemo(),
+						GenerateInto:  job.Cloner.GenerateInto(),
+						GenerateTest:  job.Cloner.GenerateTest(),
+					},
+					report,
+				)
+			},
+		), nil
+	case config.GeneratorUndgenPatch:
+		return targetOf(
+			job.Generator,
+			func(
+				cmd *cobra.Command,
+				writer *suffixwriter.Writer,
+				verbose bool,
+				pkgs []*packages.Package,
+				args []string,
+				report *genreport.Run,
+			) error {
+				return undgen.GeneratePatcher(
+					writer,
+					verbose,
+					pkgs,
+					undgen.ConstUnd.Imports,
+					args,
+					undgen.WithDeepPatch(job.Patch.GenerateDeep()),
+					undgen.WithJSONPatch(job.Patch.GenerateJSONPatch()),
+					undgen.WithDiff(job.Patch.GenerateDiff()),
+					undgen.WithReport(report),
+				)
+			},
+		), nil
+	case config.GeneratorUndgenPlain:
+		return targetOf(job.Generator, generateUndPlain), nil
+	case config.GeneratorUndgenValidator:
+		return targetOf(job.Generator, generateUndValidator), nil
+	}
+	return generatorTarget{}, fmt.Errorf("unknown generator %q", job.Generator)
+}
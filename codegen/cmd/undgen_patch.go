@@ -5,22 +5,42 @@ package cmd
 
 import (
 	"github.com/ngicks/go-codegen/codegen/generator/undgen"
+	"github.com/ngicks/go-codegen/codegen/pkg/genreport"
 	"github.com/ngicks/go-codegen/codegen/pkg/suffixwriter"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"golang.org/x/tools/go/packages"
 )
 
+var (
+	deepPatch bool
+	jsonPatch bool
+	diffPatch bool
+)
+
 func init() {
 	fset := undgenPatchCmd.Flags()
-	commonFlags(undgenPatchCmd, fset, false)
+	commonFlags(undgenPatchCmd, fset, true)
+	patchFlags(fset)
 	undgenCmd.AddCommand(undgenPatchCmd)
 }
 
+func patchFlags(fset *pflag.FlagSet) {
+	fset.BoolVar(&deepPatch, "deep", false, "enables deep patches. Struct fields whose type is also a target are patched by the patch type of the field type instead of being replaced as a whole.")
+	fset.BoolVar(&diffPatch, "diff", false, "also generates Diff<Type> functions which compute patches setting only fields that differ between 2 values.")
+	fset.BoolVar(&jsonPatch, "json-patch", false, "also generates ToJSONPatch and FromJSONPatch methods which convert patches from and to JSON Patch (RFC 6902) documents.")
+}
+
+// patchOptions builds options for undgen.GeneratePatcher from flags defined by patchFlags.
+func patchOptions() []undgen.Option {
+	return []undgen.Option{undgen.WithDeepPatch(deepPatch), undgen.WithJSONPatch(jsonPatch), undgen.WithDiff(diffPatch)}
+}
+
 // undgenPatchCmd represents the patch command
 var undgenPatchCmd = &cobra.Command{
 	Use:   "patch [flags] types...",
 	Short: "undgen-patch generates patcher types based on target types.",
-	Long: `undgen-patch generates patcher types base on target types defined in a target package.
+	Long: `undgen-patch generates patcher types base on target types defined in target packages.
 
 The generation target types are specified as cli argument. e.g.
 
@@ -32,25 +52,73 @@ codegen undgen patch --pkg ./path/to/package ...
 
 to generate for all types found in the package.
 
+--pkg can be specified multiple times and accepts patterns like ./... .
+A bare type name matches types of that name in every matched package.
+To narrow it down to a single package, qualify the name with the package path, e.g.
+
+codegen undgen patch --pkg ./... example.com/foo/types.TypeA
+
 A patch is basically same type as target but name is suffixed with Patch and all fields are wrapped in sliceund.Und[T].
 If each field that is already a und type, namely one of und.Und[T], sliceund.Und[T], elastic.Elastic[T], sliceelastic.Elastic[T].
 option.Option[T] will be widened to be sliceund.Und[T].
 
 All generated code will be written along the source code in which the target type is defined.
 Generated files are suffixed with und_patch before file extension, i.e. <original_source_filename>.und_patch.go.
+
+With --deep, a field whose type is a struct type also being a target, e.g. Address of User, is wrapped as sliceund.Und[AddressPatch].
+Merge and ApplyPatch recurse into such fields, so a patch can change only User.Address.City.
+Fields of pointer, slice or map types are replaced as a whole regardless of --deep.
+
+Patch types are JSON Merge Patch (RFC 7396) documents as they are when marshaled or unmarshaled by encoding/json.
+Nested objects are merged only with --deep.
+With --json-patch, ToJSONPatch and FromJSONPatch methods are also generated.
+They convert patches from and to JSON Patch (RFC 6902) documents
+using types of github.com/ngicks/go-codegen/pkg/undgen/runtime/jsonpatch.
+Defined and null fields become add operations, which create or replace members.
+Only add, remove and replace operations on fields are supported; move, copy and test are rejected.
+--json-patch implies --diff, so that Diff<Type>(before, after).ToJSONPatch() produces a JSON Patch document.
+Paths pointing inside a field are allowed only for fields patched by --deep.
+
+With --diff, func Diff<Type>(before, after <Type>) <Type>Patch is also generated.
+It returns a patch which sets only fields that differ between before and after.
+Fields are compared by their Equal method if they have one of the signature func(T) bool, e.g. time.Time or ones generated by cloner --equal,
+by == if they can be compared without panicking, or by reflect.DeepEqual otherwise.
+Fields patched by --deep are set to the diff of nested values.
+Fields tagged with patch:"-" are skipped. Fields tagged with patch:"readonly" are set if they differ,
+thus CheckReadOnly and ApplyPatchChecked reject the patch.
+Since und.Und, elastic.Elastic and alike fields are kept as they are in patches,
+a change from defined or null to undefined of these fields can not be expressed.
+
+Fields may have policies in patch struct tags. Options are comma separated, e.g. patch:"nonnull,readonly".
+  - patch:"-": the field is omitted from the patch type. ApplyPatch keeps the value of the field as is.
+  - patch:"nonnull": UndValidate() error is generated on the patch type. It returns an error if the field is null.
+  - patch:"readonly": CheckReadOnly() error is generated on the patch type. It returns an error wrapping ErrReadOnly of github.com/ngicks/go-codegen/pkg/undgen/runtime/patchpolicy if the field is defined or null.
+    ApplyPatchChecked(v <Type>) (<Type>, error) is also generated. It fails with the error of CheckReadOnly before applying the patch.
+    ApplyPatch does not check policies and applies read-only fields as well; use ApplyPatchChecked for untrusted patches.
+Policies of fields patched by --deep are checked recursively.
 `,
 	RunE: runCommand(
 		"undgen patch",
 		".und_patch",
-		false,
-		func(
-			cmd *cobra.Command,
-			writer *suffixwriter.Writer,
-			verbose bool,
-			pkgs []*packages.Package,
-			args []string,
-		) error {
-			return undgen.GeneratePatcher(writer, verbose, pkgs[0], undgen.ConstUnd.Imports, args...)
-		},
+		true,
+		generateUndPatch,
 	),
 }
+
+func generateUndPatch(
+	cmd *cobra.Command,
+	writer *suffixwriter.Writer,
+	verbose bool,
+	pkgs []*packages.Package,
+	args []string,
+	report *genreport.Run,
+) error {
+	return undgen.GeneratePatcher(
+		writer,
+		verbose,
+		pkgs,
+		undgen.ConstUnd.Imports,
+		args,
+		append(patchOptions(), undgen.WithReport(report))...,
+	)
+}
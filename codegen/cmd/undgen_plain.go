@@ -5,6 +5,7 @@ package cmd
 
 import (
 	"github.com/ngicks/go-codegen/codegen/generator/undgen"
+	"github.com/ngicks/go-codegen/codegen/pkg/genreport"
 	"github.com/ngicks/go-codegen/codegen/pkg/suffixwriter"
 	"github.com/spf13/cobra"
 	"golang.org/x/tools/go/packages"
@@ -25,14 +26,24 @@ var undgenPlainCmd = &cobra.Command{
 		"undgen plain",
 		".und_plain",
 		true,
-		func(
-			cmd *cobra.Command,
-			writer *suffixwriter.Writer,
-			verbose bool,
-			pkgs []*packages.Package,
-			args []string,
-		) error {
-			return undgen.GeneratePlain(writer, verbose, pkgs, undgen.ConstUnd.Imports)
-		},
+		generateUndPlain,
 	),
 }
+
+func generateUndPlain(
+	cmd *cobra.Command,
+	writer *suffixwriter.Writer,
+	verbose bool,
+	pkgs []*packages.Package,
+	args []string,
+	report *genreport.Run,
+) error {
+	return undgen.GeneratePlain(
+		writer,
+		verbose,
+		pkgs,
+		undgen.ConstUnd.Imports,
+		undgen.WithCache(openCache(cmd, "undgen plain", "")),
+		undgen.WithReport(report),
+	)
+}
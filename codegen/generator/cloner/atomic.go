@@ -0,0 +1,119 @@
+package cloner
+
+import (
+	"errors"
+	"fmt"
+	"go/types"
+
+	"github.com/ngicks/go-codegen/codegen/pkg/imports"
+	"github.com/ngicks/go-codegen/codegen/pkg/typegraph"
+)
+
+// isAtomic reports whether named is one of sync/atomic types which can be cloned by Load and Store.
+func isAtomic(named *types.Named) bool {
+	if named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != "sync/atomic" {
+		return false
+	}
+	switch named.Obj().Name() {
+	case "Bool", "Int32", "Int64", "Uint32", "Uint64", "Uintptr", "Value", "Pointer":
+		return true
+	}
+	return false
+}
+
+// cloneAtomic returns a cloner expression for values of the atomic type unwrapped, or pointers to them if leafTy is a pointer.
+// Values are read by Load and written to new ones by Store, since copying them is a data race.
+// Pointees of atomic.Pointer[T] are cloned if T can be cloned, otherwise the pointer is stored as is.
+//
+// Values are addressed rather than passed since the cloner expression for values is not callable.
+func cloneAtomic(
+	c *Config,
+	pkgPath string,
+	importMap imports.ImportMap,
+	g *typegraph.Graph,
+	unwrapped types.Type,
+	leafTy types.Type,
+	cloneCallbacks [][2]string,
+) (cloneExpr func(s string) string, callable bool, err error) {
+	named := types.Unalias(unwrapped).(*types.Named)
+	ty := types.TypeString(named, importMap.Qualifier(pkgPath))
+
+	store := "dst.Store(src.Load())"
+	switch named.Obj().Name() {
+	case "Value":
+		// Store panics for nil.
+		store = `if x := src.Load(); x != nil {
+			dst.Store(x)
+		}`
+	case "Pointer":
+		elem := types.NewPointer(named.TypeArgs().At(0))
+		var child *typegraph.Node
+		_ = typegraph.TraverseTypes(
+			elem,
+			nil,
+			func(ty types.Type, _ *types.Named, _ []typegraph.EdgeRouteNode) error {
+				child, _ = g.GetByType(ty)
+				return nil
+			},
+			nil,
+		)
+		expr, callable, err := cloneTy(c, pkgPath, importMap, g, nil, child, -1, elem, cloneCallbacks)
+		switch {
+		case err == nil && callable:
+			store = "p := src.Load()\ndst.Store(" + expr("p") + "(p))"
+		case err == nil:
+			store = "p := src.Load()\ndst.Store(" + expr("p") + ")"
+		case errors.Is(err, errParamNotOk):
+			return nil, false, err
+		}
+	}
+
+	if _, isPointer := types.Unalias(leafTy).Underlying().(*types.Pointer); isPointer {
+		expr := fmt.Sprintf(
+			`func(src *%[1]s) *%[1]s {
+				if src == nil {
+					return nil
+				}
+				dst := new(%[1]s)
+				%[2]s
+				return dst
+			}`,
+			ty, store,
+		)
+		return func(string) string { return expr }, true, nil
+	}
+	return func(s string) string {
+		return fmt.Sprintf(
+			`func(src *%[1]s) (dst %[1]s) {
+				%[2]s
+				return
+			}(&%[3]s)`,
+			ty, store, s,
+		)
+	}, false, nil
+}
+
+// equalAtomic returns a function building a boolean expression that compares loaded values of x and y,
+// values of the atomic type unwrapped or pointers to them if leafTy is a pointer.
+func equalAtomic(pkgPath string, importMap imports.ImportMap, unwrapped types.Type, leafTy types.Type) func(x, y string) string {
+	compare := func(x, y string) string { return x + ".Load() == " + y + ".Load()" }
+	switch types.Unalias(unwrapped).(*types.Named).Obj().Name() {
+	case "Value", "Pointer":
+		deepEqual := deepEqual(importMap)
+		compare = func(x, y string) string { return deepEqual(x+".Load()", y+".Load()") }
+	}
+	if _, isPointer := types.Unalias(leafTy).Underlying().(*types.Pointer); isPointer {
+		return func(x, y string) string {
+			return fmt.Sprintf(
+				`func(x, y %s) bool {
+					if x == nil || y == nil {
+						return x == y
+					}
+					return %s
+				}(%s, %s)`,
+				types.TypeString(leafTy, importMap.Qualifier(pkgPath)), compare("x", "y"), x, y,
+			)
+		}
+	}
+	return compare
+}
@@ -21,6 +21,19 @@ func (h CustomHandlers) Match(ty types.Type) int {
 	return -1
 }
 
+// Names returns non empty names of handlers.
+func (h CustomHandlers) Names() []string {
+	return slices.Collect(
+		hiter.Filter(
+			func(name string) bool { return name != "" },
+			hiter.Map(
+				func(h CustomHandler) string { return h.Name },
+				slices.Values(h),
+			),
+		),
+	)
+}
+
 func (h CustomHandlers) Imports() []imports.TargetImport {
 	return slices.Collect(
 		hiter.Flatten(
@@ -35,15 +48,27 @@ func (h CustomHandlers) Imports() []imports.TargetImport {
 }
 
 type CustomHandler struct {
+	// Name, if non empty, describes the handler.
+	// Names of handlers are part of the cache key, thus handlers of same name must generate same code.
+	Name    string
 	Matcher func(types.Type) bool
 	Imports []imports.TargetImport
 	Expr    func(CustomHandlerExprData) (expr func(s string) (expr string), isFunc bool)
+	// Equal, if non nil, returns a function building a boolean expression that compares x and y.
+	// It is used for Equal methods. If nil, values are compared by reflect.DeepEqual.
+	Equal func(CustomHandlerExprData) (expr func(x, y string) string)
+	// Into, if non nil, returns a function building statements that clone src into dst
+	// reusing memory dst already has. It is used for CloneInto methods.
+	// If nil, dst is assigned the expression Expr builds.
+	Into func(CustomHandlerExprData) (stmt func(dst, src string) string)
 }
 
 type CustomHandlerExprData struct {
 	ImportMap imports.ImportMap
 	PkgPath   string
 	Ty        types.Type
+	// NilEqualsEmpty is set for Equal if nil and empty slices or maps should be treated as equal.
+	NilEqualsEmpty bool
 }
 
 var builtinCustomHandlers = [...]CustomHandler{
@@ -82,6 +107,26 @@ var builtinCustomHandlers = [...]CustomHandler{
 					}`, types.TypeString(data.Ty, data.ImportMap.Qualifier(data.PkgPath)))
 			}, true
 		},
+		Equal: func(data CustomHandlerExprData) (expr func(x, y string) string) {
+			return equalCollection(data, "slices", data.Ty.(*types.Slice).Elem())
+		},
+		Into: func(data CustomHandlerExprData) (stmt func(dst, src string) string) {
+			return func(dst, src string) string {
+				return fmt.Sprintf(
+					`if %[1]s == nil {
+						%[2]s = nil
+					} else {
+						if %[2]s == nil || cap(%[2]s) < len(%[1]s) {
+							%[2]s = make(%[3]s, len(%[1]s), cap(%[1]s))
+						} else {
+							%[2]s = %[2]s[:len(%[1]s)]
+						}
+						copy(%[2]s, %[1]s)
+					}`,
+					src, dst, types.TypeString(data.Ty, data.ImportMap.Qualifier(data.PkgPath)),
+				)
+			}
+		},
 	},
 	{
 		// calls maps.Clone on basic map type.
@@ -105,6 +150,27 @@ var builtinCustomHandlers = [...]CustomHandler{
 				return ident + ".Clone"
 			}, true
 		},
+		Equal: func(data CustomHandlerExprData) (expr func(x, y string) string) {
+			return equalCollection(data, "maps", data.Ty.(*types.Map).Elem())
+		},
+		Into: func(data CustomHandlerExprData) (stmt func(dst, src string) string) {
+			return func(dst, src string) string {
+				ident, _ := data.ImportMap.Ident("maps")
+				return fmt.Sprintf(
+					`if %[1]s == nil {
+						%[2]s = nil
+					} else {
+						if %[2]s == nil {
+							%[2]s = make(%[3]s, len(%[1]s))
+						} else {
+							clear(%[2]s)
+						}
+						%[4]s.Copy(%[2]s, %[1]s)
+					}`,
+					src, dst, types.TypeString(data.Ty, data.ImportMap.Qualifier(data.PkgPath)), ident,
+				)
+			}
+		},
 	},
 	{
 		// clones time but strips monotonic timer.
@@ -134,6 +200,12 @@ var builtinCustomHandlers = [...]CustomHandler{
 					}`, tok, ident)
 			}, true
 		},
+		Equal: func(data CustomHandlerExprData) (expr func(x, y string) string) {
+			// compares instants as Clone strips monotonic clock readings.
+			return func(x, y string) string {
+				return x + ".Equal(" + y + ")"
+			}
+		},
 	},
 	{
 		// clones *big.Int, *big.Rat, *big.Float, *big.Uint
@@ -169,6 +241,9 @@ var builtinCustomHandlers = [...]CustomHandler{
 				if name == "Rat" {
 					return fmt.Sprintf(
 						`func(%[1]s *%[2]s.%[3]s) *%[2]s.%[3]s {
+						if v == nil {
+							return nil
+						}
 						new := %[2]s.New%[3]s(0, 1)
 						new.Set(v)
 						return new
@@ -176,6 +251,9 @@ var builtinCustomHandlers = [...]CustomHandler{
 				} else {
 					return fmt.Sprintf(
 						`func(%[1]s *%[2]s.%[3]s) *%[2]s.%[3]s {
+						if v == nil {
+							return nil
+						}
 						new := %[2]s.New%[3]s(0)
 						new.Set(v)
 						return new
@@ -183,6 +261,19 @@ var builtinCustomHandlers = [...]CustomHandler{
 				}
 			}, true
 		},
+		Equal: func(data CustomHandlerExprData) (expr func(x, y string) string) {
+			return func(x, y string) string {
+				return fmt.Sprintf(
+					`func(x, y %s) bool {
+						if x == nil || y == nil {
+							return x == y
+						}
+						return x.Cmp(y) == 0
+					}(%s, %s)`,
+					types.TypeString(data.Ty, data.ImportMap.Qualifier(data.PkgPath)), x, y,
+				)
+			}
+		},
 	},
 	{
 		Matcher: func(t types.Type) bool {
@@ -211,9 +302,43 @@ var builtinCustomHandlers = [...]CustomHandler{
 				return s
 			}, false
 		},
+		Equal: func(data CustomHandlerExprData) (expr func(x, y string) string) {
+			if types.IsInterface(data.Ty) || !types.Comparable(data.Ty) {
+				return func(x, y string) string {
+					ident, _ := data.ImportMap.Ident("reflect")
+					return fmt.Sprintf("%s.DeepEqual(%s, %s)", ident, x, y)
+				}
+			}
+			return func(x, y string) string {
+				return x + " == " + y
+			}
+		},
 	},
 }
 
+// equalCollection compares slices or maps whose element type is elem by pkg.Equal, e.g. slices.Equal.
+// Unless data.NilEqualsEmpty is set, nil and empty ones are not equal.
+func equalCollection(data CustomHandlerExprData, pkg string, elem types.Type) func(x, y string) string {
+	if types.IsInterface(elem) || !types.Comparable(elem) {
+		return func(x, y string) string {
+			ident, _ := data.ImportMap.Ident("reflect")
+			expr := fmt.Sprintf("%s.DeepEqual(%s, %s)", ident, x, y)
+			if data.NilEqualsEmpty {
+				return fmt.Sprintf("(len(%s) == 0 && len(%s) == 0 || %s)", x, y, expr)
+			}
+			return expr
+		}
+	}
+	return func(x, y string) string {
+		ident, _ := data.ImportMap.Ident(pkg)
+		expr := fmt.Sprintf("%s.Equal(%s, %s)", ident, x, y)
+		if data.NilEqualsEmpty {
+			return expr
+		}
+		return fmt.Sprintf("(%s == nil) == (%s == nil) && %s", x, y, expr)
+	}
+}
+
 func isBasicOrKnownCloneByAssign(ty types.Type) bool {
 	if _, ok := ty.(*types.Basic); ok {
 		return true
@@ -0,0 +1,149 @@
+package cloner
+
+import (
+	"cmp"
+	"fmt"
+	"go/types"
+	"log/slog"
+	"slices"
+
+	"github.com/ngicks/go-codegen/codegen/pkg/directive"
+	"github.com/ngicks/go-codegen/codegen/pkg/genreport"
+	"github.com/ngicks/go-codegen/codegen/pkg/pkgsutil"
+	"github.com/ngicks/go-codegen/codegen/pkg/typegraph"
+	"github.com/ngicks/go-codegen/codegen/pkg/typematcher"
+	"github.com/ngicks/go-iterator-helper/hiter"
+	"golang.org/x/tools/go/packages"
+)
+
+// diagnose warns about fields of target nodes which generated code can not clone completely:
+// fields holding named types of other packages which have unexported fields
+// but are neither generation targets nor implementors of Clone methods.
+// Those are left zero value since code in other packages can not touch unexported fields.
+//
+// Types in packages loaded together are generation targets unless they are excluded, e.g. by //codegen:ignore,
+// and their Clone methods are called instead.
+// Messages name the package to load along with, or tell that the type is excluded if its package is loaded.
+func (c *Config) diagnose(g *typegraph.Graph, pkgs []*packages.Package) {
+	conf := c.matcherConfig()
+	loaded := make(map[string]bool, len(pkgs))
+	for _, pkg := range pkgs {
+		loaded[pkg.PkgPath] = true
+	}
+	targets := slices.SortedFunc(
+		hiter.OmitF(hiter.Filter2(
+			func(_ typegraph.Ident, node *typegraph.Node) bool { return isGenerated(g, node.Type) },
+			g.EnumerateTypes(),
+		)),
+		func(i, j *typegraph.Node) int {
+			return cmp.Or(
+				cmp.Compare(i.Type.Obj().Pkg().Path(), j.Type.Obj().Pkg().Path()),
+				cmp.Compare(i.Type.Obj().Name(), j.Type.Obj().Name()),
+			)
+		},
+	)
+	for _, node := range targets {
+		pkgPath := node.Type.Obj().Pkg().Path()
+		priv, _ := node.Priv.(clonerPriv)
+		warn := func(field string, ty types.Type) {
+			for _, lost := range incompleteTypes(conf, g, pkgPath, ty, map[*types.Named]bool{}) {
+				msg := incompleteMessage(lost, loaded[lost.Obj().Pkg().Path()])
+				c.logger().Warn(
+					"incomplete clone",
+					slog.String("type", qualifiedName(node.Type)),
+					slog.String("field", field),
+					slog.String("reason", msg),
+				)
+				c.Report.AddWarning(genreport.Warning{
+					PkgPath: pkgPath,
+					Name:    node.Type.Obj().Name(),
+					Field:   field,
+					Message: msg,
+				})
+			}
+		}
+		switch x := node.Type.Underlying().(type) {
+		case *types.Struct:
+			for i, f := range pkgsutil.EnumerateFields(x) {
+				if d, ok := priv.lines[i]; ok && (d.Ignore || d.CopyPtr || d.Reflect) {
+					continue
+				}
+				warn(f.Name(), f.Type())
+			}
+		case *types.Array, *types.Slice, *types.Map:
+			warn("", x.(interface{ Elem() types.Type }).Elem())
+		}
+	}
+}
+
+// incompleteMessage describes why lost is left zero value and how to clone it.
+// loaded is true if the package of lost is loaded along with the package referring to it.
+func incompleteMessage(lost *types.Named, loaded bool) string {
+	pkgPath := lost.Obj().Pkg().Path()
+	reason := fmt.Sprintf(
+		"its package %q is not loaded: load %q along with this package so that its Clone method is generated there",
+		pkgPath, pkgPath,
+	)
+	if loaded {
+		reason = fmt.Sprintf(
+			"it is excluded from generation in the loaded package %q, e.g. by //%s: make it a generation target",
+			pkgPath, directive.DirectivePrefix+directive.DirectiveCommentIgnore,
+		)
+	}
+	return fmt.Sprintf(
+		"%s has unexported fields and no Clone method, thus left zero value since %s, "+
+			"implement Clone on it, or place //%s%s as field doc comment",
+		qualifiedName(lost), reason, DirectivePrefix, DirectiveCommentReflect,
+	)
+}
+
+// incompleteTypes returns named types of packages other than pkgPath found in ty
+// which generated code can not clone since they have unexported fields.
+// Types cloned by assignment, Clone methods, custom handlers or reflection are complete.
+// No-copy types are handled by NoCopyHandle.
+func incompleteTypes(c *MatcherConfig, g *typegraph.Graph, pkgPath string, ty types.Type, visited map[*types.Named]bool) []*types.Named {
+	if c.CustomHandlers.Match(ty) >= 0 {
+		return nil
+	}
+	switch x := types.Unalias(ty).(type) {
+	case *types.Pointer:
+		return incompleteTypes(c, g, pkgPath, x.Elem(), visited)
+	case *types.Slice:
+		return incompleteTypes(c, g, pkgPath, x.Elem(), visited)
+	case *types.Array:
+		return incompleteTypes(c, g, pkgPath, x.Elem(), visited)
+	case *types.Map:
+		return incompleteTypes(c, g, pkgPath, x.Elem(), visited)
+	case *types.Struct:
+		var lost []*types.Named
+		for _, f := range pkgsutil.EnumerateFields(x) {
+			lost = append(lost, incompleteTypes(c, g, pkgPath, f.Type(), visited)...)
+		}
+		return lost
+	case *types.Named:
+		if visited[x] || x.Obj().Pkg() == nil || x.Obj().Pkg().Path() == pkgPath {
+			return nil
+		}
+		visited[x] = true
+		if isGenerated(g, x) ||
+			typematcher.IsNoCopy(x) ||
+			(c.AtomicHandle == CopyHandleClone && isAtomic(x)) ||
+			clonerMatcher.IsImplementor(x) ||
+			clonerMatcher.IsFuncImplementor(x) ||
+			typematcher.IsCloneByAssign(x, cloneByAssignNamedTypeMatcher(g)) ||
+			(c.ReflectFallback && x.Obj().Exported()) {
+			return nil
+		}
+		st, ok := x.Underlying().(*types.Struct)
+		if !ok {
+			return incompleteTypes(c, g, pkgPath, x.Underlying(), visited)
+		}
+		for i := range st.NumFields() {
+			if !st.Field(i).Exported() {
+				return []*types.Named{x}
+			}
+		}
+		return incompleteTypes(c, g, pkgPath, st, visited)
+	}
+	return nil
+}
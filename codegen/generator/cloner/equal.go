@@ -0,0 +1,511 @@
+package cloner
+
+import (
+	"fmt"
+	"go/types"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ngicks/go-codegen/codegen/pkg/astutil"
+	"github.com/ngicks/go-codegen/codegen/pkg/directive"
+	"github.com/ngicks/go-codegen/codegen/pkg/imports"
+	"github.com/ngicks/go-codegen/codegen/pkg/pkgsutil"
+	"github.com/ngicks/go-codegen/codegen/pkg/typegraph"
+	"github.com/ngicks/go-codegen/codegen/pkg/typematcher"
+	"github.com/ngicks/go-iterator-helper/hiter"
+	"github.com/ngicks/go-iterator-helper/hiter/stringsiter"
+)
+
+// equalPolicy is how values are compared, set by directives on a field.
+// It applies to the entire route of the field.
+type equalPolicy struct {
+	// compares pointers by identity instead of comparing pointees.
+	ptrIdentity bool
+	// treats nil and empty slices or maps as equal.
+	nilEqualsEmpty bool
+}
+
+func (d direction) equalPolicy() equalPolicy {
+	return equalPolicy{ptrIdentity: d.EqPtr, nilEqualsEmpty: d.EqNilEmpty}
+}
+
+// generateEqual writes Equal method, or EqualFunc for generic types, of node.
+// It must be called only for nodes for which generateCloner succeeded
+// so that fields are handled consistently.
+func generateEqual(
+	c *Config,
+	printf func(format string, args ...any),
+	g *typegraph.Graph,
+	importMap imports.ImportMap,
+	node *typegraph.Node,
+) (err error) {
+	typeName := node.Ts.Name.Name + astutil.PrintTypeParamsAst(node.Ts)
+	pkgPath := node.Type.Obj().Pkg().Path()
+
+	var eqCallbacks [][2]string
+
+	otherType := typeName
+	if equalsByPointer(node.Type) {
+		otherType = "*" + typeName
+	}
+
+	printf("//" + directive.DirectivePrefix + directive.DirectiveCommentGenerated + "\n")
+	if node.Type.TypeParams().Len() == 0 {
+		printf("func (%[2]s) Equal(other %[1]s) bool {\n", otherType, receiver(node, typeName))
+	} else {
+		// [][2]string{{"eqT","T"}}
+		eqCallbacks = gatherEqualCallback(node.Type.TypeParams())
+
+		printf(
+			"func (%[3]s) EqualFunc(other %[1]s, %[2]s) bool {\n",
+			otherType,
+			stringsiter.Join(
+				", ",
+				hiter.Map(
+					func(s [2]string) string {
+						return fmt.Sprintf("%[1]s func(%[2]s, %[2]s) bool", s[0], s[1])
+					},
+					slices.Values(eqCallbacks),
+				),
+			),
+			receiver(node, typeName),
+		)
+	}
+	defer printf("}\n\n")
+
+	edges := node.ChildEdgeMap(c.MatcherConfig.MatchEdge)
+	priv, _ := node.Priv.(clonerPriv)
+
+	var exprs []string
+	switch x := node.Type.Underlying().(type) {
+	case *types.Struct:
+		for i, f := range pkgsutil.EnumerateFields(x) {
+			d := priv.lines[i]
+			if d.EqIgnore {
+				continue
+			}
+			edge, _, _, _ := edges.ByFieldPos(i)
+			eqExpr, err := equalTy(
+				c,
+				pkgPath,
+				importMap,
+				g,
+				node,
+				edge.ChildNode,
+				i,
+				f.Type(),
+				eqCallbacks,
+				d.equalPolicy(),
+			)
+			if err != nil {
+				continue
+			}
+			exprs = append(exprs, eqExpr("v."+f.Name(), "other."+f.Name()))
+		}
+	case *types.Array, *types.Slice, *types.Map:
+		_, edge, _ := edges.First()
+		eqExpr, err := equalTy(
+			c,
+			pkgPath,
+			importMap,
+			g,
+			node,
+			edge.ChildNode,
+			-1,
+			x,
+			eqCallbacks,
+			equalPolicy{},
+		)
+		if err == nil {
+			exprs = append(exprs, eqExpr(receiverValue(node), "other"))
+		}
+	}
+
+	if len(exprs) == 0 {
+		printf("return true\n")
+		return nil
+	}
+	printf("return %s\n", strings.Join(exprs, " &&\n"))
+	return nil
+}
+
+func gatherEqualCallback(tyParams *types.TypeParamList) [][2]string {
+	return slices.Collect(
+		hiter.Map(
+			func(p *types.TypeParam) [2]string {
+				name := p.Obj().Name()
+				first, size := utf8.DecodeRuneInString(name)
+				return [2]string{
+					"eq" + string(unicode.ToUpper(first)) + name[size:],
+					name,
+				}
+			},
+			hiter.OmitF(hiter.AtterAll(tyParams)),
+		),
+	)
+}
+
+// equalTy returns a function that builds an expression comparing 2 values of ty.
+// Values are handled, or ignored, in the same way as cloneTy does.
+func equalTy(
+	c *Config,
+	pkgPath string,
+	importMap imports.ImportMap,
+	g *typegraph.Graph,
+	parent *typegraph.Node,
+	child *typegraph.Node,
+	pos int,
+	ty types.Type,
+	eqCallbacks [][2]string,
+	policy equalPolicy,
+) (eqExpr func(x, y string) string, err error) {
+	unwrapped, stack, handleKind, idx := c.matcherConfig().handleField(
+		pos,
+		parent,
+		child,
+		g,
+		ty,
+	)
+
+	if handleKind == handleKindIgnore {
+		return nil, errNotHandled
+	}
+
+	route := fieldRoute(stack)
+
+	leafTy := types.Unalias(ty)
+	for _, n := range route {
+		leafTy = types.Unalias(unwrapTyOne(leafTy, n.Kind))
+	}
+
+	leaf, ok := equalLeaf(c, pkgPath, importMap, g, handleKind, idx, unwrapped, leafTy, eqCallbacks, policy)
+	if !ok {
+		if len(route) == 0 {
+			return nil, errNotHandled
+		}
+		// values are not comparable, e.g. funcs. Only compares the structure around them.
+		leaf = func(x, y string) string { return "true" }
+	}
+
+	return equalAlongPath(types.Unalias(ty), route, importMap.Qualifier(pkgPath), policy, leaf), nil
+}
+
+// equalLeaf returns a function building an expression comparing values of leafTy,
+// the type at the end of the route.
+// ok is false if values can not be compared.
+func equalLeaf(
+	c *Config,
+	pkgPath string,
+	importMap imports.ImportMap,
+	g *typegraph.Graph,
+	handleKind handleKind,
+	customHandlerIndex int,
+	unwrapped types.Type,
+	leafTy types.Type,
+	eqCallbacks [][2]string,
+	policy equalPolicy,
+) (eqExpr func(x, y string) string, ok bool) {
+	if _, isPointer := leafTy.Underlying().(*types.Pointer); isPointer && policy.ptrIdentity {
+		return func(x, y string) string { return x + " == " + y }, true
+	}
+
+	switch handleKind {
+	case handleKindAssign:
+		if asUnderlying[*types.Signature](leafTy) != nil {
+			return nil, false
+		}
+		return equalOperator(leafTy, importMap), true
+	case handleKindNewChannel:
+		return func(x, y string) string { return x + " == " + y }, true
+	case handleKindCallCb:
+		return func(x, y string) string {
+			return fmt.Sprintf("%s(%s, %s)", eqCallbacks[unwrapped.(*types.TypeParam).Index()][0], x, y)
+		}, true
+	case handleKindCallClone:
+		if hasEqual(g, unwrapped, "Equal") {
+			return func(x, y string) string { return x + ".Equal(" + equalArg(unwrapped, y) + ")" }, true
+		}
+		return deepEqual(importMap), true
+	case handleKindCallCloneFunc:
+		named, isNamed := types.Unalias(unwrapped).(*types.Named)
+		if !isNamed || !hasEqual(g, unwrapped, "EqualFunc") {
+			return deepEqual(importMap), true
+		}
+		var args []string
+		for _, t := range hiter.AtterAll(named.TypeArgs()) {
+			if x, ok := t.(*types.TypeParam); ok {
+				args = append(args, eqCallbacks[x.Index()][0])
+				continue
+			}
+			var childTy types.Type
+			_ = typegraph.TraverseTypes(
+				t,
+				nil,
+				func(ty types.Type, named *types.Named, stack []typegraph.EdgeRouteNode) error {
+					childTy = ty
+					return nil
+				},
+				nil,
+			)
+			child, _ := g.GetByType(childTy)
+
+			// type params found in t are ones of the type being generated.
+			expr, err := equalTy(c, pkgPath, importMap, g, nil, child, -1, t, eqCallbacks, policy)
+			if err != nil {
+				// can't compare type args. fall back to reflection.
+				return deepEqual(importMap), true
+			}
+			args = append(
+				args,
+				fmt.Sprintf(
+					`func(x, y %s) bool {
+						return %s
+					}`,
+					types.TypeString(t, importMap.Qualifier(pkgPath)), expr("x", "y"),
+				),
+			)
+		}
+		return func(x, y string) string {
+			return x + ".EqualFunc(" + equalArg(unwrapped, y) + ", " + strings.Join(args, ",\n") + ")"
+		}, true
+	case handleKindUseCustomHandler:
+		handler := c.matcherConfig().CustomHandlers[customHandlerIndex]
+		if handler.Equal == nil {
+			return deepEqual(importMap), true
+		}
+		return handler.Equal(CustomHandlerExprData{
+			ImportMap:      importMap,
+			PkgPath:        pkgPath,
+			Ty:             unwrapped,
+			NilEqualsEmpty: policy.nilEqualsEmpty,
+		}), true
+	case handleKindCloneInterface, handleKindReflect:
+		return deepEqual(importMap), true
+	case handleKindAtomic:
+		return equalAtomic(pkgPath, importMap, unwrapped, leafTy), true
+	case handleKindStructLiteral:
+		return equalStruct(c, pkgPath, importMap, g, eqCallbacks, false, unwrapped, policy), true
+	case handleKindCopyPublicField:
+		switch x := unwrapped.(*types.Named).Underlying().(type) {
+		case *types.Struct:
+			return equalStruct(c, pkgPath, importMap, g, eqCallbacks, true, unwrapped, policy), true
+		default:
+			expr, err := equalTy(c, pkgPath, importMap, g, nil, nil, -1, x, eqCallbacks, policy)
+			if err != nil {
+				return nil, false
+			}
+			return expr, true
+		}
+	}
+	return nil, false
+}
+
+// equalOperator compares values of ty by == if ty is comparable, and by reflect.DeepEqual otherwise.
+// Interfaces are compared by reflect.DeepEqual since == panics if their dynamic types are not comparable.
+func equalOperator(ty types.Type, importMap imports.ImportMap) func(x, y string) string {
+	if types.IsInterface(ty) || !types.Comparable(ty) {
+		return deepEqual(importMap)
+	}
+	return func(x, y string) string { return x + " == " + y }
+}
+
+func deepEqual(importMap imports.ImportMap) func(x, y string) string {
+	return func(x, y string) string {
+		ident, _ := importMap.Ident("reflect")
+		return fmt.Sprintf("%s.DeepEqual(%s, %s)", ident, x, y)
+	}
+}
+
+// equalsByPointer reports whether Equal methods of ty take the other value by pointer.
+// Struct types holding no-copy objects, e.g. values of sync/atomic types, must not be copied.
+func equalsByPointer(ty types.Type) bool {
+	_, isStruct := ty.Underlying().(*types.Struct)
+	return isStruct && typematcher.IsNoCopy(ty)
+}
+
+// equalArg returns the argument passing y to Equal methods of ty.
+func equalArg(ty types.Type, y string) string {
+	if equalsByPointer(ty) {
+		return addrOf(y)
+	}
+	return y
+}
+
+// hasEqual reports whether ty has the method, or will have it since it is a generation target in g.
+func hasEqual(g *typegraph.Graph, ty types.Type, method string) bool {
+	named, ok := types.Unalias(ty).(*types.Named)
+	if !ok {
+		return false
+	}
+	if n, ok := g.GetByType(named); ok && n.Matched&^typegraph.MatchKindExternal > 0 {
+		return true
+	}
+	obj, _, _ := types.LookupFieldOrMethod(named, false, named.Obj().Pkg(), method)
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+	sig := fn.Type().(*types.Signature)
+	if sig.Params().Len() < 1 || sig.Results().Len() != 1 {
+		return false
+	}
+	var param types.Type = named
+	if equalsByPointer(named) {
+		param = types.NewPointer(named)
+	}
+	return types.Identical(sig.Params().At(0).Type(), param) &&
+		types.Identical(sig.Results().At(0).Type(), types.Typ[types.Bool])
+}
+
+// equalStruct compares every field, or exported fields only if onlyPublic is true, of the struct type unwrapped.
+func equalStruct(
+	c *Config,
+	pkgPath string,
+	importMap imports.ImportMap,
+	g *typegraph.Graph,
+	eqCallbacks [][2]string,
+	onlyPublic bool,
+	unwrapped types.Type,
+	policy equalPolicy,
+) func(x, y string) string {
+	var exprs []string
+	for _, f := range pkgsutil.EnumerateFields(structOrUnderlyingStruct(unwrapped)) {
+		if onlyPublic && !f.Exported() {
+			continue
+		}
+		var childTy types.Type
+		_ = typegraph.TraverseTypes(
+			f.Type(),
+			func(ty types.Type, currentStack []typegraph.EdgeRouteNode) bool {
+				_, isStructLit := ty.(*types.Struct)
+				return isStructLit
+			},
+			func(ty types.Type, named *types.Named, stack []typegraph.EdgeRouteNode) error {
+				childTy = ty
+				return nil
+			},
+			nil,
+		)
+
+		if named := as[*types.Named](childTy); onlyPublic && named != nil {
+			if !named.Obj().Exported() {
+				continue
+			}
+		}
+		child, _ := g.GetByType(childTy)
+
+		expr, err := equalTy(c, pkgPath, importMap, g, nil, child, -1, f.Type(), eqCallbacks, policy)
+		if err != nil {
+			continue
+		}
+		exprs = append(exprs, expr("x."+f.Name(), "y."+f.Name()))
+	}
+
+	body := "true"
+	if len(exprs) > 0 {
+		body = strings.Join(exprs, " &&\n")
+	}
+	tyExpr := types.TypeString(unwrapped, importMap.Qualifier(pkgPath))
+	return func(x, y string) string {
+		return fmt.Sprintf(
+			`func(x, y %s) bool {
+				return %s
+			}(%s, %s)`,
+			tyExpr, body, x, y,
+		)
+	}
+}
+
+// equalAlongPath wraps leaf with loops and nil checks along route.
+// ty is the type of values compared by the returned expression.
+func equalAlongPath(
+	ty types.Type,
+	route []typegraph.EdgeRouteNode,
+	qualifier types.Qualifier,
+	policy equalPolicy,
+	leaf func(x, y string) string,
+) func(x, y string) string {
+	if len(route) == 0 {
+		return leaf
+	}
+
+	tyExpr := types.TypeString(ty, qualifier)
+	nilCheck := " || (x == nil) != (y == nil)"
+	if policy.nilEqualsEmpty {
+		nilCheck = ""
+	}
+
+	switch route[0].Kind {
+	case typegraph.EdgeKindPointer:
+		if policy.ptrIdentity {
+			return func(x, y string) string { return x + " == " + y }
+		}
+		inner := equalAlongPath(types.Unalias(ty.(*types.Pointer).Elem()), route[1:], qualifier, policy, leaf)
+		return func(x, y string) string {
+			return fmt.Sprintf(
+				`func(x, y %s) bool {
+					if x == nil || y == nil {
+						return x == y
+					}
+					return %s
+				}(%s, %s)`,
+				tyExpr, inner("(*x)", "(*y)"), x, y,
+			)
+		}
+	case typegraph.EdgeKindArray:
+		inner := equalAlongPath(types.Unalias(ty.(*types.Array).Elem()), route[1:], qualifier, policy, leaf)
+		return func(x, y string) string {
+			return fmt.Sprintf(
+				`func(x, y %s) bool {
+					for i := range x {
+						if !(%s) {
+							return false
+						}
+					}
+					return true
+				}(%s, %s)`,
+				tyExpr, inner("x[i]", "y[i]"), x, y,
+			)
+		}
+	case typegraph.EdgeKindSlice:
+		inner := equalAlongPath(types.Unalias(ty.(*types.Slice).Elem()), route[1:], qualifier, policy, leaf)
+		return func(x, y string) string {
+			return fmt.Sprintf(
+				`func(x, y %s) bool {
+					if len(x) != len(y)%s {
+						return false
+					}
+					for i := range x {
+						if !(%s) {
+							return false
+						}
+					}
+					return true
+				}(%s, %s)`,
+				tyExpr, nilCheck, inner("x[i]", "y[i]"), x, y,
+			)
+		}
+	case typegraph.EdgeKindMap:
+		inner := equalAlongPath(types.Unalias(ty.(*types.Map).Elem()), route[1:], qualifier, policy, leaf)
+		return func(x, y string) string {
+			return fmt.Sprintf(
+				`func(x, y %s) bool {
+					if len(x) != len(y)%s {
+						return false
+					}
+					for k, vx := range x {
+						vy, ok := y[k]
+						if !ok || !(%s) {
+							return false
+						}
+					}
+					return true
+				}(%s, %s)`,
+				tyExpr, nilCheck, inner("vx", "vy"), x, y,
+			)
+		}
+	}
+	panic(fmt.Errorf("unknown kind: %s", route[0].Kind))
+}
@@ -0,0 +1,130 @@
+package cloner
+
+import (
+	"go/types"
+	"strings"
+
+	"github.com/ngicks/go-codegen/codegen/pkg/genreport"
+	"github.com/ngicks/go-codegen/codegen/pkg/pkgsutil"
+	"github.com/ngicks/go-codegen/codegen/pkg/typegraph"
+	"golang.org/x/tools/go/packages"
+)
+
+// Explain builds the type graph of pkgs in the same way as Generate does
+// and explains why the type identified by ident is, or is not, a target of the cloner.
+func (c *Config) Explain(pkgs []*packages.Package, ident typegraph.Ident) (*genreport.Explanation, error) {
+	graph, err := c.Graph(pkgs)
+	if err != nil {
+		return nil, err
+	}
+	return genreport.Explain(
+		graph,
+		ident,
+		c.matcherConfig().MatchEdge,
+		rejectionOf,
+		c.matcherConfig().explainFields(graph),
+	)
+}
+
+// explainFields returns a function that reproduces decisions on each field, or the element, of a node in g.
+// Decisions are ones made by Generate while rejections are ones found by MatchType.
+func (c *MatcherConfig) explainFields(g *typegraph.Graph) func(node *typegraph.Node) []genreport.Field {
+	return func(node *typegraph.Node) []genreport.Field {
+		qualifier := types.RelativeTo(node.Type.Obj().Pkg())
+		priv, _ := node.Priv.(clonerPriv)
+		edges := node.ChildEdgeMap(c.MatchEdge)
+
+		explain := func(i int, name string, ty types.Type, child *typegraph.Node) genreport.Field {
+			_, stack, kind, _ := c.handleField(i, node, child, g, ty)
+			f := genreport.Field{
+				Index:    i,
+				Name:     name,
+				Type:     types.TypeString(ty, qualifier),
+				Route:    []string{},
+				Decision: kind.String(),
+			}
+			for _, r := range stack {
+				f.Route = append(f.Route, r.Kind.String())
+			}
+
+			conf := priv.typeDirection.override(*c)
+			if d, ok := priv.lines[i]; ok {
+				conf = d.override(conf)
+				f.Directive = d.String()
+			}
+			_, _, _, _, f.Rejection = conf.matchTy(ty, nil, nil, noopLogger)
+			if f.Rejection != "" {
+				f.Decision = "disallowed"
+			}
+			return f
+		}
+
+		switch x := node.Type.Underlying().(type) {
+		case *types.Struct:
+			var fields []genreport.Field
+			for i, v := range pkgsutil.EnumerateFields(x) {
+				edge, _, _, _ := edges.ByFieldPos(i)
+				fields = append(fields, explain(i, v.Name(), v.Type(), edge.ChildNode))
+			}
+			return fields
+		case *types.Array, *types.Slice, *types.Map:
+			_, edge, _ := edges.First()
+			return []genreport.Field{explain(-1, "", x, edge.ChildNode)}
+		}
+		return nil
+	}
+}
+
+func (k handleKind) String() string {
+	switch k {
+	case handleKindIgnore:
+		return "ignored"
+	case handleKindAssign:
+		return "assigned"
+	case handleKindNewChannel:
+		return "new channel made"
+	case handleKindCallCb:
+		return "cloned by the callback for the type parameter"
+	case handleKindCallClone:
+		return "cloned by Clone method"
+	case handleKindCallCloneFunc:
+		return "cloned by CloneFunc method"
+	case handleKindUseCustomHandler:
+		return "cloned by a custom handler"
+	case handleKindStructLiteral:
+		return "cloned as struct literal"
+	case handleKindCopyPublicField:
+		return "exported fields copied"
+	case handleKindCloneInterface:
+		return "cloned by type switch over implementors"
+	case handleKindReflect:
+		return "cloned by reflection"
+	case handleKindAtomic:
+		return "cloned by Load and Store"
+	}
+	return "unknown"
+}
+
+func (d direction) String() string {
+	var names []string
+	for _, v := range []struct {
+		set  bool
+		name string
+	}{
+		{d.Ignore, DirectiveCommentIgnore},
+		{d.CopyPtr, DirectiveCommentCopyPtr},
+		{d.Make, DirectiveCommentMake},
+		{d.Reflect, DirectiveCommentReflect},
+		{d.EqIgnore, DirectiveCommentEqualIgnore},
+		{d.EqPtr, DirectiveCommentEqualPtr},
+		{d.EqNilEmpty, DirectiveCommentEqualNilEmpty},
+	} {
+		if v.set {
+			names = append(names, v.name)
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	return "//" + DirectivePrefix + strings.Join(names, ",")
+}
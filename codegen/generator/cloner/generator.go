@@ -1,20 +1,24 @@
 package cloner
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"go/ast"
+	"go/types"
 	"io"
 	"iter"
 	"log/slog"
 	"slices"
 
 	"github.com/dave/dst/decorator"
+	"github.com/ngicks/go-codegen/codegen/internal/workpool"
 	"github.com/ngicks/go-codegen/codegen/pkg/astutil"
 	"github.com/ngicks/go-codegen/codegen/pkg/directive"
+	"github.com/ngicks/go-codegen/codegen/pkg/gencache"
+	"github.com/ngicks/go-codegen/codegen/pkg/genreport"
 	"github.com/ngicks/go-codegen/codegen/pkg/imports"
-	"github.com/ngicks/go-codegen/codegen/internal/bufpool"
 	"github.com/ngicks/go-codegen/codegen/pkg/pkgsutil"
 	"github.com/ngicks/go-codegen/codegen/pkg/suffixwriter"
 	"github.com/ngicks/go-codegen/codegen/pkg/typegraph"
@@ -25,16 +29,40 @@ import (
 type Config struct {
 	MatcherConfig *MatcherConfig
 	Logger        *slog.Logger
+	// Cache, if non nil, is used to skip generation for files whose types are unchanged since the last run.
+	// It must be created for the cloner with the same MatcherConfig.
+	Cache *gencache.Cache
+	// Report, if non nil, records every type examined and why types are not matched.
+	Report *genreport.Run
+	// GenerateEqual enables generation of Equal methods, or EqualFunc for generic types,
+	// alongside Clone methods.
+	GenerateEqual bool
+	// Memo makes Clone, or CloneFunc for generic types, delegate to CloneWithMemo, or CloneFuncWithMemo,
+	// which take a map from source pointers to cloned pointers.
+	// Pointers visited more than once are cloned only once, thus aliasing and cycles of pointers are preserved.
+	Memo bool
+	// GenerateInto enables generation of CloneInto methods, or CloneFuncInto for generic types,
+	// which clone the receiver into an existing value reusing its slices, maps and pointers.
+	GenerateInto bool
+	// GenerateTest enables generation of test files along generated files, e.g. foo.clone_test.go for foo.go,
+	// which fuzz and benchmark generated clone methods of non-generic types
+	// by [github.com/ngicks/go-codegen/pkg/cloner/runtime/clonetest].
+	GenerateTest bool
+
+	typeDirections map[*types.TypeName]typeDirection
 }
 
 func (c *Config) matcherConfig() *MatcherConfig {
 	if c.MatcherConfig != nil {
 		conf := c.MatcherConfig.fallback()
 		conf.CustomHandlers = append(slices.Clip(conf.CustomHandlers), builtinCustomHandlers[:]...)
+		conf.typeDirections = c.typeDirections
 		return conf.SetLogger(c.logger())
 	}
 
-	return NewMatcherConfig().SetLogger(c.logger())
+	conf := NewMatcherConfig()
+	conf.typeDirections = c.typeDirections
+	return conf.SetLogger(c.logger())
 }
 
 var (
@@ -56,19 +84,20 @@ func (c *Config) Generate(
 ) error {
 	parser := imports.NewParserPackages(pkgs)
 	parser.AppendExtra(c.matcherConfig().CustomHandlers.Imports()...)
+	if c.GenerateEqual {
+		// fallback for values that can not be compared by ==.
+		parser.AppendExtra(imports.TargetImport{Import: imports.Import{Path: "reflect", Name: "reflect"}})
+	}
+	// for interface fields cloned by type switch and fields cloned by reflection.
+	// Only files using it import it.
+	parser.AppendExtra(imports.TargetImport{Import: imports.Import{Path: runtimePkgPath, Name: "cloneruntime"}})
 
-	graph, err := typegraph.New(
-		pkgs,
-		c.matcherConfig().MatchType,
-		directive.ExcludeIgnoredGenDecl,
-		directive.ExcludeIgnoredTypeSpec,
-		typegraph.WithPrivParser(parseNode),
-	)
+	graph, err := c.Graph(pkgs)
 	if err != nil {
 		return err
 	}
-
-	graph.MarkDependant(c.matcherConfig().MatchEdge)
+	c.Report.AddGraph(graph, c.matcherConfig().MatchEdge, rejectionOf)
+	c.diagnose(graph, pkgs)
 
 	replacerData, err := graph.GatherReplaceData(
 		parser,
@@ -80,48 +109,76 @@ func (c *Config) Generate(
 		return err
 	}
 
-	buf := bufpool.GetBuf()
-	defer bufpool.PutBuf(buf)
-
-	for _, data := range hiter.Filter2(
-		func(f *ast.File, data *typegraph.ReplaceData) bool { return f != nil && data != nil },
-		hiter.MapsKeys(replacerData, pkgsutil.EnumerateFile(pkgs)),
-	) {
-		buf.Reset()
-
-		if len(data.TargetNodes) == 0 {
-			continue
-		}
-
-		data.ImportMap.AddMissingImports(data.DstFile)
-		res := decorator.NewRestorer()
-		af, err := res.RestoreFile(data.DstFile)
-		if err != nil {
-			return fmt.Errorf("converting dst to ast for %q: %w", data.Filename, err)
-		}
-
-		if err := astutil.PrintFileHeader(buf, af, res.Fset); err != nil {
-			return fmt.Errorf("%q: %w", data.Filename, err)
-		}
-
-		handled := 0
-		for _, node := range data.TargetNodes {
-			err = generateMethod(c, buf, graph, node, data)
-			if err != nil {
-				if errors.Is(err, errNotHandled) {
-					continue
-				}
-				return err
+	return workpool.Run(
+		sourcePrinter.Jobs(),
+		slices.Collect(hiter.OmitF(hiter.Filter2(
+			func(f *ast.File, data *typegraph.ReplaceData) bool { return f != nil && data != nil },
+			hiter.MapsKeys(replacerData, pkgsutil.EnumerateFile(pkgs)),
+		))),
+		func(data *typegraph.ReplaceData) error {
+			if len(data.TargetNodes) == 0 {
+				return nil
+			}
+
+			cache := c.Cache
+			if c.matcherConfig().InterfaceHandle == CopyHandleClone {
+				// type switches cloning interfaces list implementors found anywhere in loaded packages.
+				cache = cache.Derive(implementorsFingerprint(graph, data.TargetNodes[0].Type.Obj().Pkg().Path()))
 			}
-			handled++
-		}
+			err := cache.Write(ctx, sourcePrinter, data, func(buf *bytes.Buffer) (written bool, err error) {
+				data.ImportMap.AddMissingImports(data.DstFile)
+				res := decorator.NewRestorer()
+				af, err := res.RestoreFile(data.DstFile)
+				if err != nil {
+					return false, fmt.Errorf("converting dst to ast for %q: %w", data.Filename, err)
+				}
+
+				if err := astutil.PrintFileHeader(buf, af, res.Fset); err != nil {
+					return false, fmt.Errorf("%q: %w", data.Filename, err)
+				}
+
+				handled := 0
+				for _, node := range data.TargetNodes {
+					err = generateMethod(c, buf, graph, node, data)
+					if err != nil {
+						if errors.Is(err, errNotHandled) {
+							continue
+						}
+						return false, err
+					}
+					handled++
+				}
 
-		if handled > 0 {
-			err = sourcePrinter.Write(ctx, data.Filename, buf.Bytes())
-			if err != nil {
+				return handled > 0, nil
+			})
+			if err != nil || !c.GenerateTest {
 				return err
 			}
-		}
+			return c.writeTest(ctx, sourcePrinter, graph, data)
+		},
+	)
+}
+
+// Graph builds the type graph of pkgs in the same way as Generate does,
+// with matched and dependant types marked.
+func (c *Config) Graph(pkgs []*packages.Package) (*typegraph.Graph, error) {
+	typeDirections, err := parseTypeDirections(pkgs)
+	if err != nil {
+		return nil, err
+	}
+	c.typeDirections = typeDirections
+
+	graph, err := typegraph.New(
+		pkgs,
+		c.matcherConfig().MatchType,
+		directive.ExcludeIgnoredGenDecl,
+		directive.ExcludeIgnoredTypeSpec,
+		typegraph.WithPrivParser(parseNode),
+	)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+
+	graph.MarkDependant(c.matcherConfig().MatchEdge)
+	return graph, nil
 }
@@ -0,0 +1,98 @@
+package cloner
+
+import (
+	"fmt"
+	"go/types"
+
+	"github.com/ngicks/go-codegen/codegen/pkg/imports"
+)
+
+// HandlerRule declares a [CustomHandler] for a named type without writing Go code,
+// e.g. rules loaded from a config file.
+type HandlerRule struct {
+	// Type is the named type the rule applies to.
+	Type imports.TargetType
+	// Pointer makes the rule apply to pointers to Type instead of Type itself.
+	Pointer bool
+	// Clone is the package level function which clones values, e.g. func(*T) *T if Pointer is set.
+	// If zero, values are cloned by assignment.
+	Clone imports.TargetType
+	// Equal is the package level function which compares values, e.g. func(x, y T) bool.
+	// If zero, values cloned by assignment are compared by == if comparable,
+	// and others are compared by reflect.DeepEqual.
+	Equal imports.TargetType
+}
+
+// String describes r, e.g. "*example.com/foo.Bar clone=example.com/foo.CloneBar".
+func (r HandlerRule) String() string {
+	s := targetTypeString(r.Type)
+	if r.Pointer {
+		s = "*" + s
+	}
+	if r.Clone == (imports.TargetType{}) {
+		s += " clone=assign"
+	} else {
+		s += " clone=" + targetTypeString(r.Clone)
+	}
+	if r.Equal != (imports.TargetType{}) {
+		s += " equal=" + targetTypeString(r.Equal)
+	}
+	return s
+}
+
+func targetTypeString(t imports.TargetType) string {
+	return t.ImportPath + "." + t.Name
+}
+
+// CustomHandler converts r into a CustomHandler.
+func (r HandlerRule) CustomHandler() CustomHandler {
+	var imps []imports.TargetImport
+	for _, fn := range []imports.TargetType{r.Clone, r.Equal} {
+		if fn != (imports.TargetType{}) {
+			imps = append(imps, imports.TargetImport{Import: imports.Import{Path: fn.ImportPath}})
+		}
+	}
+	return CustomHandler{
+		Name: r.String(),
+		Matcher: func(t types.Type) bool {
+			if r.Pointer {
+				p, ok := t.(*types.Pointer)
+				if !ok {
+					return false
+				}
+				t = p.Elem()
+			}
+			return r.Type.Is(t)
+		},
+		Imports: imps,
+		Expr: func(data CustomHandlerExprData) (expr func(s string) (expr string), isFunc bool) {
+			if r.Clone == (imports.TargetType{}) {
+				return func(s string) string { return s }, false
+			}
+			return func(s string) string {
+				return qualifiedFunc(data, r.Clone)
+			}, true
+		},
+		Equal: func(data CustomHandlerExprData) (expr func(x, y string) string) {
+			switch {
+			case r.Equal != (imports.TargetType{}):
+				return func(x, y string) string {
+					return fmt.Sprintf("%s(%s, %s)", qualifiedFunc(data, r.Equal), x, y)
+				}
+			case r.Clone == (imports.TargetType{}) && !types.IsInterface(data.Ty) && types.Comparable(data.Ty):
+				return func(x, y string) string { return x + " == " + y }
+			default:
+				return deepEqual(data.ImportMap)
+			}
+		},
+	}
+}
+
+// qualifiedFunc returns the expression referring fn from the package data.PkgPath.
+func qualifiedFunc(data CustomHandlerExprData, fn imports.TargetType) string {
+	if fn.ImportPath == data.PkgPath {
+		return fn.Name
+	}
+	ident, _ := data.ImportMap.Ident(fn.ImportPath)
+	return ident + "." + fn.Name
+}
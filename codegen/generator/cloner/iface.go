@@ -0,0 +1,186 @@
+package cloner
+
+import (
+	"fmt"
+	"go/types"
+	"strings"
+
+	"github.com/ngicks/go-codegen/codegen/pkg/imports"
+	"github.com/ngicks/go-codegen/codegen/pkg/typegraph"
+)
+
+// runtimePkgPath is the package which generated code falls back to
+// for interface values of types not known at generation time, and for values cloned by reflection.
+const runtimePkgPath = "github.com/ngicks/go-codegen/pkg/cloner/runtime"
+
+// implementor is a concrete type whose values can be stored in an interface.
+type implementor struct {
+	ty *types.Named
+	// value is true if ty itself implements the interface.
+	// Otherwise only *ty does.
+	value bool
+}
+
+// interfaceImplementors enumerates named types with Clone methods, generated or hand-written,
+// which implement iface and are defined in packages loaded into g.
+// Only the package pkgPath and packages it depends on are searched to avoid import cycles.
+func interfaceImplementors(g *typegraph.Graph, pkgPath string, iface *types.Interface) []implementor {
+	var impls []implementor
+	for _, named := range implementorCandidates(g, pkgPath) {
+		switch {
+		case types.Implements(named, iface):
+			impls = append(impls, implementor{ty: named, value: true})
+		case types.Implements(types.NewPointer(named), iface):
+			impls = append(impls, implementor{ty: named})
+		}
+	}
+	return impls
+}
+
+// implementorCandidates enumerates named types which interfaceImplementors may return for pkgPath,
+// regardless of interfaces.
+func implementorCandidates(g *typegraph.Graph, pkgPath string) []*types.Named {
+	loaded := map[string]*types.Package{}
+	for _, node := range g.EnumerateTypes() {
+		if node.Matched.IsExternal() {
+			continue
+		}
+		pkg := node.Type.Obj().Pkg()
+		loaded[pkg.Path()] = pkg
+	}
+
+	current, ok := loaded[pkgPath]
+	if !ok {
+		return nil
+	}
+
+	var candidates []*types.Named
+	for _, pkg := range dependencyOrder(current) {
+		if _, ok := loaded[pkg.Path()]; !ok || !importable(pkgPath, pkg.Path()) {
+			continue
+		}
+		for _, name := range pkg.Scope().Names() {
+			obj, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+			if !ok || obj.IsAlias() || (pkg != current && !obj.Exported()) {
+				continue
+			}
+			named, ok := obj.Type().(*types.Named)
+			if !ok || named.TypeParams().Len() > 0 || types.IsInterface(named) {
+				continue
+			}
+			if !clonerMatcher.IsImplementor(named) && !isGenerated(g, named) {
+				continue
+			}
+			candidates = append(candidates, named)
+		}
+	}
+	return candidates
+}
+
+// implementorsFingerprint describes implementor candidates for pkgPath and their method sets.
+// Outputs cloning interfaces by type switches depend on it
+// though it is not captured by fingerprints of types in the file,
+// since implementors may be defined anywhere in loaded packages.
+func implementorsFingerprint(g *typegraph.Graph, pkgPath string) string {
+	var b strings.Builder
+	for _, named := range implementorCandidates(g, pkgPath) {
+		b.WriteString(types.TypeString(named, nil))
+		for _, t := range []types.Type{named, types.NewPointer(named)} {
+			mset := types.NewMethodSet(t)
+			for i := range mset.Len() {
+				b.WriteString("\n\t" + types.ObjectString(mset.At(i).Obj(), nil))
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func isGenerated(g *typegraph.Graph, named *types.Named) bool {
+	n, ok := g.GetByType(named)
+	return ok && n.Matched&^typegraph.MatchKindExternal > 0
+}
+
+// dependencyOrder returns pkg followed by packages it imports directly or indirectly, each only once.
+func dependencyOrder(pkg *types.Package) []*types.Package {
+	visited := map[*types.Package]bool{pkg: true}
+	order := []*types.Package{pkg}
+	for i := 0; i < len(order); i++ {
+		for _, imp := range order[i].Imports() {
+			if !visited[imp] {
+				visited[imp] = true
+				order = append(order, imp)
+			}
+		}
+	}
+	return order
+}
+
+// importable reports whether the package path can be imported from the package from,
+// respecting internal directories.
+func importable(from, path string) bool {
+	elems := strings.Split(path, "/")
+	for i := len(elems) - 1; i >= 0; i-- {
+		if elems[i] == "internal" {
+			parent := strings.Join(elems[:i], "/")
+			return parent == "" || from == parent || strings.HasPrefix(from, parent+"/")
+		}
+	}
+	return true
+}
+
+// cloneInterface returns a func literal cloning values of the interface type ifaceTy.
+// Values are cloned by a type switch over implementors found in g,
+// then by the runtime registry, or copied as is if neither knows the dynamic type.
+func cloneInterface(
+	c *Config,
+	pkgPath string,
+	importMap imports.ImportMap,
+	g *typegraph.Graph,
+	ifaceTy types.Type,
+) string {
+	qualifier := importMap.Qualifier(pkgPath)
+	iface := types.TypeString(ifaceTy, qualifier)
+
+	clone := func(named *types.Named) string {
+		method := cloneMethod(g, named)
+		if c.Memo && hasMethod(g, named, method+"WithMemo") {
+			return "x." + method + "WithMemo(" + memoParam + ")"
+		}
+		return "x." + method + "()"
+	}
+
+	var cases strings.Builder
+	for _, impl := range interfaceImplementors(g, pkgPath, ifaceTy.Underlying().(*types.Interface)) {
+		ty := types.TypeString(impl.ty, qualifier)
+		if impl.value {
+			fmt.Fprintf(&cases, "case %s:\nreturn %s\n", ty, clone(impl.ty))
+		}
+		fmt.Fprintf(
+			&cases,
+			`case *%s:
+				if x == nil {
+					return x
+				}
+				c := %s
+				return &c
+			`,
+			ty, clone(impl.ty),
+		)
+	}
+
+	runtimeIdent, _ := importMap.Ident(runtimePkgPath)
+	var typeSwitch string
+	if cases.Len() > 0 {
+		typeSwitch = fmt.Sprintf("switch x := v.(type) {\n%s}\n", cases.String())
+	}
+	return fmt.Sprintf(
+		`func(v %[1]s) %[1]s {
+			%[2]sif cloned, ok := %[3]s.Clone(v); ok {
+				return cloned.(%[1]s)
+			}
+			return v
+		}`,
+		iface, typeSwitch, runtimeIdent,
+	)
+}
@@ -0,0 +1,123 @@
+package generationtests
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ngicks/go-codegen/codegen/generator/cloner"
+	"github.com/ngicks/go-codegen/codegen/pkg/genreport"
+	"github.com/ngicks/go-codegen/codegen/pkg/suffixwriter"
+	"golang.org/x/tools/go/packages"
+	"gotest.tools/v3/assert"
+)
+
+// Cloning types of other packages by Clone methods generated in those packages has been supported
+// as long as the packages are loaded together. These tests pin that behavior,
+// warnings for types which can not be cloned completely,
+// and that --reflect-fallback prefers those Clone methods over reflection.
+func TestGenerate_crosspkg(t *testing.T) {
+	const (
+		crosspkg = "github.com/ngicks/go-codegen/codegen/generator/cloner/internal/testtargets/crosspkg"
+		model    = crosspkg + "/model"
+	)
+
+	generate := func(t *testing.T, pkgs []*packages.Package, reflectFallback bool) (string, []genreport.Warning) {
+		t.Helper()
+		testPrinter := suffixwriter.NewTestWriter(".cloner", suffixwriter.WithCwd("../testtargets"))
+		run := (&genreport.Report{}).NewRun("cloner")
+		cfg := cloner.Config{
+			MatcherConfig: &cloner.MatcherConfig{
+				ChannelHandle:   cloner.CopyHandleDisallow,
+				ReflectFallback: reflectFallback,
+			},
+			Report: run,
+		}
+		err := cfg.Generate(
+			context.Background(),
+			testPrinter.Writer,
+			pkgs,
+		)
+		assert.NilError(t, err)
+		results := testPrinter.Results()
+		var out string
+		for _, k := range slices.Sorted(maps.Keys(results)) {
+			result := results[k]
+			t.Logf("%q:\n%s", k, result)
+			if strings.HasSuffix(k, "/crosspkg/crosspkg.cloner.go") {
+				out = string(result)
+			}
+		}
+		return out, run.Warnings
+	}
+	withoutModel := func() []*packages.Package {
+		return slices.DeleteFunc(
+			slices.Clone(testTargets["crosspkg"]),
+			func(pkg *packages.Package) bool { return pkg.PkgPath != crosspkg },
+		)
+	}
+
+	notLoaded := func(name, field, ty string) genreport.Warning {
+		return genreport.Warning{
+			PkgPath: crosspkg,
+			Name:    name,
+			Field:   field,
+			Message: `"` + model + `".` + ty + ` has unexported fields and no Clone method, thus left zero value since ` +
+				`its package "` + model + `" is not loaded: load "` + model + `" along with this package ` +
+				`so that its Clone method is generated there, ` +
+				`implement Clone on it, or place //cloner:reflect as field doc comment`,
+		}
+	}
+	excluded := genreport.Warning{
+		PkgPath: crosspkg,
+		Name:    "Partial",
+		Field:   "Opaque",
+		Message: `"` + model + `".Opaque has unexported fields and no Clone method, thus left zero value since ` +
+			`it is excluded from generation in the loaded package "` + model + `", e.g. by //codegen:ignore: ` +
+			`make it a generation target, ` +
+			`implement Clone on it, or place //cloner:reflect as field doc comment`,
+	}
+
+	t.Run("with model", func(t *testing.T) {
+		out, warnings := generate(t, testTargets["crosspkg"], false)
+		assert.DeepEqual(t, []genreport.Warning{excluded}, warnings)
+		assert.Assert(t, strings.Contains(out, "ID: v.ID.Clone()"), "Clone generated in model must be called")
+	})
+	t.Run("without model", func(t *testing.T) {
+		out, warnings := generate(t, withoutModel(), false)
+		assert.DeepEqual(
+			t,
+			[]genreport.Warning{
+				notLoaded("Partial", "Opaque", "Opaque"),
+				notLoaded("Team", "Owner", "User"),
+				notLoaded("Team", "Groups", "Group"),
+				notLoaded("Team", "Box", "Box"),
+			},
+			warnings,
+		)
+		assert.Assert(t, !strings.Contains(out, "Owner:"), "fields of types of unloaded packages must be left zero value")
+	})
+	t.Run("reflect fallback with model", func(t *testing.T) {
+		// model types are generation targets, thus they are cloned exactly as without --reflect-fallback.
+		// Before, fields of pointers to them were cloned by calling Clone on the pointer, e.g. Owner: v.Owner.Clone().
+		out, warnings := generate(t, testTargets["crosspkg"], true)
+		assert.Equal(t, 0, len(warnings))
+		expected, _ := generate(t, testTargets["crosspkg"], false)
+		assert.Equal(t, cloneMethodOf(expected, "Team"), cloneMethodOf(out, "Team"))
+		assert.Assert(t, !strings.Contains(out, "Owner: v.Owner.Clone()"))
+	})
+	t.Run("reflect fallback without model", func(t *testing.T) {
+		out, warnings := generate(t, withoutModel(), true)
+		assert.Equal(t, 0, len(warnings))
+		assert.Assert(t, strings.Contains(out, "Owner: cloneruntime.DeepClone(v.Owner)"))
+	})
+}
+
+// cloneMethodOf returns the generated Clone method of typeName in src.
+func cloneMethodOf(src, typeName string) string {
+	_, method, _ := strings.Cut(src, "func (v "+typeName+") Clone()")
+	method, _, _ = strings.Cut(method, "//codegen:generated")
+	return method
+}
@@ -1,4 +1,11 @@
 package generationtests
 
-//go:generate go run -race ./_generate_test -e _generate_test,implementor
-//go:generate go run -race github.com/ngicks/go-codegen/codegen cloner -v --chan-disallow --ignore-generated --dir ../testtargets --pkg ./...
+//go:generate go run -race ./_generate_test -e _generate_test,implementor,equal,memo,handlerrule,into,ifaceclone,reflectfallback,crosspkg,atomicclone
+//go:generate go run -race github.com/ngicks/go-codegen/codegen cloner -v --chan-disallow --test --ignore-generated --dir ../testtargets --pkg ./...
+//go:generate go run -race github.com/ngicks/go-codegen/codegen cloner -v --chan-disallow --test --equal --ignore-generated --dir ../testtargets --pkg ./equal
+//go:generate go run -race github.com/ngicks/go-codegen/codegen cloner -v --chan-disallow --test --memo --ignore-generated --dir ../testtargets --pkg ./memo
+//go:generate go run -race github.com/ngicks/go-codegen/codegen cloner -v --chan-disallow --test --into --ignore-generated --dir ../testtargets --pkg ./into
+//go:generate go run -race github.com/ngicks/go-codegen/codegen cloner -v --chan-disallow --test --interface-clone --ignore-generated --dir ../testtargets --pkg ./ifaceclone/...
+//go:generate go run -race github.com/ngicks/go-codegen/codegen cloner -v --chan-disallow --test --reflect-fallback --ignore-generated --dir ../testtargets --pkg ./reflectfallback/...
+//go:generate go run -race github.com/ngicks/go-codegen/codegen cloner -v --chan-disallow --test --atomic-clone --equal --ignore-generated --dir ../testtargets --pkg ./atomicclone
+//go:generate go run -race github.com/ngicks/go-codegen/codegen run -v --config ../testtargets/handlerrule/codegen.yaml
@@ -0,0 +1,50 @@
+package generationtests
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ngicks/go-codegen/codegen/generator/cloner"
+	"github.com/ngicks/go-codegen/codegen/pkg/suffixwriter"
+	"gotest.tools/v3/assert"
+)
+
+func TestGenerate_test(t *testing.T) {
+	testPrinter := suffixwriter.NewTestWriter(".clone", suffixwriter.WithCwd("../testtargets"))
+	cfg := cloner.Config{
+		MatcherConfig: &cloner.MatcherConfig{
+			ChannelHandle: cloner.CopyHandleDisallow,
+		},
+		GenerateTest: true,
+	}
+	err := cfg.Generate(
+		context.Background(),
+		testPrinter.Writer,
+		testTargets["typedirective"],
+	)
+	assert.NilError(t, err)
+	results := testPrinter.Results()
+	for _, k := range slices.Sorted(maps.Keys(results)) {
+		t.Logf("%q:\n%s", k, results[k])
+	}
+
+	var testFile string
+	for k, v := range results {
+		if strings.HasSuffix(k, "typedirective.clone_test.go") {
+			testFile = string(v)
+		}
+	}
+	assert.Assert(t, testFile != "", "results = %v", slices.Sorted(maps.Keys(results)))
+	for _, s := range []string{
+		`clonetest.Skip[Channels]("Fn")`,
+		"func FuzzClone_Forced(f *testing.F)",
+		"func BenchmarkClone_Forced(b *testing.B)",
+		"func FuzzCopy_Renamed(f *testing.F)",
+		"return v.Copy()",
+	} {
+		assert.Assert(t, strings.Contains(testFile, s), "missing %q in\n%s", s, testFile)
+	}
+}
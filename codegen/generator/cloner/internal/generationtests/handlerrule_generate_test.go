@@ -0,0 +1,68 @@
+package generationtests
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ngicks/go-codegen/codegen/generator/cloner"
+	"github.com/ngicks/go-codegen/codegen/pkg/imports"
+	"github.com/ngicks/go-codegen/codegen/pkg/suffixwriter"
+	"gotest.tools/v3/assert"
+)
+
+func TestGenerate_handlerrule(t *testing.T) {
+	const (
+		pkgPath = "github.com/ngicks/go-codegen/codegen/generator/cloner/internal/testtargets/handlerrule"
+		money   = pkgPath + "/money"
+	)
+	pkgs := testTargets["handlerrule"]
+	testPrinter := suffixwriter.NewTestWriter(".cloner", suffixwriter.WithCwd("../testtargets"))
+	var handlers cloner.CustomHandlers
+	for _, rule := range []cloner.HandlerRule{
+		{Type: imports.TargetType{ImportPath: money, Name: "Decimal"}},
+		{
+			Type:    imports.TargetType{ImportPath: money, Name: "Numeric"},
+			Pointer: true,
+			Clone:   imports.TargetType{ImportPath: money, Name: "CloneNumeric"},
+			Equal:   imports.TargetType{ImportPath: money, Name: "EqualNumeric"},
+		},
+		{
+			Type:  imports.TargetType{ImportPath: pkgPath, Name: "Local"},
+			Clone: imports.TargetType{ImportPath: pkgPath, Name: "CloneLocal"},
+		},
+	} {
+		handlers = append(handlers, rule.CustomHandler())
+	}
+	cfg := cloner.Config{
+		MatcherConfig: &cloner.MatcherConfig{
+			ChannelHandle:  cloner.CopyHandleDisallow,
+			CustomHandlers: handlers,
+		},
+		GenerateEqual: true,
+	}
+	err := cfg.Generate(
+		context.Background(),
+		testPrinter.Writer,
+		pkgs,
+	)
+	assert.NilError(t, err)
+	results := testPrinter.Results()
+	var generated strings.Builder
+	for _, k := range slices.Sorted(maps.Keys(results)) {
+		result := results[k]
+		t.Logf("%q:\n%s", k, result)
+		generated.Write(result)
+	}
+	for _, expr := range []string{
+		"D: v.D,",
+		"N: money.CloneNumeric(v.N),",
+		"inner[k] = money.CloneNumeric(v)",
+		"L: CloneLocal(v.L),",
+		"money.EqualNumeric(v.N, other.N)",
+	} {
+		assert.Assert(t, strings.Contains(generated.String(), expr), "%q not found", expr)
+	}
+}
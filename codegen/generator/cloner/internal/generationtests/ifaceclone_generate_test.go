@@ -0,0 +1,34 @@
+package generationtests
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/ngicks/go-codegen/codegen/generator/cloner"
+	"github.com/ngicks/go-codegen/codegen/pkg/suffixwriter"
+	"gotest.tools/v3/assert"
+)
+
+func TestGenerate_ifaceclone(t *testing.T) {
+	pkgs := testTargets["ifaceclone"]
+	testPrinter := suffixwriter.NewTestWriter(".cloner", suffixwriter.WithCwd("../testtargets"))
+	cfg := cloner.Config{
+		MatcherConfig: &cloner.MatcherConfig{
+			ChannelHandle:   cloner.CopyHandleDisallow,
+			InterfaceHandle: cloner.CopyHandleClone,
+		},
+	}
+	err := cfg.Generate(
+		context.Background(),
+		testPrinter.Writer,
+		pkgs,
+	)
+	assert.NilError(t, err)
+	results := testPrinter.Results()
+	for _, k := range slices.Sorted(maps.Keys(results)) {
+		result := results[k]
+		t.Logf("%q:\n%s", k, result)
+	}
+}
@@ -0,0 +1,73 @@
+package tests
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/ngicks/go-codegen/codegen/generator/cloner/internal/testtargets/atomicclone"
+	"gotest.tools/v3/assert"
+)
+
+func TestAtomicClone(t *testing.T) {
+	var org atomicclone.Counters
+	org.Hits.Store(10)
+	org.Ready.Store(true)
+	org.Misses = new(atomic.Uint32)
+	org.Misses.Store(3)
+	org.Config.Store(&atomicclone.Config{Name: "foo", Tags: []string{"a"}})
+	org.Last.Store("last")
+	org.Shared = []*atomic.Int32{new(atomic.Int32), nil}
+	org.Shared[0].Store(5)
+	org.Ignored.Store(7)
+
+	cloned := org.Clone()
+	assert.Equal(t, cloned.Hits.Load(), int64(10))
+	assert.Assert(t, cloned.Ready.Load())
+	assert.Equal(t, cloned.Misses.Load(), uint32(3))
+	assert.DeepEqual(t, cloned.Config.Load(), &atomicclone.Config{Name: "foo", Tags: []string{"a"}})
+	assert.Equal(t, cloned.Last.Load(), "last")
+	assert.Equal(t, cloned.Shared[0].Load(), int32(5))
+	assert.Assert(t, cloned.Shared[1] == nil)
+	assert.Equal(t, cloned.Ignored.Load(), int64(0))
+	assert.Assert(t, cloned.Equal(&org), "ignored fields are not compared")
+
+	assert.Assert(t, cloned.Misses != org.Misses)
+	assert.Assert(t, cloned.Config.Load() != org.Config.Load())
+	assert.Assert(t, cloned.Shared[0] != org.Shared[0])
+
+	cloned.Hits.Add(1)
+	cloned.Misses.Add(1)
+	cloned.Config.Load().Tags[0] = "b"
+	cloned.Shared[0].Add(1)
+	assert.Equal(t, org.Hits.Load(), int64(10))
+	assert.Equal(t, org.Misses.Load(), uint32(3))
+	assert.Equal(t, org.Config.Load().Tags[0], "a")
+	assert.Equal(t, org.Shared[0].Load(), int32(5))
+}
+
+func TestAtomicClone_zero(t *testing.T) {
+	var org atomicclone.Counters
+	cloned := org.Clone()
+	assert.Assert(t, cloned.Config.Load() == nil)
+	assert.Assert(t, cloned.Last.Load() == nil)
+	assert.Assert(t, cloned.Misses == nil)
+	assert.Assert(t, cloned.Equal(&org))
+}
+
+func TestAtomicClone_pointer(t *testing.T) {
+	var org atomicclone.Plain
+	limit := 5
+	org.Limit.Store(&limit)
+	cloned := org.Clone()
+	assert.Equal(t, *cloned.Limit.Load(), 5)
+	assert.Assert(t, cloned.Limit.Load() != &limit)
+	assert.Assert(t, cloned.Equal(&org))
+
+	var generic atomicclone.Generic[[]int]
+	generic.Current.Store(&[]int{1, 2})
+	generic.Count.Store(2)
+	clonedGeneric := generic.CloneFunc(func(v []int) []int { return append([]int(nil), v...) })
+	(*clonedGeneric.Current.Load())[0] = 3
+	assert.DeepEqual(t, *generic.Current.Load(), []int{1, 2})
+	assert.Equal(t, clonedGeneric.Count.Load(), uint64(2))
+}
@@ -0,0 +1,34 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/ngicks/go-codegen/codegen/generator/cloner/internal/testtargets/crosspkg"
+	"github.com/ngicks/go-codegen/codegen/generator/cloner/internal/testtargets/crosspkg/model"
+	"gotest.tools/v3/assert"
+)
+
+func TestCrossPackage(t *testing.T) {
+	owner := model.NewUser("owner", "a", "b")
+	org := crosspkg.Team{
+		Owner:  &owner,
+		Groups: []model.Group{model.NewGroup("g", &owner)},
+		Box:    model.NewBox([]int{1, 2}),
+	}
+
+	cloned := org.Clone()
+
+	assert.Assert(t, cloned.Owner != org.Owner)
+	assert.Equal(t, cloned.Owner.Name(), "owner")
+	cloned.Owner.Tags()[0] = "mod"
+	assert.Equal(t, org.Owner.Tags()[0], "a")
+
+	member := cloned.Groups[0].Members()[0]
+	assert.Assert(t, member != &owner)
+	assert.DeepEqual(t, member.Tags(), []string{"a", "b"})
+	member.Tags()[1] = "mod"
+	assert.Equal(t, owner.Tags()[1], "b")
+
+	cloned.Box.Get()[0] = 5
+	assert.Equal(t, org.Box.Get()[0], 1)
+}
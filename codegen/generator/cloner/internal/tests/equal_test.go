@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ngicks/go-codegen/codegen/generator/cloner/internal/testtargets/equal"
+	"gotest.tools/v3/assert"
+)
+
+func TestEqual(t *testing.T) {
+	one, two := 1, 2
+	shared := 5
+	org := equal.A{
+		Str:      "foo",
+		Bytes:    []byte("bar"),
+		M:        map[string]int{"baz": 1},
+		Ptr:      &one,
+		Time:     time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC),
+		Route:    []*map[string][2]int{{"qux": {1, 2}}, nil},
+		Ignored:  1,
+		Shared:   &shared,
+		NilEmpty: nil,
+		B:        equal.B{Name: "b"},
+		Bs:       map[string]*equal.B{"b": {Name: "b"}, "nil": nil},
+	}
+
+	cloned := org.Clone()
+	// Shared is compared by identity.
+	cloned.Shared = org.Shared
+	assert.Assert(t, org.Equal(cloned))
+	assert.Assert(t, cloned.Equal(org))
+
+	for _, tc := range []struct {
+		name   string
+		mutate func(a *equal.A)
+		equal  bool
+	}{
+		{"Str", func(a *equal.A) { a.Str = "foo2" }, false},
+		{"Bytes nil", func(a *equal.A) { a.Bytes = nil }, false},
+		{"Bytes empty", func(a *equal.A) { a.Bytes = []byte{} }, false},
+		{"M nil", func(a *equal.A) { a.M = nil }, false},
+		{"Ptr same value", func(a *equal.A) { v := 1; a.Ptr = &v }, true},
+		{"Ptr other value", func(a *equal.A) { a.Ptr = &two }, false},
+		{"Ptr nil", func(a *equal.A) { a.Ptr = nil }, false},
+		{"Time other location", func(a *equal.A) { a.Time = a.Time.In(time.FixedZone("", 3600)) }, true},
+		{"Route deep", func(a *equal.A) { (*a.Route[0])["qux"] = [2]int{1, 3} }, false},
+		{"Route nil elem", func(a *equal.A) { a.Route[1] = &map[string][2]int{} }, false},
+		{"Ignored", func(a *equal.A) { a.Ignored = 2 }, true},
+		{"Shared same value", func(a *equal.A) { v := shared; a.Shared = &v }, false},
+		{"NilEmpty empty", func(a *equal.A) { a.NilEmpty = []string{} }, true},
+		{"B", func(a *equal.A) { a.B.Name = "c" }, false},
+		{"Bs nil elem", func(a *equal.A) { a.Bs["nil"] = &equal.B{} }, false},
+		{"Bs missing key", func(a *equal.A) { delete(a.Bs, "nil"); a.Bs["other"] = nil }, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mutated := org.Clone()
+			mutated.Shared = org.Shared
+			tc.mutate(&mutated)
+			assert.Equal(t, tc.equal, org.Equal(mutated))
+			assert.Equal(t, tc.equal, mutated.Equal(org))
+		})
+	}
+}
+
+func TestEqualFunc(t *testing.T) {
+	eqInt := func(x, y int) bool { return x == y }
+	org := equal.Param[int]{T: 1, Ts: []int{1, 2}}
+	assert.Assert(t, org.EqualFunc(org.CloneFunc(func(i int) int { return i }), eqInt))
+	assert.Assert(t, !org.EqualFunc(equal.Param[int]{T: 1, Ts: []int{1, 3}}, eqInt))
+	assert.Assert(t, !org.EqualFunc(equal.Param[int]{T: 1}, eqInt))
+
+	nested := equal.Nested{P: equal.Param[[]int]{T: []int{1}, Ts: [][]int{nil, {}}}}
+	assert.Assert(t, nested.Equal(nested.Clone()))
+	assert.Assert(t, !nested.Equal(equal.Nested{P: equal.Param[[]int]{T: []int{1}, Ts: [][]int{{}, {}}}}))
+}
@@ -0,0 +1,33 @@
+package tests
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ngicks/go-codegen/codegen/generator/cloner/internal/testtargets/handlerrule"
+	"github.com/ngicks/go-codegen/codegen/generator/cloner/internal/testtargets/handlerrule/money"
+	"gotest.tools/v3/assert"
+)
+
+func TestHandlerRule(t *testing.T) {
+	org := handlerrule.A{
+		D:  money.New(15, -1),
+		N:  &money.Numeric{Int: big.NewInt(10), Valid: true},
+		Ns: []*money.Numeric{{Int: big.NewInt(20)}, nil},
+		L:  handlerrule.Local{V: []int{1, 2}},
+	}
+	cloned := org.Clone()
+	assert.Assert(t, org.Equal(cloned))
+	assert.Equal(t, org.D, cloned.D)
+	assert.Assert(t, org.N != cloned.N)
+	assert.Assert(t, org.N.Int != cloned.N.Int)
+	assert.Assert(t, org.Ns[0] != cloned.Ns[0])
+	assert.Assert(t, cloned.Ns[1] == nil)
+
+	cloned.N.Int.SetInt64(11)
+	assert.Equal(t, int64(10), org.N.Int.Int64())
+	assert.Assert(t, !org.Equal(cloned))
+
+	cloned.L.V[0] = 5
+	assert.Equal(t, 1, org.L.V[0])
+}
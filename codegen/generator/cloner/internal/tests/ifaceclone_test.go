@@ -0,0 +1,73 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/ngicks/go-codegen/codegen/generator/cloner/internal/testtargets/ifaceclone"
+	"github.com/ngicks/go-codegen/codegen/generator/cloner/internal/testtargets/ifaceclone/shape"
+	cloneruntime "github.com/ngicks/go-codegen/pkg/cloner/runtime"
+	"gotest.tools/v3/assert"
+)
+
+// circle is unknown to the generator.
+type circle struct {
+	Center *shape.Point
+	R      float64
+}
+
+func (c circle) Area() float64 {
+	return 3 * c.R * c.R
+}
+
+func TestCloneInterface(t *testing.T) {
+	org := ifaceclone.Canvas{
+		Main: shape.Polygon{Points: []shape.Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}}},
+		Layers: []shape.Shape{
+			&shape.Polygon{Points: []shape.Point{{X: 1, Y: 1}}},
+			&shape.Label{Text: "label", Tags: []string{"a"}},
+			ifaceclone.Group{Members: []shape.Shape{shape.Polygon{Points: []shape.Point{{X: 2, Y: 2}}}}},
+			(*shape.Polygon)(nil),
+			nil,
+		},
+		ByName: map[string]shape.Shape{"c": circle{Center: &shape.Point{}, R: 1}},
+		Named:  ifaceclone.Tag{Values: []string{"tag"}},
+	}
+
+	cloned := org.Clone()
+	assert.DeepEqual(t, org, cloned)
+
+	cloned.Main.(shape.Polygon).Points[0].X = 5
+	assert.Equal(t, org.Main.(shape.Polygon).Points[0].X, float64(0))
+
+	assert.Assert(t, cloned.Layers[0] != org.Layers[0])
+	cloned.Layers[0].(*shape.Polygon).Points[0].X = 5
+	assert.Equal(t, org.Layers[0].(*shape.Polygon).Points[0].X, float64(1))
+
+	cloned.Layers[1].(*shape.Label).Tags[0] = "b"
+	assert.Equal(t, org.Layers[1].(*shape.Label).Tags[0], "a")
+
+	cloned.Layers[2].(ifaceclone.Group).Members[0].(shape.Polygon).Points[0].X = 5
+	assert.Equal(t, org.Layers[2].(ifaceclone.Group).Members[0].(shape.Polygon).Points[0].X, float64(2))
+
+	assert.Assert(t, cloned.Layers[3].(*shape.Polygon) == nil)
+	assert.Assert(t, cloned.Layers[4] == nil)
+
+	cloned.Named.(ifaceclone.Tag).Values[0] = "mod"
+	assert.Equal(t, org.Named.(ifaceclone.Tag).Values[0], "tag")
+
+	// types unknown to the generator are copied unless registered.
+	assert.Assert(t, cloned.ByName["c"].(circle).Center == org.ByName["c"].(circle).Center)
+
+	cloneruntime.Register(func(c circle) circle {
+		if c.Center != nil {
+			center := *c.Center
+			c.Center = &center
+		}
+		return c
+	})
+	defer cloneruntime.Unregister[circle]()
+
+	cloned = org.Clone()
+	assert.DeepEqual(t, org, cloned)
+	assert.Assert(t, cloned.ByName["c"].(circle).Center != org.ByName["c"].(circle).Center)
+}
@@ -0,0 +1,95 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/ngicks/go-codegen/codegen/generator/cloner/internal/testtargets/into"
+	"gotest.tools/v3/assert"
+)
+
+func newFrame(seq int) into.Frame {
+	last := "last"
+	e := into.Entity{ID: seq, Pos: &[2]float64{1, 2}, Children: []int{seq, seq + 1}}
+	return into.Frame{
+		Seq:      seq,
+		Samples:  []float64{1, 2, 3},
+		Tags:     map[string]string{"foo": "bar", "baz": "qux"},
+		Meta:     &into.Meta{Name: "meta", Labels: []string{"a", "b"}},
+		Entities: []into.Entity{e, {ID: seq + 1}},
+		Index:    map[string]*into.Entity{"e": &e, "nil": nil},
+		Grid:     [2][]int{{1, 2}, nil},
+		Buf:      into.Buffer[string]{Items: []string{"x", "y"}, Last: &last},
+	}
+}
+
+func TestCloneInto(t *testing.T) {
+	org := newFrame(1)
+
+	var dst into.Frame
+	org.CloneInto(&dst)
+	assert.DeepEqual(t, org.Clone(), dst)
+
+	// no memory is shared.
+	dst.Samples[0] = 10
+	dst.Tags["foo"] = "foo"
+	dst.Meta.Labels[0] = "c"
+	dst.Entities[0].Children[0] = 10
+	*dst.Index["e"].Pos = [2]float64{5, 5}
+	dst.Grid[0][0] = 10
+	*dst.Buf.Last = "changed"
+	assert.DeepEqual(t, newFrame(1), org)
+
+	// allocations of dst are reused.
+	samples, meta, children, indexed := &dst.Samples[0], dst.Meta, &dst.Entities[0].Children[0], dst.Index["e"]
+	org.CloneInto(&dst)
+	assert.DeepEqual(t, org.Clone(), dst)
+	assert.Assert(t, samples == &dst.Samples[0])
+	assert.Assert(t, meta == dst.Meta)
+	assert.Assert(t, children == &dst.Entities[0].Children[0])
+	assert.Assert(t, indexed == dst.Index["e"])
+
+	allocs := testing.AllocsPerRun(10, func() { org.CloneInto(&dst) })
+	assert.Equal(t, float64(0), allocs)
+}
+
+func TestCloneInto_shrink(t *testing.T) {
+	var dst into.Frame
+	big := newFrame(1)
+	big.CloneInto(&dst)
+
+	small := into.Frame{
+		Samples:  []float64{},
+		Tags:     map[string]string{"foo": "foo"},
+		Entities: []into.Entity{{ID: 5}},
+		Index:    map[string]*into.Entity{"nil": {ID: 3}},
+	}
+	small.CloneInto(&dst)
+	assert.DeepEqual(t, small.Clone(), dst)
+	assert.Assert(t, dst.Samples != nil)
+	assert.Assert(t, dst.Meta == nil)
+	assert.Assert(t, dst.Grid[0] == nil)
+	assert.Assert(t, dst.Buf.Last == nil)
+
+	var entities into.Entities
+	into.Entities(big.Entities).CloneInto(&entities)
+	assert.DeepEqual(t, big.Entities, []into.Entity(entities))
+}
+
+func TestCloneInto_zeroesIgnored(t *testing.T) {
+	// Clone leaves ignored and no-copy fields zero value. CloneInto must not keep old ones in dst.
+	org := newFrame(1)
+	org.Done = make(chan struct{})
+
+	dst := newFrame(2)
+	dst.Done = make(chan struct{})
+	org.CloneInto(&dst)
+	assert.Assert(t, dst.Done == nil)
+	assert.DeepEqual(t, org.Clone(), dst)
+
+	var c into.Counter
+	c.Mu.Lock()
+	src := &into.Counter{N: 5}
+	src.CloneInto(&c)
+	assert.Equal(t, 5, c.N)
+	assert.Assert(t, c.Mu.TryLock())
+}
@@ -0,0 +1,98 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/ngicks/go-codegen/codegen/generator/cloner/internal/testtargets/memo"
+	"gotest.tools/v3/assert"
+)
+
+func TestMemo_cycle(t *testing.T) {
+	var org memo.List
+	for i := range 3 {
+		n := &memo.Node{Value: i, Prev: org.Tail}
+		if org.Tail == nil {
+			org.Head = n
+		} else {
+			org.Tail.Next = n
+		}
+		org.Tail = n
+	}
+	// makes it circular.
+	org.Head.Prev = org.Tail
+	org.Tail.Next = org.Head
+
+	cloned := org.Clone()
+
+	n := cloned.Head
+	orgN := org.Head
+	for i := range 3 {
+		assert.Assert(t, n != orgN)
+		assert.Equal(t, i, n.Value)
+		assert.Assert(t, n.Next.Prev == n)
+		assert.Assert(t, n.Prev.Next == n)
+		n, orgN = n.Next, orgN.Next
+	}
+	assert.Assert(t, n == cloned.Head)
+	assert.Assert(t, cloned.Tail == cloned.Head.Prev)
+
+	cloned.Head.Value = 10
+	assert.Equal(t, 0, org.Head.Value)
+}
+
+func TestMemo_shared(t *testing.T) {
+	i, j := 1, 2
+	pj := &j
+	org := memo.Shared{
+		A: &i,
+		B: &i,
+		C: []*int{&i, &j, nil},
+		D: map[string]**int{"a": &pj, "b": &pj},
+	}
+
+	cloned := org.Clone()
+	assert.Assert(t, cloned.A != org.A)
+	assert.Assert(t, cloned.A == cloned.B)
+	assert.Assert(t, cloned.C[0] == cloned.A)
+	assert.Assert(t, cloned.C[1] != &j)
+	assert.Equal(t, 2, *cloned.C[1])
+	assert.Assert(t, cloned.C[2] == nil)
+	assert.Assert(t, cloned.D["a"] == cloned.D["b"])
+	assert.Assert(t, *cloned.D["a"] == cloned.C[1])
+
+	*cloned.A = 5
+	assert.Equal(t, 5, *cloned.B)
+	assert.Equal(t, 1, i)
+}
+
+func TestMemo_generic(t *testing.T) {
+	root := &memo.TreeNode[string]{Value: "root"}
+	for _, v := range []string{"foo", "bar"} {
+		root.Children = append(root.Children, &memo.TreeNode[string]{Value: v, Parent: root})
+	}
+	org := memo.Tree[string]{Root: root, Nodes: append([]*memo.TreeNode[string]{root}, root.Children...)}
+
+	cloned := org.CloneFunc(func(s string) string { return s })
+	assert.Assert(t, cloned.Root != org.Root)
+	assert.Assert(t, cloned.Nodes[0] == cloned.Root)
+	for i, c := range cloned.Root.Children {
+		assert.Assert(t, c.Parent == cloned.Root)
+		assert.Assert(t, cloned.Nodes[i+1] == c)
+		assert.Equal(t, org.Root.Children[i].Value, c.Value)
+	}
+}
+
+func TestMemo_interior(t *testing.T) {
+	pair := &memo.Pair{L: 1, R: 2}
+	org := memo.Interior{
+		Whole: pair,
+		First: &pair.L,
+		Again: pair,
+	}
+
+	cloned := org.Clone()
+	assert.Assert(t, cloned.Whole != org.Whole)
+	assert.Assert(t, cloned.Whole == cloned.Again)
+	assert.Equal(t, 1, *cloned.First)
+	assert.Equal(t, memo.Pair{L: 1, R: 2}, *cloned.Whole)
+}
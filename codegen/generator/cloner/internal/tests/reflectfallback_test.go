@@ -0,0 +1,50 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/ngicks/go-codegen/codegen/generator/cloner/internal/testtargets/reflectfallback"
+	"github.com/ngicks/go-codegen/codegen/generator/cloner/internal/testtargets/reflectfallback/vendorlike"
+	"gotest.tools/v3/assert"
+)
+
+func TestReflectFallback(t *testing.T) {
+	org := reflectfallback.Fallback{
+		Set:   vendorlike.NewSet("a"),
+		Sets:  []vendorlike.Set{*vendorlike.NewSet("b")},
+		Ring:  vendorlike.NewRing(1, 2, 3),
+		Names: []string{"foo"},
+	}
+
+	cloned := org.Clone()
+	assert.Assert(t, cloned.Set != org.Set)
+	cloned.Set.Add("c")
+	cloned.Sets[0].Add("d")
+	assert.Equal(t, org.Set.Len(), 1)
+	assert.Assert(t, !org.Sets[0].Has("d"))
+
+	r := cloned.Ring
+	for _, v := range []int{1, 2, 3} {
+		assert.Equal(t, r.Value, v)
+		r = r.Next()
+	}
+	assert.Assert(t, r == cloned.Ring, "cycle is preserved")
+	assert.Assert(t, cloned.Ring != org.Ring)
+}
+
+func TestReflectDirective(t *testing.T) {
+	child := &reflectfallback.Directed{Set: vendorlike.NewSet("child")}
+	org := reflectfallback.Directed{
+		Set:    vendorlike.NewSet("a"),
+		Nested: map[string][]*reflectfallback.Directed{"x": {child, child}},
+	}
+
+	cloned := org.Clone()
+	cloned.Set.Add("b")
+	assert.Assert(t, !org.Set.Has("b"))
+
+	c := cloned.Nested["x"]
+	assert.Assert(t, c[0] != child)
+	assert.Assert(t, c[0] == c[1], "aliasing is preserved")
+	assert.Assert(t, c[0].Set.Has("child"))
+}
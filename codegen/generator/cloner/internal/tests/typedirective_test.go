@@ -0,0 +1,39 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/ngicks/go-codegen/codegen/generator/cloner/internal/testtargets/typedirective"
+	"gotest.tools/v3/assert"
+)
+
+func TestTypeDirective(t *testing.T) {
+	org := typedirective.Holder{
+		Forced:   typedirective.Forced{A: 1, B: "b"},
+		Pointer:  typedirective.Pointer{Values: []int{1, 2}},
+		Pointers: typedirective.PointerSlice{{Values: []int{3}}, nil},
+		Renamed:  &typedirective.Renamed{Values: map[string]int{"a": 1}},
+		Channels: []typedirective.Channels{{Ch: make(chan int, 3), Fn: func() {}, Ids: []int{4}}},
+	}
+
+	cloned := org.Clone()
+	assert.DeepEqual(t, org.Forced, cloned.Forced)
+
+	cloned.Pointer.Values[0] = 5
+	assert.Equal(t, org.Pointer.Values[0], 1)
+
+	assert.Assert(t, cloned.Pointers[0] != org.Pointers[0])
+	assert.Assert(t, cloned.Pointers[1] == nil)
+	cloned.Pointers[0].Values[0] = 5
+	assert.Equal(t, org.Pointers[0].Values[0], 3)
+
+	cloned.Renamed.Values["a"] = 5
+	assert.Equal(t, org.Renamed.Values["a"], 1)
+
+	// chan=make overrides the global option.
+	assert.Assert(t, cloned.Channels[0].Ch != org.Channels[0].Ch)
+	assert.Equal(t, cap(cloned.Channels[0].Ch), 3)
+	assert.Assert(t, cloned.Channels[0].Fn == nil)
+	cloned.Channels[0].Ids[0] = 5
+	assert.Equal(t, org.Channels[0].Ids[0], 4)
+}
@@ -0,0 +1,19 @@
+// Code generated by github.com/ngicks/go-codegen/codegen DO NOT EDIT.
+// to regenerate the code, refer to help by invoking
+// go run github.com/ngicks/go-codegen/codegen cloner --help
+
+package any
+
+import (
+	"testing"
+
+	clonetest "github.com/ngicks/go-codegen/pkg/cloner/runtime/clonetest"
+)
+
+func FuzzClone_Any(f *testing.F) {
+	clonetest.Fuzz(f, func(v Any) Any { return v.Clone() })
+}
+
+func BenchmarkClone_Any(b *testing.B) {
+	clonetest.Benchmark(b, func(v Any) Any { return v.Clone() })
+}
@@ -0,0 +1,190 @@
+// Code generated by github.com/ngicks/go-codegen/codegen DO NOT EDIT.
+// to regenerate the code, refer to help by invoking
+// go run github.com/ngicks/go-codegen/codegen cloner --help
+
+package atomicclone
+
+import (
+	"reflect"
+	"slices"
+	"sync/atomic"
+)
+
+//codegen:generated
+func (v *Counters) Clone() Counters {
+	return Counters{
+		Hits: func(src *atomic.Int64) (dst atomic.Int64) {
+			dst.Store(src.Load())
+			return
+		}(&v.Hits),
+		Ready: func(src *atomic.Bool) (dst atomic.Bool) {
+			dst.Store(src.Load())
+			return
+		}(&v.Ready),
+		Misses: func(src *atomic.Uint32) *atomic.Uint32 {
+			if src == nil {
+				return nil
+			}
+			dst := new(atomic.Uint32)
+			dst.Store(src.Load())
+			return dst
+		}(v.Misses),
+		Config: func(src *atomic.Pointer[Config]) (dst atomic.Pointer[Config]) {
+			p := src.Load()
+			dst.Store(func(v *Config) *Config {
+				var out *Config
+
+				inner := out
+				if v != nil {
+					v := *v
+					vv := v.Clone()
+					inner = &vv
+				}
+				out = inner
+
+				return out
+			}(p))
+			return
+		}(&v.Config),
+		Last: func(src *atomic.Value) (dst atomic.Value) {
+			if x := src.Load(); x != nil {
+				dst.Store(x)
+			}
+			return
+		}(&v.Last),
+		Shared: func(v []*atomic.Int32) []*atomic.Int32 {
+			var out []*atomic.Int32
+
+			if v != nil {
+				out = make([]*atomic.Int32, len(v), cap(v))
+			}
+
+			inner := out
+			for k, v := range v {
+				inner[k] = func(src *atomic.Int32) *atomic.Int32 {
+					if src == nil {
+						return nil
+					}
+					dst := new(atomic.Int32)
+					dst.Store(src.Load())
+					return dst
+				}(v)
+			}
+			out = inner
+
+			return out
+		}(v.Shared),
+	}
+}
+
+//codegen:generated
+func (v *Counters) Equal(other *Counters) bool {
+	return v.Hits.Load() == other.Hits.Load() &&
+		v.Ready.Load() == other.Ready.Load() &&
+		func(x, y *atomic.Uint32) bool {
+			if x == nil || y == nil {
+				return x == y
+			}
+			return x.Load() == y.Load()
+		}(v.Misses, other.Misses) &&
+		reflect.DeepEqual(v.Config.Load(), other.Config.Load()) &&
+		reflect.DeepEqual(v.Last.Load(), other.Last.Load()) &&
+		func(x, y []*atomic.Int32) bool {
+			if len(x) != len(y) || (x == nil) != (y == nil) {
+				return false
+			}
+			for i := range x {
+				if !(func(x, y *atomic.Int32) bool {
+					if x == nil || y == nil {
+						return x == y
+					}
+					return x.Load() == y.Load()
+				}(x[i], y[i])) {
+					return false
+				}
+			}
+			return true
+		}(v.Shared, other.Shared)
+}
+
+//codegen:generated
+func (v Config) Clone() Config {
+	return Config{
+		Name: v.Name,
+		Tags: func(src []string) []string {
+			if src == nil {
+				return nil
+			}
+			dst := make([]string, len(src), cap(src))
+			copy(dst, src)
+			return dst
+		}(v.Tags),
+	}
+}
+
+//codegen:generated
+func (v Config) Equal(other Config) bool {
+	return v.Name == other.Name &&
+		(v.Tags == nil) == (other.Tags == nil) && slices.Equal(v.Tags, other.Tags)
+}
+
+//codegen:generated
+func (v *Generic[T]) CloneFunc(cloneT func(T) T) Generic[T] {
+	return Generic[T]{
+		Current: func(src *atomic.Pointer[T]) (dst atomic.Pointer[T]) {
+			p := src.Load()
+			dst.Store(func(v *T) *T {
+				var out *T
+
+				inner := out
+				if v != nil {
+					v := *v
+					vv := cloneT(v)
+					inner = &vv
+				}
+				out = inner
+
+				return out
+			}(p))
+			return
+		}(&v.Current),
+		Count: func(src *atomic.Uint64) (dst atomic.Uint64) {
+			dst.Store(src.Load())
+			return
+		}(&v.Count),
+	}
+}
+
+//codegen:generated
+func (v *Generic[T]) EqualFunc(other *Generic[T], eqT func(T, T) bool) bool {
+	return reflect.DeepEqual(v.Current.Load(), other.Current.Load()) &&
+		v.Count.Load() == other.Count.Load()
+}
+
+//codegen:generated
+func (v *Plain) Clone() Plain {
+	return Plain{
+		Limit: func(src *atomic.Pointer[int]) (dst atomic.Pointer[int]) {
+			p := src.Load()
+			dst.Store(func(v *int) *int {
+				var out *int
+
+				inner := out
+				if v != nil {
+					v := *v
+					vv := v
+					inner = &vv
+				}
+				out = inner
+
+				return out
+			}(p))
+			return
+		}(&v.Limit),
+	}
+}
+
+//codegen:generated
+func (v *Plain) Equal(other *Plain) bool {
+	return reflect.DeepEqual(v.Limit.Load(), other.Limit.Load())
+}
@@ -0,0 +1,36 @@
+package atomicclone
+
+import (
+	"sync/atomic"
+)
+
+//cloner:ptr
+type Counters struct {
+	Hits   atomic.Int64
+	Ready  atomic.Bool
+	Misses *atomic.Uint32
+	Config atomic.Pointer[Config]
+	Last   atomic.Value
+	Shared []*atomic.Int32
+	//cloner:ignore
+	Ignored atomic.Int64
+	// can not be read without copying, thus ignored as no-copy objects.
+	Values []atomic.Int64
+}
+
+type Config struct {
+	Name string
+	Tags []string
+}
+
+//cloner:ptr
+type Generic[T any] struct {
+	Current atomic.Pointer[T]
+	Count   atomic.Uint64
+}
+
+//cloner:ptr
+type Plain struct {
+	// *int is cloned, not shared.
+	Limit atomic.Pointer[int]
+}
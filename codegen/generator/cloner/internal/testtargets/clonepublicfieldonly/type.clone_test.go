@@ -0,0 +1,31 @@
+// Code generated by github.com/ngicks/go-codegen/codegen DO NOT EDIT.
+// to regenerate the code, refer to help by invoking
+// go run github.com/ngicks/go-codegen/codegen cloner --help
+
+package clonepublicfieldonly
+
+import (
+	"testing"
+
+	clonetest "github.com/ngicks/go-codegen/pkg/cloner/runtime/clonetest"
+)
+
+func init() {
+	clonetest.Skip[exampleStruct]("f2", "f4")
+}
+
+func FuzzClone_exampleStruct(f *testing.F) {
+	clonetest.Fuzz(f, func(v exampleStruct) exampleStruct { return v.Clone() })
+}
+
+func BenchmarkClone_exampleStruct(b *testing.B) {
+	clonetest.Benchmark(b, func(v exampleStruct) exampleStruct { return v.Clone() })
+}
+
+func FuzzClone_exampleMap(f *testing.F) {
+	clonetest.Fuzz(f, func(v exampleMap) exampleMap { return v.Clone() })
+}
+
+func BenchmarkClone_exampleMap(b *testing.B) {
+	clonetest.Benchmark(b, func(v exampleMap) exampleMap { return v.Clone() })
+}
@@ -0,0 +1,21 @@
+// Code generated by github.com/ngicks/go-codegen/codegen DO NOT EDIT.
+// to regenerate the code, refer to help by invoking
+// go run github.com/ngicks/go-codegen/codegen cloner --help
+
+//go:build linux || darwin
+
+package constraint
+
+import (
+	"testing"
+
+	clonetest "github.com/ngicks/go-codegen/pkg/cloner/runtime/clonetest"
+)
+
+func FuzzClone_A(f *testing.F) {
+	clonetest.Fuzz(f, func(v A) A { return v.Clone() })
+}
+
+func BenchmarkClone_A(b *testing.B) {
+	clonetest.Benchmark(b, func(v A) A { return v.Clone() })
+}
@@ -0,0 +1,68 @@
+// Code generated by github.com/ngicks/go-codegen/codegen DO NOT EDIT.
+// to regenerate the code, refer to help by invoking
+// go run github.com/ngicks/go-codegen/codegen cloner --help
+
+package crosspkg
+
+import (
+	"github.com/ngicks/go-codegen/codegen/generator/cloner/internal/testtargets/crosspkg/model"
+)
+
+//codegen:generated
+func (v Team) Clone() Team {
+	return Team{
+		Owner: func(v *model.User) *model.User {
+			var out *model.User
+
+			inner := out
+			if v != nil {
+				v := *v
+				vv := v.Clone()
+				inner = &vv
+			}
+			out = inner
+
+			return out
+		}(v.Owner),
+		Groups: func(v []model.Group) []model.Group {
+			var out []model.Group
+
+			if v != nil {
+				out = make([]model.Group, len(v), cap(v))
+			}
+
+			inner := out
+			for k, v := range v {
+				inner[k] = v.Clone()
+			}
+			out = inner
+
+			return out
+		}(v.Groups),
+		ID: v.ID.Clone(),
+		Box: v.Box.CloneFunc(
+			func(src []int) []int {
+				if src == nil {
+					return nil
+				}
+				dst := make([]int, len(src), cap(src))
+				copy(dst, src)
+				return dst
+			},
+		),
+	}
+}
+
+//codegen:generated
+func (v Partial) Clone() Partial {
+	return Partial{
+		Name: func(src []string) []string {
+			if src == nil {
+				return nil
+			}
+			dst := make([]string, len(src), cap(src))
+			copy(dst, src)
+			return dst
+		}(v.Name),
+	}
+}
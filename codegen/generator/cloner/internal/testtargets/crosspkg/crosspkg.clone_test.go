@@ -0,0 +1,31 @@
+// Code generated by github.com/ngicks/go-codegen/codegen DO NOT EDIT.
+// to regenerate the code, refer to help by invoking
+// go run github.com/ngicks/go-codegen/codegen cloner --help
+
+package crosspkg
+
+import (
+	"testing"
+
+	clonetest "github.com/ngicks/go-codegen/pkg/cloner/runtime/clonetest"
+)
+
+func init() {
+	clonetest.Skip[Partial]("Opaque")
+}
+
+func FuzzClone_Team(f *testing.F) {
+	clonetest.Fuzz(f, func(v Team) Team { return v.Clone() })
+}
+
+func BenchmarkClone_Team(b *testing.B) {
+	clonetest.Benchmark(b, func(v Team) Team { return v.Clone() })
+}
+
+func FuzzClone_Partial(f *testing.F) {
+	clonetest.Fuzz(f, func(v Partial) Partial { return v.Clone() })
+}
+
+func BenchmarkClone_Partial(b *testing.B) {
+	clonetest.Benchmark(b, func(v Partial) Partial { return v.Clone() })
+}
@@ -0,0 +1,18 @@
+package crosspkg
+
+import "github.com/ngicks/go-codegen/codegen/generator/cloner/internal/testtargets/crosspkg/model"
+
+// Team refers to types of model.
+// The cloner calls Clone methods generated for them in model if model is loaded as well.
+type Team struct {
+	Owner  *model.User
+	Groups []model.Group
+	ID     model.ID
+	Box    model.Box[[]int]
+}
+
+// Partial can not be cloned completely since Opaque is not a generation target.
+type Partial struct {
+	Name   []string
+	Opaque model.Opaque
+}
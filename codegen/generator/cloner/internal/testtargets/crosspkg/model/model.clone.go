@@ -0,0 +1,72 @@
+// Code generated by github.com/ngicks/go-codegen/codegen DO NOT EDIT.
+// to regenerate the code, refer to help by invoking
+// go run github.com/ngicks/go-codegen/codegen cloner --help
+
+package model
+
+//codegen:generated
+func (v User) Clone() User {
+	return User{
+		name: v.name,
+		tags: func(src []string) []string {
+			if src == nil {
+				return nil
+			}
+			dst := make([]string, len(src), cap(src))
+			copy(dst, src)
+			return dst
+		}(v.tags),
+	}
+}
+
+//codegen:generated
+func (v ID) Clone() ID {
+	return ID{
+		value: v.value,
+	}
+}
+
+//codegen:generated
+func (v Group) Clone() Group {
+	return Group{
+		id: v.id.Clone(),
+		members: func(v []*User) []*User {
+			var out []*User
+
+			if v != nil {
+				out = make([]*User, len(v), cap(v))
+			}
+
+			inner := out
+			for k, v := range v {
+				outer := &inner
+				var inner *User
+
+				if v != nil {
+					v := *v
+					vv := v.Clone()
+					inner = &vv
+				}
+				(*outer)[k] = inner
+			}
+			out = inner
+
+			return out
+		}(v.members),
+		Public: func(src []int) []int {
+			if src == nil {
+				return nil
+			}
+			dst := make([]int, len(src), cap(src))
+			copy(dst, src)
+			return dst
+		}(v.Public),
+	}
+}
+
+//codegen:generated
+func (v Box[T]) CloneFunc(cloneT func(T) T) Box[T] {
+	return Box[T]{
+		v: cloneT(v.v),
+	}
+}
@@ -0,0 +1,35 @@
+// Code generated by github.com/ngicks/go-codegen/codegen DO NOT EDIT.
+// to regenerate the code, refer to help by invoking
+// go run github.com/ngicks/go-codegen/codegen cloner --help
+
+package model
+
+import (
+	"testing"
+
+	clonetest "github.com/ngicks/go-codegen/pkg/cloner/runtime/clonetest"
+)
+
+func FuzzClone_User(f *testing.F) {
+	clonetest.Fuzz(f, func(v User) User { return v.Clone() })
+}
+
+func BenchmarkClone_User(b *testing.B) {
+	clonetest.Benchmark(b, func(v User) User { return v.Clone() })
+}
+
+func FuzzClone_ID(f *testing.F) {
+	clonetest.Fuzz(f, func(v ID) ID { return v.Clone() })
+}
+
+func BenchmarkClone_ID(b *testing.B) {
+	clonetest.Benchmark(b, func(v ID) ID { return v.Clone() })
+}
+
+func FuzzClone_Group(f *testing.F) {
+	clonetest.Fuzz(f, func(v Group) Group { return v.Clone() })
+}
+
+func BenchmarkClone_Group(b *testing.B) {
+	clonetest.Benchmark(b, func(v Group) Group { return v.Clone() })
+}
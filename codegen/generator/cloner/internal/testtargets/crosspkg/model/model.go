@@ -0,0 +1,58 @@
+// Package model has types with unexported fields.
+// They are cloned completely only by Clone methods generated in this package.
+package model
+
+type User struct {
+	name string
+	tags []string
+}
+
+func NewUser(name string, tags ...string) User {
+	return User{name: name, tags: tags}
+}
+
+func (u User) Name() string {
+	return u.name
+}
+
+func (u User) Tags() []string {
+	return u.tags
+}
+
+type ID struct {
+	value string
+}
+
+type Group struct {
+	id      ID
+	members []*User
+	Public  []int
+}
+
+func NewGroup(id string, members ...*User) Group {
+	return Group{id: ID{value: id}, members: members}
+}
+
+func (g Group) Members() []*User {
+	return g.members
+}
+
+type Box[T any] struct {
+	v T
+}
+
+func NewBox[T any](v T) Box[T] {
+	return Box[T]{v: v}
+}
+
+func (b Box[T]) Get() T {
+	return b.v
+}
+
+// Opaque is excluded from generation.
+//
+//codegen:ignore
+type Opaque struct {
+	secret []byte
+	Name   string
+}
@@ -0,0 +1,19 @@
+// Code generated by github.com/ngicks/go-codegen/codegen DO NOT EDIT.
+// to regenerate the code, refer to help by invoking
+// go run github.com/ngicks/go-codegen/codegen cloner --help
+
+package customcloner
+
+import (
+	"testing"
+
+	clonetest "github.com/ngicks/go-codegen/pkg/cloner/runtime/clonetest"
+)
+
+func FuzzClone_Custom(f *testing.F) {
+	clonetest.Fuzz(f, func(v Custom) Custom { return v.Clone() })
+}
+
+func BenchmarkClone_Custom(b *testing.B) {
+	clonetest.Benchmark(b, func(v Custom) Custom { return v.Clone() })
+}
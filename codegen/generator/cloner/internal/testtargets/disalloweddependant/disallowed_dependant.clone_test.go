@@ -0,0 +1,19 @@
+// Code generated by github.com/ngicks/go-codegen/codegen DO NOT EDIT.
+// to regenerate the code, refer to help by invoking
+// go run github.com/ngicks/go-codegen/codegen cloner --help
+
+package disalloweddependant
+
+import (
+	"testing"
+
+	clonetest "github.com/ngicks/go-codegen/pkg/cloner/runtime/clonetest"
+)
+
+func FuzzClone_B(f *testing.F) {
+	clonetest.Fuzz(f, func(v B) B { return v.Clone() })
+}
+
+func BenchmarkClone_B(b *testing.B) {
+	clonetest.Benchmark(b, func(v B) B { return v.Clone() })
+}
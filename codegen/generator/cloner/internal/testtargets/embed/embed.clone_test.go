@@ -0,0 +1,43 @@
+// Code generated by github.com/ngicks/go-codegen/codegen DO NOT EDIT.
+// to regenerate the code, refer to help by invoking
+// go run github.com/ngicks/go-codegen/codegen cloner --help
+
+package embed
+
+import (
+	"testing"
+
+	clonetest "github.com/ngicks/go-codegen/pkg/cloner/runtime/clonetest"
+)
+
+func FuzzClone_A(f *testing.F) {
+	clonetest.Fuzz(f, func(v A) A { return v.Clone() })
+}
+
+func BenchmarkClone_A(b *testing.B) {
+	clonetest.Benchmark(b, func(v A) A { return v.Clone() })
+}
+
+func FuzzClone_B(f *testing.F) {
+	clonetest.Fuzz(f, func(v B) B { return v.Clone() })
+}
+
+func BenchmarkClone_B(b *testing.B) {
+	clonetest.Benchmark(b, func(v B) B { return v.Clone() })
+}
+
+func FuzzClone_C(f *testing.F) {
+	clonetest.Fuzz(f, func(v C) C { return v.Clone() })
+}
+
+func BenchmarkClone_C(b *testing.B) {
+	clonetest.Benchmark(b, func(v C) C { return v.Clone() })
+}
+
+func FuzzClone_D(f *testing.F) {
+	clonetest.Fuzz(f, func(v D) D { return v.Clone() })
+}
+
+func BenchmarkClone_D(b *testing.B) {
+	clonetest.Benchmark(b, func(v D) D { return v.Clone() })
+}
@@ -0,0 +1,263 @@
+// Code generated by github.com/ngicks/go-codegen/codegen DO NOT EDIT.
+// to regenerate the code, refer to help by invoking
+// go run github.com/ngicks/go-codegen/codegen cloner --help
+
+package equal
+
+import (
+	"time"
+
+	"maps"
+	"slices"
+)
+
+//codegen:generated
+func (v A) Clone() A {
+	return A{
+		Str: v.Str,
+		Bytes: func(src []byte) []byte {
+			if src == nil {
+				return nil
+			}
+			dst := make([]byte, len(src), cap(src))
+			copy(dst, src)
+			return dst
+		}(v.Bytes),
+		M: maps.Clone(v.M),
+		Ptr: func(v *int) *int {
+			var out *int
+
+			inner := out
+			if v != nil {
+				v := *v
+				vv := v
+				inner = &vv
+			}
+			out = inner
+
+			return out
+		}(v.Ptr),
+		Time: func(t time.Time) time.Time {
+			return time.Date(
+				t.Year(),
+				t.Month(),
+				t.Day(),
+				t.Hour(),
+				t.Minute(),
+				t.Second(),
+				t.Nanosecond(),
+				t.Location(),
+			)
+		}(v.Time),
+		Route: func(v []*map[string][2]int) []*map[string][2]int {
+			var out []*map[string][2]int
+
+			if v != nil {
+				out = make([]*map[string][2]int, len(v), cap(v))
+			}
+
+			inner := out
+			for k, v := range v {
+				outer := &inner
+				var inner *map[string][2]int
+
+				if v != nil {
+					v := *v
+					outer := &inner
+					var inner map[string][2]int
+					if v != nil {
+						inner = make(map[string][2]int, len(v))
+					}
+					for k, v := range v {
+						inner[k] = v
+					}
+					(*outer) = &inner
+				}
+				(*outer)[k] = inner
+			}
+			out = inner
+
+			return out
+		}(v.Route),
+		Ignored: v.Ignored,
+		Shared: func(v *int) *int {
+			var out *int
+
+			inner := out
+			if v != nil {
+				v := *v
+				vv := v
+				inner = &vv
+			}
+			out = inner
+
+			return out
+		}(v.Shared),
+		NilEmpty: func(src []string) []string {
+			if src == nil {
+				return nil
+			}
+			dst := make([]string, len(src), cap(src))
+			copy(dst, src)
+			return dst
+		}(v.NilEmpty),
+		B: v.B.Clone(),
+		Bs: func(v map[string]*B) map[string]*B {
+			var out map[string]*B
+
+			if v != nil {
+				out = make(map[string]*B, len(v))
+			}
+
+			inner := out
+			for k, v := range v {
+				outer := &inner
+				var inner *B
+
+				if v != nil {
+					v := *v
+					vv := v.Clone()
+					inner = &vv
+				}
+				(*outer)[k] = inner
+			}
+			out = inner
+
+			return out
+		}(v.Bs),
+	}
+}
+
+//codegen:generated
+func (v A) Equal(other A) bool {
+	return v.Str == other.Str &&
+		(v.Bytes == nil) == (other.Bytes == nil) && slices.Equal(v.Bytes, other.Bytes) &&
+		(v.M == nil) == (other.M == nil) && maps.Equal(v.M, other.M) &&
+		func(x, y *int) bool {
+			if x == nil || y == nil {
+				return x == y
+			}
+			return (*x) == (*y)
+		}(v.Ptr, other.Ptr) &&
+		v.Time.Equal(other.Time) &&
+		func(x, y []*map[string][2]int) bool {
+			if len(x) != len(y) || (x == nil) != (y == nil) {
+				return false
+			}
+			for i := range x {
+				if !(func(x, y *map[string][2]int) bool {
+					if x == nil || y == nil {
+						return x == y
+					}
+					return func(x, y map[string][2]int) bool {
+						if len(x) != len(y) || (x == nil) != (y == nil) {
+							return false
+						}
+						for k, vx := range x {
+							vy, ok := y[k]
+							if !ok || !(vx == vy) {
+								return false
+							}
+						}
+						return true
+					}((*x), (*y))
+				}(x[i], y[i])) {
+					return false
+				}
+			}
+			return true
+		}(v.Route, other.Route) &&
+		v.Shared == other.Shared &&
+		slices.Equal(v.NilEmpty, other.NilEmpty) &&
+		v.B.Equal(other.B) &&
+		func(x, y map[string]*B) bool {
+			if len(x) != len(y) || (x == nil) != (y == nil) {
+				return false
+			}
+			for k, vx := range x {
+				vy, ok := y[k]
+				if !ok || !(func(x, y *B) bool {
+					if x == nil || y == nil {
+						return x == y
+					}
+					return (*x).Equal((*y))
+				}(vx, vy)) {
+					return false
+				}
+			}
+			return true
+		}(v.Bs, other.Bs)
+}
+
+//codegen:generated
+func (v B) Clone() B {
+	return B{
+		Name: v.Name,
+	}
+}
+
+//codegen:generated
+func (v B) Equal(other B) bool {
+	return v.Name == other.Name
+}
+
+//codegen:generated
+func (v Param[T]) CloneFunc(cloneT func(T) T) Param[T] {
+	return Param[T]{
+		T: cloneT(v.T),
+		Ts: func(v []T) []T {
+			var out []T
+
+			if v != nil {
+				out = make([]T, len(v), cap(v))
+			}
+
+			inner := out
+			for k, v := range v {
+				inner[k] = cloneT(v)
+			}
+			out = inner
+
+			return out
+		}(v.Ts),
+	}
+}
+
+//codegen:generated
+func (v Param[T]) EqualFunc(other Param[T], eqT func(T, T) bool) bool {
+	return eqT(v.T, other.T) &&
+		func(x, y []T) bool {
+			if len(x) != len(y) || (x == nil) != (y == nil) {
+				return false
+			}
+			for i := range x {
+				if !(eqT(x[i], y[i])) {
+					return false
+				}
+			}
+			return true
+		}(v.Ts, other.Ts)
+}
+
+//codegen:generated
+func (v Nested) Clone() Nested {
+	return Nested{
+		P: v.P.CloneFunc(
+			func(src []int) []int {
+				if src == nil {
+					return nil
+				}
+				dst := make([]int, len(src), cap(src))
+				copy(dst, src)
+				return dst
+			},
+		),
+	}
+}
+
+//codegen:generated
+func (v Nested) Equal(other Nested) bool {
+	return v.P.EqualFunc(other.P, func(x, y []int) bool {
+		return (x == nil) == (y == nil) && slices.Equal(x, y)
+	})
+}
@@ -0,0 +1,33 @@
+package equal
+
+import "time"
+
+type A struct {
+	Str   string
+	Bytes []byte
+	M     map[string]int
+	Ptr   *int
+	Time  time.Time
+	Route []*map[string][2]int
+	//cloner:eqignore
+	Ignored int
+	//cloner:eqptr
+	Shared *int
+	//cloner:eqnilempty
+	NilEmpty []string
+	B        B
+	Bs       map[string]*B
+}
+
+type B struct {
+	Name string
+}
+
+type Param[T any] struct {
+	T  T
+	Ts []T
+}
+
+type Nested struct {
+	P Param[[]int]
+}
@@ -0,0 +1,27 @@
+// Code generated by github.com/ngicks/go-codegen/codegen DO NOT EDIT.
+// to regenerate the code, refer to help by invoking
+// go run github.com/ngicks/go-codegen/codegen cloner --help
+
+package errors
+
+import (
+	"testing"
+
+	clonetest "github.com/ngicks/go-codegen/pkg/cloner/runtime/clonetest"
+)
+
+func FuzzClone_includesError(f *testing.F) {
+	clonetest.Fuzz(f, func(v includesError) includesError { return v.Clone() })
+}
+
+func BenchmarkClone_includesError(b *testing.B) {
+	clonetest.Benchmark(b, func(v includesError) includesError { return v.Clone() })
+}
+
+func FuzzClone_errs(f *testing.F) {
+	clonetest.Fuzz(f, func(v errs) errs { return v.Clone() })
+}
+
+func BenchmarkClone_errs(b *testing.B) {
+	clonetest.Benchmark(b, func(v errs) errs { return v.Clone() })
+}
@@ -0,0 +1,74 @@
+// Code generated by github.com/ngicks/go-codegen/codegen DO NOT EDIT.
+// to regenerate the code, refer to help by invoking
+// go run github.com/ngicks/go-codegen/codegen cloner --help
+
+package handlerrule
+
+import (
+	"slices"
+
+	"reflect"
+
+	"github.com/ngicks/go-codegen/codegen/generator/cloner/internal/testtargets/handlerrule/money"
+)
+
+//codegen:generated
+func (v A) Clone() A {
+	return A{
+		D: v.D,
+		N: money.CloneNumeric(v.N),
+		Ns: func(v []*money.Numeric) []*money.Numeric {
+			var out []*money.Numeric
+
+			if v != nil {
+				out = make([]*money.Numeric, len(v), cap(v))
+			}
+
+			inner := out
+			for k, v := range v {
+				inner[k] = money.CloneNumeric(v)
+			}
+			out = inner
+
+			return out
+		}(v.Ns),
+		L: CloneLocal(v.L),
+	}
+}
+
+//codegen:generated
+func (v A) Equal(other A) bool {
+	return v.D == other.D &&
+		money.EqualNumeric(v.N, other.N) &&
+		func(x, y []*money.Numeric) bool {
+			if len(x) != len(y) || (x == nil) != (y == nil) {
+				return false
+			}
+			for i := range x {
+				if !(money.EqualNumeric(x[i], y[i])) {
+					return false
+				}
+			}
+			return true
+		}(v.Ns, other.Ns) &&
+		reflect.DeepEqual(v.L, other.L)
+}
+
+//codegen:generated
+func (v Local) Clone() Local {
+	return Local{
+		V: func(src []int) []int {
+			if src == nil {
+				return nil
+			}
+			dst := make([]int, len(src), cap(src))
+			copy(dst, src)
+			return dst
+		}(v.V),
+	}
+}
+
+//codegen:generated
+func (v Local) Equal(other Local) bool {
+	return (v.V == nil) == (other.V == nil) && slices.Equal(v.V, other.V)
+}
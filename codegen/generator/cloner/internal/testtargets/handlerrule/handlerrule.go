@@ -0,0 +1,22 @@
+package handlerrule
+
+import (
+	"slices"
+
+	"github.com/ngicks/go-codegen/codegen/generator/cloner/internal/testtargets/handlerrule/money"
+)
+
+type A struct {
+	D  money.Decimal
+	N  *money.Numeric
+	Ns []*money.Numeric
+	L  Local
+}
+
+type Local struct {
+	V []int
+}
+
+func CloneLocal(l Local) Local {
+	return Local{V: slices.Clone(l.V)}
+}
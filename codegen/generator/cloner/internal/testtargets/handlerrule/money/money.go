@@ -0,0 +1,53 @@
+// Package money mimics third party packages, e.g. github.com/shopspring/decimal,
+// whose types are cloned by custom handler rules.
+package money
+
+import "math/big"
+
+// Decimal is immutable, thus safe to clone by assignment.
+//
+//codegen:ignore
+type Decimal struct {
+	value *big.Int
+	exp   int32
+}
+
+func New(value int64, exp int32) Decimal {
+	return Decimal{value: big.NewInt(value), exp: exp}
+}
+
+func (d Decimal) String() string {
+	return new(big.Float).SetMantExp(new(big.Float).SetInt(d.value), 0).String() + "e" + big.NewInt(int64(d.exp)).String()
+}
+
+//codegen:ignore
+type Numeric struct {
+	Int   *big.Int
+	Exp   int32
+	Valid bool
+}
+
+func CloneNumeric(n *Numeric) *Numeric {
+	if n == nil {
+		return nil
+	}
+	cloned := &Numeric{Exp: n.Exp, Valid: n.Valid}
+	if n.Int != nil {
+		cloned.Int = new(big.Int).Set(n.Int)
+	}
+	return cloned
+}
+
+func EqualNumeric(x, y *Numeric) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.Int == nil || y.Int == nil {
+		if x.Int != y.Int {
+			return false
+		}
+	} else if x.Int.Cmp(y.Int) != 0 {
+		return false
+	}
+	return x.Exp == y.Exp && x.Valid == y.Valid
+}
@@ -0,0 +1,215 @@
+// Code generated by github.com/ngicks/go-codegen/codegen DO NOT EDIT.
+// to regenerate the code, refer to help by invoking
+// go run github.com/ngicks/go-codegen/codegen cloner --help
+
+package ifaceclone
+
+import (
+	"github.com/ngicks/go-codegen/codegen/generator/cloner/internal/testtargets/ifaceclone/shape"
+
+	cloneruntime "github.com/ngicks/go-codegen/pkg/cloner/runtime"
+)
+
+//codegen:generated
+func (v Canvas) Clone() Canvas {
+	return Canvas{
+		Main: func(v shape.Shape) shape.Shape {
+			switch x := v.(type) {
+			case Group:
+				return x.Clone()
+			case *Group:
+				if x == nil {
+					return x
+				}
+				c := x.Clone()
+				return &c
+			case *shape.Label:
+				if x == nil {
+					return x
+				}
+				c := x.Clone()
+				return &c
+			case shape.Polygon:
+				return x.Clone()
+			case *shape.Polygon:
+				if x == nil {
+					return x
+				}
+				c := x.Clone()
+				return &c
+			}
+			if cloned, ok := cloneruntime.Clone(v); ok {
+				return cloned.(shape.Shape)
+			}
+			return v
+		}(v.Main),
+		Layers: func(v []shape.Shape) []shape.Shape {
+			var out []shape.Shape
+
+			if v != nil {
+				out = make([]shape.Shape, len(v), cap(v))
+			}
+
+			inner := out
+			for k, v := range v {
+				inner[k] = func(v shape.Shape) shape.Shape {
+					switch x := v.(type) {
+					case Group:
+						return x.Clone()
+					case *Group:
+						if x == nil {
+							return x
+						}
+						c := x.Clone()
+						return &c
+					case *shape.Label:
+						if x == nil {
+							return x
+						}
+						c := x.Clone()
+						return &c
+					case shape.Polygon:
+						return x.Clone()
+					case *shape.Polygon:
+						if x == nil {
+							return x
+						}
+						c := x.Clone()
+						return &c
+					}
+					if cloned, ok := cloneruntime.Clone(v); ok {
+						return cloned.(shape.Shape)
+					}
+					return v
+				}(v)
+			}
+			out = inner
+
+			return out
+		}(v.Layers),
+		ByName: func(v map[string]shape.Shape) map[string]shape.Shape {
+			var out map[string]shape.Shape
+
+			if v != nil {
+				out = make(map[string]shape.Shape, len(v))
+			}
+
+			inner := out
+			for k, v := range v {
+				inner[k] = func(v shape.Shape) shape.Shape {
+					switch x := v.(type) {
+					case Group:
+						return x.Clone()
+					case *Group:
+						if x == nil {
+							return x
+						}
+						c := x.Clone()
+						return &c
+					case *shape.Label:
+						if x == nil {
+							return x
+						}
+						c := x.Clone()
+						return &c
+					case shape.Polygon:
+						return x.Clone()
+					case *shape.Polygon:
+						if x == nil {
+							return x
+						}
+						c := x.Clone()
+						return &c
+					}
+					if cloned, ok := cloneruntime.Clone(v); ok {
+						return cloned.(shape.Shape)
+					}
+					return v
+				}(v)
+			}
+			out = inner
+
+			return out
+		}(v.ByName),
+		Named: func(v Named) Named {
+			switch x := v.(type) {
+			case Tag:
+				return x.Clone()
+			case *Tag:
+				if x == nil {
+					return x
+				}
+				c := x.Clone()
+				return &c
+			}
+			if cloned, ok := cloneruntime.Clone(v); ok {
+				return cloned.(Named)
+			}
+			return v
+		}(v.Named),
+	}
+}
+
+//codegen:generated
+func (v Group) Clone() Group {
+	return Group{
+		Members: func(v []shape.Shape) []shape.Shape {
+			var out []shape.Shape
+
+			if v != nil {
+				out = make([]shape.Shape, len(v), cap(v))
+			}
+
+			inner := out
+			for k, v := range v {
+				inner[k] = func(v shape.Shape) shape.Shape {
+					switch x := v.(type) {
+					case Group:
+						return x.Clone()
+					case *Group:
+						if x == nil {
+							return x
+						}
+						c := x.Clone()
+						return &c
+					case *shape.Label:
+						if x == nil {
+							return x
+						}
+						c := x.Clone()
+						return &c
+					case shape.Polygon:
+						return x.Clone()
+					case *shape.Polygon:
+						if x == nil {
+							return x
+						}
+						c := x.Clone()
+						return &c
+					}
+					if cloned, ok := cloneruntime.Clone(v); ok {
+						return cloned.(shape.Shape)
+					}
+					return v
+				}(v)
+			}
+			out = inner
+
+			return out
+		}(v.Members),
+	}
+}
+
+//codegen:generated
+func (v Tag) Clone() Tag {
+	return Tag{
+		Values: func(src []string) []string {
+			if src == nil {
+				return nil
+			}
+			dst := make([]string, len(src), cap(src))
+			copy(dst, src)
+			return dst
+		}(v.Values),
+	}
+}
@@ -0,0 +1,35 @@
+// Code generated by github.com/ngicks/go-codegen/codegen DO NOT EDIT.
+// to regenerate the code, refer to help by invoking
+// go run github.com/ngicks/go-codegen/codegen cloner --help
+
+package ifaceclone
+
+import (
+	"testing"
+
+	clonetest "github.com/ngicks/go-codegen/pkg/cloner/runtime/clonetest"
+)
+
+func FuzzClone_Canvas(f *testing.F) {
+	clonetest.Fuzz(f, func(v Canvas) Canvas { return v.Clone() })
+}
+
+func BenchmarkClone_Canvas(b *testing.B) {
+	clonetest.Benchmark(b, func(v Canvas) Canvas { return v.Clone() })
+}
+
+func FuzzClone_Group(f *testing.F) {
+	clonetest.Fuzz(f, func(v Group) Group { return v.Clone() })
+}
+
+func BenchmarkClone_Group(b *testing.B) {
+	clonetest.Benchmark(b, func(v Group) Group { return v.Clone() })
+}
+
+func FuzzClone_Tag(f *testing.F) {
+	clonetest.Fuzz(f, func(v Tag) Tag { return v.Clone() })
+}
+
+func BenchmarkClone_Tag(b *testing.B) {
+	clonetest.Benchmark(b, func(v Tag) Tag { return v.Clone() })
+}
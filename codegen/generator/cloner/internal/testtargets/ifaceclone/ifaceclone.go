@@ -0,0 +1,40 @@
+package ifaceclone
+
+import "github.com/ngicks/go-codegen/codegen/generator/cloner/internal/testtargets/ifaceclone/shape"
+
+type Canvas struct {
+	Main   shape.Shape
+	Layers []shape.Shape
+	ByName map[string]shape.Shape
+	Named  Named
+}
+
+// Group is a Shape defined in the package which has the interface field.
+type Group struct {
+	Members []shape.Shape
+}
+
+func (g Group) Area() float64 {
+	var sum float64
+	for _, m := range g.Members {
+		if m != nil {
+			sum += m.Area()
+		}
+	}
+	return sum
+}
+
+type Named interface {
+	Name() string
+}
+
+type Tag struct {
+	Values []string
+}
+
+func (t Tag) Name() string {
+	if len(t.Values) == 0 {
+		return ""
+	}
+	return t.Values[0]
+}
@@ -0,0 +1,34 @@
+// Code generated by github.com/ngicks/go-codegen/codegen DO NOT EDIT.
+// to regenerate the code, refer to help by invoking
+// go run github.com/ngicks/go-codegen/codegen cloner --help
+
+package shape
+
+//codegen:generated
+func (v Point) Clone() Point {
+	return Point{
+		X: v.X,
+		Y: v.Y,
+	}
+}
+
+//codegen:generated
+func (v Polygon) Clone() Polygon {
+	return Polygon{
+		Points: func(v []Point) []Point {
+			var out []Point
+
+			if v != nil {
+				out = make([]Point, len(v), cap(v))
+			}
+
+			inner := out
+			for k, v := range v {
+				inner[k] = v.Clone()
+			}
+			out = inner
+
+			return out
+		}(v.Points),
+	}
+}
@@ -0,0 +1,27 @@
+// Code generated by github.com/ngicks/go-codegen/codegen DO NOT EDIT.
+// to regenerate the code, refer to help by invoking
+// go run github.com/ngicks/go-codegen/codegen cloner --help
+
+package shape
+
+import (
+	"testing"
+
+	clonetest "github.com/ngicks/go-codegen/pkg/cloner/runtime/clonetest"
+)
+
+func FuzzClone_Point(f *testing.F) {
+	clonetest.Fuzz(f, func(v Point) Point { return v.Clone() })
+}
+
+func BenchmarkClone_Point(b *testing.B) {
+	clonetest.Benchmark(b, func(v Point) Point { return v.Clone() })
+}
+
+func FuzzClone_Polygon(f *testing.F) {
+	clonetest.Fuzz(f, func(v Polygon) Polygon { return v.Clone() })
+}
+
+func BenchmarkClone_Polygon(b *testing.B) {
+	clonetest.Benchmark(b, func(v Polygon) Polygon { return v.Clone() })
+}
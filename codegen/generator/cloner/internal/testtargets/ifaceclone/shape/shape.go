@@ -0,0 +1,42 @@
+package shape
+
+type Shape interface {
+	Area() float64
+}
+
+type Point struct {
+	X, Y float64
+}
+
+type Polygon struct {
+	Points []Point
+}
+
+func (p Polygon) Area() float64 {
+	var sum float64
+	for i := range p.Points {
+		j := (i + 1) % len(p.Points)
+		sum += p.Points[i].X*p.Points[j].Y - p.Points[j].X*p.Points[i].Y
+	}
+	if sum < 0 {
+		sum = -sum
+	}
+	return sum / 2
+}
+
+// Label implements Shape only as a pointer.
+// Its Clone method is hand-written.
+//
+//codegen:ignore
+type Label struct {
+	Text string
+	Tags []string
+}
+
+func (l *Label) Area() float64 {
+	return 0
+}
+
+func (l *Label) Clone() Label {
+	return Label{Text: l.Text, Tags: append([]string(nil), l.Tags...)}
+}
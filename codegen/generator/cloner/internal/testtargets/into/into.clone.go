@@ -0,0 +1,376 @@
+// Code generated by github.com/ngicks/go-codegen/codegen DO NOT EDIT.
+// to regenerate the code, refer to help by invoking
+// go run github.com/ngicks/go-codegen/codegen cloner --help
+
+package into
+
+import (
+	"sync"
+
+	"maps"
+)
+
+//codegen:generated
+func (v Frame) Clone() Frame {
+	return Frame{
+		Seq: v.Seq,
+		Samples: func(src []float64) []float64 {
+			if src == nil {
+				return nil
+			}
+			dst := make([]float64, len(src), cap(src))
+			copy(dst, src)
+			return dst
+		}(v.Samples),
+		Tags: maps.Clone(v.Tags),
+		Meta: func(v *Meta) *Meta {
+			var out *Meta
+
+			inner := out
+			if v != nil {
+				v := *v
+				vv := v.Clone()
+				inner = &vv
+			}
+			out = inner
+
+			return out
+		}(v.Meta),
+		Entities: func(v []Entity) []Entity {
+			var out []Entity
+
+			if v != nil {
+				out = make([]Entity, len(v), cap(v))
+			}
+
+			inner := out
+			for k, v := range v {
+				inner[k] = v.Clone()
+			}
+			out = inner
+
+			return out
+		}(v.Entities),
+		Index: func(v map[string]*Entity) map[string]*Entity {
+			var out map[string]*Entity
+
+			if v != nil {
+				out = make(map[string]*Entity, len(v))
+			}
+
+			inner := out
+			for k, v := range v {
+				outer := &inner
+				var inner *Entity
+
+				if v != nil {
+					v := *v
+					vv := v.Clone()
+					inner = &vv
+				}
+				(*outer)[k] = inner
+			}
+			out = inner
+
+			return out
+		}(v.Index),
+		Grid: func(v [2][]int) [2][]int {
+			var out [2][]int
+
+			inner := out
+			for k, v := range v {
+				inner[k] = func(src []int) []int {
+					if src == nil {
+						return nil
+					}
+					dst := make([]int, len(src), cap(src))
+					copy(dst, src)
+					return dst
+				}(v)
+			}
+			out = inner
+
+			return out
+		}(v.Grid),
+		Buf: v.Buf.CloneFunc(
+			func(v string) string {
+				return v
+			},
+		),
+	}
+}
+
+//codegen:generated
+func (v Frame) CloneInto(dst *Frame) {
+	dst.Seq = v.Seq
+	if v.Samples == nil {
+		dst.Samples = nil
+	} else {
+		if dst.Samples == nil || cap(dst.Samples) < len(v.Samples) {
+			dst.Samples = make([]float64, len(v.Samples), cap(v.Samples))
+		} else {
+			dst.Samples = dst.Samples[:len(v.Samples)]
+		}
+		copy(dst.Samples, v.Samples)
+	}
+	if v.Tags == nil {
+		dst.Tags = nil
+	} else {
+		if dst.Tags == nil {
+			dst.Tags = make(map[string]string, len(v.Tags))
+		} else {
+			clear(dst.Tags)
+		}
+		maps.Copy(dst.Tags, v.Tags)
+	}
+	if v.Meta == nil {
+		dst.Meta = nil
+	} else {
+		if dst.Meta == nil {
+			dst.Meta = new(Meta)
+		}
+		(*v.Meta).CloneInto(dst.Meta)
+	}
+	if v.Entities == nil {
+		dst.Entities = nil
+	} else {
+		if dst.Entities == nil || cap(dst.Entities) < len(v.Entities) {
+			dst.Entities = make([]Entity, len(v.Entities), cap(v.Entities))
+		} else {
+			dst.Entities = dst.Entities[:len(v.Entities)]
+		}
+		for i0 := range v.Entities {
+			v.Entities[i0].CloneInto(&dst.Entities[i0])
+		}
+	}
+	if v.Index == nil {
+		dst.Index = nil
+	} else {
+		if dst.Index == nil {
+			dst.Index = make(map[string]*Entity, len(v.Index))
+		}
+		for k0 := range dst.Index {
+			if _, ok := v.Index[k0]; !ok {
+				delete(dst.Index, k0)
+			}
+		}
+		for k0, v0 := range v.Index {
+			e0 := dst.Index[k0]
+			if v0 == nil {
+				e0 = nil
+			} else {
+				if e0 == nil {
+					e0 = new(Entity)
+				}
+				(*v0).CloneInto(e0)
+			}
+			dst.Index[k0] = e0
+		}
+	}
+	for i0 := range v.Grid {
+		if v.Grid[i0] == nil {
+			dst.Grid[i0] = nil
+		} else {
+			if dst.Grid[i0] == nil || cap(dst.Grid[i0]) < len(v.Grid[i0]) {
+				dst.Grid[i0] = make([]int, len(v.Grid[i0]), cap(v.Grid[i0]))
+			} else {
+				dst.Grid[i0] = dst.Grid[i0][:len(v.Grid[i0])]
+			}
+			copy(dst.Grid[i0], v.Grid[i0])
+		}
+	}
+	v.Buf.CloneFuncInto(
+		&dst.Buf,
+		func(v string) string {
+			return v
+		},
+	)
+	dst.Done = nil
+}
+
+//codegen:generated
+func (v Meta) Clone() Meta {
+	return Meta{
+		Name: v.Name,
+		Labels: func(src []string) []string {
+			if src == nil {
+				return nil
+			}
+			dst := make([]string, len(src), cap(src))
+			copy(dst, src)
+			return dst
+		}(v.Labels),
+	}
+}
+
+//codegen:generated
+func (v Meta) CloneInto(dst *Meta) {
+	dst.Name = v.Name
+	if v.Labels == nil {
+		dst.Labels = nil
+	} else {
+		if dst.Labels == nil || cap(dst.Labels) < len(v.Labels) {
+			dst.Labels = make([]string, len(v.Labels), cap(v.Labels))
+		} else {
+			dst.Labels = dst.Labels[:len(v.Labels)]
+		}
+		copy(dst.Labels, v.Labels)
+	}
+}
+
+//codegen:generated
+func (v Entity) Clone() Entity {
+	return Entity{
+		ID: v.ID,
+		Pos: func(v *[2]float64) *[2]float64 {
+			var out *[2]float64
+
+			inner := out
+			if v != nil {
+				v := *v
+				vv := v
+				inner = &vv
+			}
+			out = inner
+
+			return out
+		}(v.Pos),
+		Children: func(src []int) []int {
+			if src == nil {
+				return nil
+			}
+			dst := make([]int, len(src), cap(src))
+			copy(dst, src)
+			return dst
+		}(v.Children),
+	}
+}
+
+//codegen:generated
+func (v Entity) CloneInto(dst *Entity) {
+	dst.ID = v.ID
+	if v.Pos == nil {
+		dst.Pos = nil
+	} else {
+		if dst.Pos == nil {
+			dst.Pos = new([2]float64)
+		}
+		(*dst.Pos) = (*v.Pos)
+	}
+	if v.Children == nil {
+		dst.Children = nil
+	} else {
+		if dst.Children == nil || cap(dst.Children) < len(v.Children) {
+			dst.Children = make([]int, len(v.Children), cap(v.Children))
+		} else {
+			dst.Children = dst.Children[:len(v.Children)]
+		}
+		copy(dst.Children, v.Children)
+	}
+}
+
+//codegen:generated
+func (v Buffer[T]) CloneFunc(cloneT func(T) T) Buffer[T] {
+	return Buffer[T]{
+		Items: func(v []T) []T {
+			var out []T
+
+			if v != nil {
+				out = make([]T, len(v), cap(v))
+			}
+
+			inner := out
+			for k, v := range v {
+				inner[k] = cloneT(v)
+			}
+			out = inner
+
+			return out
+		}(v.Items),
+		Last: func(v *T) *T {
+			var out *T
+
+			inner := out
+			if v != nil {
+				v := *v
+				vv := cloneT(v)
+				inner = &vv
+			}
+			out = inner
+
+			return out
+		}(v.Last),
+	}
+}
+
+//codegen:generated
+func (v Buffer[T]) CloneFuncInto(dst *Buffer[T], cloneT func(T) T) {
+	if v.Items == nil {
+		dst.Items = nil
+	} else {
+		if dst.Items == nil || cap(dst.Items) < len(v.Items) {
+			dst.Items = make([]T, len(v.Items), cap(v.Items))
+		} else {
+			dst.Items = dst.Items[:len(v.Items)]
+		}
+		for i0 := range v.Items {
+			dst.Items[i0] = cloneT(v.Items[i0])
+		}
+	}
+	if v.Last == nil {
+		dst.Last = nil
+	} else {
+		if dst.Last == nil {
+			dst.Last = new(T)
+		}
+		(*dst.Last) = cloneT((*v.Last))
+	}
+}
+
+//codegen:generated
+func (v Entities) Clone() Entities {
+	return func(v []Entity) []Entity {
+		var out []Entity
+
+		if v != nil {
+			out = make([]Entity, len(v), cap(v))
+		}
+
+		inner := out
+		for k, v := range v {
+			inner[k] = v.Clone()
+		}
+		out = inner
+
+		return out
+	}(v)
+}
+
+//codegen:generated
+func (v Entities) CloneInto(dst *Entities) {
+	if v == nil {
+		(*dst) = nil
+	} else {
+		if (*dst) == nil || cap((*dst)) < len(v) {
+			(*dst) = make([]Entity, len(v), cap(v))
+		} else {
+			(*dst) = (*dst)[:len(v)]
+		}
+		for i0 := range v {
+			v[i0].CloneInto(&(*dst)[i0])
+		}
+	}
+}
+
+//codegen:generated
+func (v *Counter) Clone() Counter {
+	return Counter{
+		N: v.N,
+	}
+}
+
+//codegen:generated
+func (v *Counter) CloneInto(dst *Counter) {
+	dst.Mu = sync.Mutex{}
+	dst.N = v.N
+}
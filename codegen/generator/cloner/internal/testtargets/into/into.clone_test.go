@@ -0,0 +1,47 @@
+// Code generated by github.com/ngicks/go-codegen/codegen DO NOT EDIT.
+// to regenerate the code, refer to help by invoking
+// go run github.com/ngicks/go-codegen/codegen cloner --help
+
+package into
+
+import (
+	"testing"
+
+	clonetest "github.com/ngicks/go-codegen/pkg/cloner/runtime/clonetest"
+)
+
+func init() {
+	clonetest.Skip[Frame]("Done")
+}
+
+func FuzzClone_Frame(f *testing.F) {
+	clonetest.Fuzz(f, func(v Frame) Frame { return v.Clone() })
+}
+
+func BenchmarkClone_Frame(b *testing.B) {
+	clonetest.Benchmark(b, func(v Frame) Frame { return v.Clone() })
+}
+
+func FuzzClone_Meta(f *testing.F) {
+	clonetest.Fuzz(f, func(v Meta) Meta { return v.Clone() })
+}
+
+func BenchmarkClone_Meta(b *testing.B) {
+	clonetest.Benchmark(b, func(v Meta) Meta { return v.Clone() })
+}
+
+func FuzzClone_Entity(f *testing.F) {
+	clonetest.Fuzz(f, func(v Entity) Entity { return v.Clone() })
+}
+
+func BenchmarkClone_Entity(b *testing.B) {
+	clonetest.Benchmark(b, func(v Entity) Entity { return v.Clone() })
+}
+
+func FuzzClone_Entities(f *testing.F) {
+	clonetest.Fuzz(f, func(v Entities) Entities { return v.Clone() })
+}
+
+func BenchmarkClone_Entities(b *testing.B) {
+	clonetest.Benchmark(b, func(v Entities) Entities { return v.Clone() })
+}
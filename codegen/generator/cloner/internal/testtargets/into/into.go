@@ -0,0 +1,40 @@
+package into
+
+import "sync"
+
+type Frame struct {
+	Seq      int
+	Samples  []float64
+	Tags     map[string]string
+	Meta     *Meta
+	Entities []Entity
+	Index    map[string]*Entity
+	Grid     [2][]int
+	Buf      Buffer[string]
+	//cloner:ignore
+	Done chan struct{}
+}
+
+type Meta struct {
+	Name   string
+	Labels []string
+}
+
+type Entity struct {
+	ID       int
+	Pos      *[2]float64
+	Children []int
+}
+
+type Buffer[T any] struct {
+	Items []T
+	Last  *T
+}
+
+type Entities []Entity
+
+//cloner:ptr
+type Counter struct {
+	Mu sync.Mutex
+	N  int
+}
@@ -15,16 +15,25 @@ import (
 func (v Big) Clone() Big {
 	return Big{
 		Int: func(v *big.Int) *big.Int {
+			if v == nil {
+				return nil
+			}
 			new := big.NewInt(0)
 			new.Set(v)
 			return new
 		}(v.Int),
 		Float: func(v *big.Float) *big.Float {
+			if v == nil {
+				return nil
+			}
 			new := big.NewFloat(0)
 			new.Set(v)
 			return new
 		}(v.Float),
 		Rat: func(v *big.Rat) *big.Rat {
+			if v == nil {
+				return nil
+			}
 			new := big.NewRat(0, 1)
 			new.Set(v)
 			return new
@@ -158,6 +167,9 @@ func (v Pkix) Clone() Pkix {
 						inner[k] = func(v pkix.RevokedCertificate) pkix.RevokedCertificate {
 							return pkix.RevokedCertificate{
 								SerialNumber: func(v *big.Int) *big.Int {
+									if v == nil {
+										return nil
+									}
 									new := big.NewInt(0)
 									new.Set(v)
 									return new
@@ -0,0 +1,27 @@
+// Code generated by github.com/ngicks/go-codegen/codegen DO NOT EDIT.
+// to regenerate the code, refer to help by invoking
+// go run github.com/ngicks/go-codegen/codegen cloner --help
+
+package mathbig
+
+import (
+	"testing"
+
+	clonetest "github.com/ngicks/go-codegen/pkg/cloner/runtime/clonetest"
+)
+
+func FuzzClone_Big(f *testing.F) {
+	clonetest.Fuzz(f, func(v Big) Big { return v.Clone() })
+}
+
+func BenchmarkClone_Big(b *testing.B) {
+	clonetest.Benchmark(b, func(v Big) Big { return v.Clone() })
+}
+
+func FuzzClone_Pkix(f *testing.F) {
+	clonetest.Fuzz(f, func(v Pkix) Pkix { return v.Clone() })
+}
+
+func BenchmarkClone_Pkix(b *testing.B) {
+	clonetest.Benchmark(b, func(v Pkix) Pkix { return v.Clone() })
+}
@@ -0,0 +1,436 @@
+// Code generated by github.com/ngicks/go-codegen/codegen DO NOT EDIT.
+// to regenerate the code, refer to help by invoking
+// go run github.com/ngicks/go-codegen/codegen cloner --help
+
+package memo
+
+//codegen:generated
+func (v List) Clone() List {
+	return v.CloneWithMemo(make(map[any]any))
+}
+
+//codegen:generated
+func (v List) CloneWithMemo(memo map[any]any) List {
+	return List{
+		Head: func(v *Node) *Node {
+			var out *Node
+
+			inner := out
+			if v != nil {
+				if p, ok := memo[v].(*Node); ok {
+					inner = p
+				} else {
+					p := new(Node)
+					memo[v] = p
+					inner = p
+					v := *v
+					*p = v.CloneWithMemo(memo)
+				}
+			}
+			out = inner
+
+			return out
+		}(v.Head),
+		Tail: func(v *Node) *Node {
+			var out *Node
+
+			inner := out
+			if v != nil {
+				if p, ok := memo[v].(*Node); ok {
+					inner = p
+				} else {
+					p := new(Node)
+					memo[v] = p
+					inner = p
+					v := *v
+					*p = v.CloneWithMemo(memo)
+				}
+			}
+			out = inner
+
+			return out
+		}(v.Tail),
+	}
+}
+
+//codegen:generated
+func (v Node) Clone() Node {
+	return v.CloneWithMemo(make(map[any]any))
+}
+
+//codegen:generated
+func (v Node) CloneWithMemo(memo map[any]any) Node {
+	return Node{
+		Value: v.Value,
+		Prev: func(v *Node) *Node {
+			var out *Node
+
+			inner := out
+			if v != nil {
+				if p, ok := memo[v].(*Node); ok {
+					inner = p
+				} else {
+					p := new(Node)
+					memo[v] = p
+					inner = p
+					v := *v
+					*p = v.CloneWithMemo(memo)
+				}
+			}
+			out = inner
+
+			return out
+		}(v.Prev),
+		Next: func(v *Node) *Node {
+			var out *Node
+
+			inner := out
+			if v != nil {
+				if p, ok := memo[v].(*Node); ok {
+					inner = p
+				} else {
+					p := new(Node)
+					memo[v] = p
+					inner = p
+					v := *v
+					*p = v.CloneWithMemo(memo)
+				}
+			}
+			out = inner
+
+			return out
+		}(v.Next),
+	}
+}
+
+//codegen:generated
+func (v Shared) Clone() Shared {
+	return v.CloneWithMemo(make(map[any]any))
+}
+
+//codegen:generated
+func (v Shared) CloneWithMemo(memo map[any]any) Shared {
+	return Shared{
+		A: func(v *int) *int {
+			var out *int
+
+			inner := out
+			if v != nil {
+				if p, ok := memo[v].(*int); ok {
+					inner = p
+				} else {
+					p := new(int)
+					memo[v] = p
+					inner = p
+					v := *v
+					*p = v
+				}
+			}
+			out = inner
+
+			return out
+		}(v.A),
+		B: func(v *int) *int {
+			var out *int
+
+			inner := out
+			if v != nil {
+				if p, ok := memo[v].(*int); ok {
+					inner = p
+				} else {
+					p := new(int)
+					memo[v] = p
+					inner = p
+					v := *v
+					*p = v
+				}
+			}
+			out = inner
+
+			return out
+		}(v.B),
+		C: func(v []*int) []*int {
+			var out []*int
+
+			if v != nil {
+				out = make([]*int, len(v), cap(v))
+			}
+
+			inner := out
+			for k, v := range v {
+				outer := &inner
+				var inner *int
+
+				if v != nil {
+					if p, ok := memo[v].(*int); ok {
+						inner = p
+					} else {
+						p := new(int)
+						memo[v] = p
+						inner = p
+						v := *v
+						*p = v
+					}
+				}
+				(*outer)[k] = inner
+			}
+			out = inner
+
+			return out
+		}(v.C),
+		D: func(v map[string]**int) map[string]**int {
+			var out map[string]**int
+
+			if v != nil {
+				out = make(map[string]**int, len(v))
+			}
+
+			inner := out
+			for k, v := range v {
+				outer := &inner
+				var inner **int
+
+				if v != nil {
+					if p, ok := memo[v].(**int); ok {
+						inner = p
+					} else {
+						p := new(*int)
+						memo[v] = p
+						inner = p
+						v := *v
+						var inner *int
+
+						if v != nil {
+							if p, ok := memo[v].(*int); ok {
+								inner = p
+							} else {
+								p := new(int)
+								memo[v] = p
+								inner = p
+								v := *v
+								*p = v
+							}
+						}
+						*p = inner
+					}
+				}
+				(*outer)[k] = inner
+			}
+			out = inner
+
+			return out
+		}(v.D),
+	}
+}
+
+//codegen:generated
+func (v Tree[T]) CloneFunc(cloneT func(T) T) Tree[T] {
+	return v.CloneFuncWithMemo(make(map[any]any), cloneT)
+}
+
+//codegen:generated
+func (v Tree[T]) CloneFuncWithMemo(memo map[any]any, cloneT func(T) T) Tree[T] {
+	return Tree[T]{
+		Root: func(v *TreeNode[T]) *TreeNode[T] {
+			var out *TreeNode[T]
+
+			inner := out
+			if v != nil {
+				if p, ok := memo[v].(*TreeNode[T]); ok {
+					inner = p
+				} else {
+					p := new(TreeNode[T])
+					memo[v] = p
+					inner = p
+					v := *v
+					*p = v.CloneFuncWithMemo(
+						memo,
+						cloneT,
+					)
+				}
+			}
+			out = inner
+
+			return out
+		}(v.Root),
+		Nodes: func(v []*TreeNode[T]) []*TreeNode[T] {
+			var out []*TreeNode[T]
+
+			if v != nil {
+				out = make([]*TreeNode[T], len(v), cap(v))
+			}
+
+			inner := out
+			for k, v := range v {
+				outer := &inner
+				var inner *TreeNode[T]
+
+				if v != nil {
+					if p, ok := memo[v].(*TreeNode[T]); ok {
+						inner = p
+					} else {
+						p := new(TreeNode[T])
+						memo[v] = p
+						inner = p
+						v := *v
+						*p = v.CloneFuncWithMemo(
+							memo,
+							cloneT,
+						)
+					}
+				}
+				(*outer)[k] = inner
+			}
+			out = inner
+
+			return out
+		}(v.Nodes),
+	}
+}
+
+//codegen:generated
+func (v TreeNode[T]) CloneFunc(cloneT func(T) T) TreeNode[T] {
+	return v.CloneFuncWithMemo(make(map[any]any), cloneT)
+}
+
+//codegen:generated
+func (v TreeNode[T]) CloneFuncWithMemo(memo map[any]any, cloneT func(T) T) TreeNode[T] {
+	return TreeNode[T]{
+		Value: cloneT(v.Value),
+		Parent: func(v *TreeNode[T]) *TreeNode[T] {
+			var out *TreeNode[T]
+
+			inner := out
+			if v != nil {
+				if p, ok := memo[v].(*TreeNode[T]); ok {
+					inner = p
+				} else {
+					p := new(TreeNode[T])
+					memo[v] = p
+					inner = p
+					v := *v
+					*p = v.CloneFuncWithMemo(
+						memo,
+						cloneT,
+					)
+				}
+			}
+			out = inner
+
+			return out
+		}(v.Parent),
+		Children: func(v []*TreeNode[T]) []*TreeNode[T] {
+			var out []*TreeNode[T]
+
+			if v != nil {
+				out = make([]*TreeNode[T], len(v), cap(v))
+			}
+
+			inner := out
+			for k, v := range v {
+				outer := &inner
+				var inner *TreeNode[T]
+
+				if v != nil {
+					if p, ok := memo[v].(*TreeNode[T]); ok {
+						inner = p
+					} else {
+						p := new(TreeNode[T])
+						memo[v] = p
+						inner = p
+						v := *v
+						*p = v.CloneFuncWithMemo(
+							memo,
+							cloneT,
+						)
+					}
+				}
+				(*outer)[k] = inner
+			}
+			out = inner
+
+			return out
+		}(v.Children),
+	}
+}
+
+//codegen:generated
+func (v Pair) Clone() Pair {
+	return v.CloneWithMemo(make(map[any]any))
+}
+
+//codegen:generated
+func (v Pair) CloneWithMemo(memo map[any]any) Pair {
+	return Pair{
+		L: v.L,
+		R: v.R,
+	}
+}
+
+//codegen:generated
+func (v Interior) Clone() Interior {
+	return v.CloneWithMemo(make(map[any]any))
+}
+
+//codegen:generated
+func (v Interior) CloneWithMemo(memo map[any]any) Interior {
+	return Interior{
+		Whole: func(v *Pair) *Pair {
+			var out *Pair
+
+			inner := out
+			if v != nil {
+				if p, ok := memo[v].(*Pair); ok {
+					inner = p
+				} else {
+					p := new(Pair)
+					memo[v] = p
+					inner = p
+					v := *v
+					*p = v.CloneWithMemo(memo)
+				}
+			}
+			out = inner
+
+			return out
+		}(v.Whole),
+		First: func(v *int) *int {
+			var out *int
+
+			inner := out
+			if v != nil {
+				if p, ok := memo[v].(*int); ok {
+					inner = p
+				} else {
+					p := new(int)
+					memo[v] = p
+					inner = p
+					v := *v
+					*p = v
+				}
+			}
+			out = inner
+
+			return out
+		}(v.First),
+		Again: func(v *Pair) *Pair {
+			var out *Pair
+
+			inner := out
+			if v != nil {
+				if p, ok := memo[v].(*Pair); ok {
+					inner = p
+				} else {
+					p := new(Pair)
+					memo[v] = p
+					inner = p
+					v := *v
+					*p = v.CloneWithMemo(memo)
+				}
+			}
+			out = inner
+
+			return out
+		}(v.Again),
+	}
+}
@@ -0,0 +1,51 @@
+// Code generated by github.com/ngicks/go-codegen/codegen DO NOT EDIT.
+// to regenerate the code, refer to help by invoking
+// go run github.com/ngicks/go-codegen/codegen cloner --help
+
+package memo
+
+import (
+	"testing"
+
+	clonetest "github.com/ngicks/go-codegen/pkg/cloner/runtime/clonetest"
+)
+
+func FuzzClone_List(f *testing.F) {
+	clonetest.Fuzz(f, func(v List) List { return v.Clone() })
+}
+
+func BenchmarkClone_List(b *testing.B) {
+	clonetest.Benchmark(b, func(v List) List { return v.Clone() })
+}
+
+func FuzzClone_Node(f *testing.F) {
+	clonetest.Fuzz(f, func(v Node) Node { return v.Clone() })
+}
+
+func BenchmarkClone_Node(b *testing.B) {
+	clonetest.Benchmark(b, func(v Node) Node { return v.Clone() })
+}
+
+func FuzzClone_Shared(f *testing.F) {
+	clonetest.Fuzz(f, func(v Shared) Shared { return v.Clone() })
+}
+
+func BenchmarkClone_Shared(b *testing.B) {
+	clonetest.Benchmark(b, func(v Shared) Shared { return v.Clone() })
+}
+
+func FuzzClone_Pair(f *testing.F) {
+	clonetest.Fuzz(f, func(v Pair) Pair { return v.Clone() })
+}
+
+func BenchmarkClone_Pair(b *testing.B) {
+	clonetest.Benchmark(b, func(v Pair) Pair { return v.Clone() })
+}
+
+func FuzzClone_Interior(f *testing.F) {
+	clonetest.Fuzz(f, func(v Interior) Interior { return v.Clone() })
+}
+
+func BenchmarkClone_Interior(b *testing.B) {
+	clonetest.Benchmark(b, func(v Interior) Interior { return v.Clone() })
+}
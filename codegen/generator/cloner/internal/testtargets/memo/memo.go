@@ -0,0 +1,42 @@
+package memo
+
+type List struct {
+	Head *Node
+	Tail *Node
+}
+
+type Node struct {
+	Value int
+	Prev  *Node
+	Next  *Node
+}
+
+type Shared struct {
+	A *int
+	B *int
+	C []*int
+	D map[string]**int
+}
+
+type Tree[T any] struct {
+	Root  *TreeNode[T]
+	Nodes []*TreeNode[T]
+}
+
+type TreeNode[T any] struct {
+	Value    T
+	Parent   *TreeNode[T]
+	Children []*TreeNode[T]
+}
+
+type Pair struct {
+	L int
+	R int
+}
+
+// Interior has pointers sharing an address with different types, e.g. First pointing Pair.L.
+type Interior struct {
+	Whole *Pair
+	First *int
+	Again *Pair
+}
@@ -0,0 +1,50 @@
+// Code generated by github.com/ngicks/go-codegen/codegen DO NOT EDIT.
+// to regenerate the code, refer to help by invoking
+// go run github.com/ngicks/go-codegen/codegen cloner --help
+
+package reflectfallback
+
+import (
+	"github.com/ngicks/go-codegen/codegen/generator/cloner/internal/testtargets/reflectfallback/vendorlike"
+
+	cloneruntime "github.com/ngicks/go-codegen/pkg/cloner/runtime"
+)
+
+//codegen:generated
+func (v Fallback) Clone() Fallback {
+	return Fallback{
+		Set: cloneruntime.DeepClone(v.Set),
+		Sets: func(v []vendorlike.Set) []vendorlike.Set {
+			var out []vendorlike.Set
+
+			if v != nil {
+				out = make([]vendorlike.Set, len(v), cap(v))
+			}
+
+			inner := out
+			for k, v := range v {
+				inner[k] = cloneruntime.DeepClone(v)
+			}
+			out = inner
+
+			return out
+		}(v.Sets),
+		Ring: cloneruntime.DeepClone(v.Ring),
+		Names: func(src []string) []string {
+			if src == nil {
+				return nil
+			}
+			dst := make([]string, len(src), cap(src))
+			copy(dst, src)
+			return dst
+		}(v.Names),
+	}
+}
+
+//codegen:generated
+func (v Directed) Clone() Directed {
+	return Directed{
+		Set:    cloneruntime.DeepClone(v.Set),
+		Nested: cloneruntime.DeepClone(v.Nested),
+	}
+}
@@ -0,0 +1,27 @@
+// Code generated by github.com/ngicks/go-codegen/codegen DO NOT EDIT.
+// to regenerate the code, refer to help by invoking
+// go run github.com/ngicks/go-codegen/codegen cloner --help
+
+package reflectfallback
+
+import (
+	"testing"
+
+	clonetest "github.com/ngicks/go-codegen/pkg/cloner/runtime/clonetest"
+)
+
+func FuzzClone_Fallback(f *testing.F) {
+	clonetest.Fuzz(f, func(v Fallback) Fallback { return v.Clone() })
+}
+
+func BenchmarkClone_Fallback(b *testing.B) {
+	clonetest.Benchmark(b, func(v Fallback) Fallback { return v.Clone() })
+}
+
+func FuzzClone_Directed(f *testing.F) {
+	clonetest.Fuzz(f, func(v Directed) Directed { return v.Clone() })
+}
+
+func BenchmarkClone_Directed(b *testing.B) {
+	clonetest.Benchmark(b, func(v Directed) Directed { return v.Clone() })
+}
@@ -0,0 +1,19 @@
+package reflectfallback
+
+import "github.com/ngicks/go-codegen/codegen/generator/cloner/internal/testtargets/reflectfallback/vendorlike"
+
+// Fallback is cloned with the reflect fallback enabled.
+type Fallback struct {
+	Set   *vendorlike.Set
+	Sets  []vendorlike.Set
+	Ring  *vendorlike.Ring
+	Names []string
+}
+
+// Directed uses the directive instead.
+type Directed struct {
+	//cloner:reflect
+	Set *vendorlike.Set
+	//cloner:reflect
+	Nested map[string][]*Directed
+}
@@ -0,0 +1,60 @@
+// Package vendorlike mimics third party packages
+// whose types have unexported fields but no Clone method.
+package vendorlike
+
+// Set is a set of strings.
+//
+//codegen:ignore
+type Set struct {
+	m map[string]struct{}
+}
+
+func NewSet(values ...string) *Set {
+	s := &Set{m: map[string]struct{}{}}
+	for _, v := range values {
+		s.Add(v)
+	}
+	return s
+}
+
+func (s *Set) Add(v string) {
+	s.m[v] = struct{}{}
+}
+
+func (s *Set) Has(v string) bool {
+	_, ok := s.m[v]
+	return ok
+}
+
+func (s *Set) Len() int {
+	return len(s.m)
+}
+
+// Ring is a circular list.
+//
+//codegen:ignore
+type Ring struct {
+	Value int
+	next  *Ring
+}
+
+func NewRing(values ...int) *Ring {
+	var head, tail *Ring
+	for _, v := range values {
+		r := &Ring{Value: v}
+		if head == nil {
+			head = r
+		} else {
+			tail.next = r
+		}
+		tail = r
+	}
+	if tail != nil {
+		tail.next = head
+	}
+	return head
+}
+
+func (r *Ring) Next() *Ring {
+	return r.next
+}
@@ -0,0 +1,115 @@
+// Code generated by github.com/ngicks/go-codegen/codegen DO NOT EDIT.
+// to regenerate the code, refer to help by invoking
+// go run github.com/ngicks/go-codegen/codegen cloner --help
+
+package typedirective
+
+import "maps"
+
+//codegen:generated
+func (v Forced) Clone() Forced {
+	return Forced{
+		A: v.A,
+		B: v.B,
+	}
+}
+
+//codegen:generated
+func (v *Pointer) Clone() Pointer {
+	return Pointer{
+		Values: func(src []int) []int {
+			if src == nil {
+				return nil
+			}
+			dst := make([]int, len(src), cap(src))
+			copy(dst, src)
+			return dst
+		}(v.Values),
+	}
+}
+
+//codegen:generated
+func (v Renamed) Copy() Renamed {
+	return Renamed{
+		Values: maps.Clone(v.Values),
+	}
+}
+
+//codegen:generated
+func (v Channels) Clone() Channels {
+	return Channels{
+		Ch: make(chan int, cap(v.Ch)),
+		Ids: func(src []int) []int {
+			if src == nil {
+				return nil
+			}
+			dst := make([]int, len(src), cap(src))
+			copy(dst, src)
+			return dst
+		}(v.Ids),
+	}
+}
+
+//codegen:generated
+func (v *PointerSlice) Copy() PointerSlice {
+	return func(v []*Pointer) []*Pointer {
+		var out []*Pointer
+
+		if v != nil {
+			out = make([]*Pointer, len(v), cap(v))
+		}
+
+		inner := out
+		for k, v := range v {
+			outer := &inner
+			var inner *Pointer
+
+			if v != nil {
+				v := *v
+				vv := v.Clone()
+				inner = &vv
+			}
+			(*outer)[k] = inner
+		}
+		out = inner
+
+		return out
+	}((*v))
+}
+
+//codegen:generated
+func (v Holder) Clone() Holder {
+	return Holder{
+		Forced:   v.Forced.Clone(),
+		Pointer:  v.Pointer.Clone(),
+		Pointers: v.Pointers.Copy(),
+		Renamed: func(v *Renamed) *Renamed {
+			var out *Renamed
+
+			inner := out
+			if v != nil {
+				v := *v
+				vv := v.Copy()
+				inner = &vv
+			}
+			out = inner
+
+			return out
+		}(v.Renamed),
+		Channels: func(v []Channels) []Channels {
+			var out []Channels
+
+			if v != nil {
+				out = make([]Channels, len(v), cap(v))
+			}
+
+			inner := out
+			for k, v := range v {
+				inner[k] = v.Clone()
+			}
+			out = inner
+
+			return out
+		}(v.Channels),
+	}
+}
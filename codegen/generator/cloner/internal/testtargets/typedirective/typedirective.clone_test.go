@@ -0,0 +1,63 @@
+// Code generated by github.com/ngicks/go-codegen/codegen DO NOT EDIT.
+// to regenerate the code, refer to help by invoking
+// go run github.com/ngicks/go-codegen/codegen cloner --help
+
+package typedirective
+
+import (
+	"testing"
+
+	clonetest "github.com/ngicks/go-codegen/pkg/cloner/runtime/clonetest"
+)
+
+func init() {
+	clonetest.Skip[Channels]("Fn")
+}
+
+func FuzzClone_Forced(f *testing.F) {
+	clonetest.Fuzz(f, func(v Forced) Forced { return v.Clone() })
+}
+
+func BenchmarkClone_Forced(b *testing.B) {
+	clonetest.Benchmark(b, func(v Forced) Forced { return v.Clone() })
+}
+
+func FuzzClone_Pointer(f *testing.F) {
+	clonetest.Fuzz(f, func(v Pointer) Pointer { return v.Clone() })
+}
+
+func BenchmarkClone_Pointer(b *testing.B) {
+	clonetest.Benchmark(b, func(v Pointer) Pointer { return v.Clone() })
+}
+
+func FuzzCopy_Renamed(f *testing.F) {
+	clonetest.Fuzz(f, func(v Renamed) Renamed { return v.Copy() })
+}
+
+func BenchmarkCopy_Renamed(b *testing.B) {
+	clonetest.Benchmark(b, func(v Renamed) Renamed { return v.Copy() })
+}
+
+func FuzzClone_Channels(f *testing.F) {
+	clonetest.Fuzz(f, func(v Channels) Channels { return v.Clone() })
+}
+
+func BenchmarkClone_Channels(b *testing.B) {
+	clonetest.Benchmark(b, func(v Channels) Channels { return v.Clone() })
+}
+
+func FuzzCopy_PointerSlice(f *testing.F) {
+	clonetest.Fuzz(f, func(v PointerSlice) PointerSlice { return v.Copy() })
+}
+
+func BenchmarkCopy_PointerSlice(b *testing.B) {
+	clonetest.Benchmark(b, func(v PointerSlice) PointerSlice { return v.Copy() })
+}
+
+func FuzzClone_Holder(f *testing.F) {
+	clonetest.Fuzz(f, func(v Holder) Holder { return v.Clone() })
+}
+
+func BenchmarkClone_Holder(b *testing.B) {
+	clonetest.Benchmark(b, func(v Holder) Holder { return v.Clone() })
+}
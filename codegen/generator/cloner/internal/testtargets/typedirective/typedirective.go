@@ -0,0 +1,45 @@
+package typedirective
+
+// Forced only has fields which can be assigned.
+// It is not a generation target without the directive.
+//
+//cloner:generate
+type Forced struct {
+	A int
+	B string
+}
+
+// Pointer has methods with pointer receivers.
+//
+//cloner:ptr
+type Pointer struct {
+	Values []int
+}
+
+// Renamed has Copy instead of Clone.
+//
+//cloner:name=Copy
+type Renamed struct {
+	Values map[string]int
+}
+
+// Channels makes channels and ignores funcs regardless of global options.
+//
+//cloner:chan=make,func=ignore
+type Channels struct {
+	Ch  chan int
+	Fn  func()
+	Ids []int
+}
+
+//cloner:ptr,name=Copy
+type PointerSlice []*Pointer
+
+// Holder refers to types above.
+type Holder struct {
+	Forced   Forced
+	Pointer  Pointer
+	Pointers PointerSlice
+	Renamed  *Renamed
+	Channels []Channels
+}
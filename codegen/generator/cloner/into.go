@@ -0,0 +1,381 @@
+package cloner
+
+import (
+	"errors"
+	"fmt"
+	"go/types"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ngicks/go-codegen/codegen/pkg/astutil"
+	"github.com/ngicks/go-codegen/codegen/pkg/directive"
+	"github.com/ngicks/go-codegen/codegen/pkg/imports"
+	"github.com/ngicks/go-codegen/codegen/pkg/pkgsutil"
+	"github.com/ngicks/go-codegen/codegen/pkg/typegraph"
+	"github.com/ngicks/go-iterator-helper/hiter"
+	"github.com/ngicks/go-iterator-helper/hiter/stringsiter"
+)
+
+// generateInto writes CloneInto method, or CloneFuncInto for generic types, of node.
+// It must be called only for nodes for which generateCloner succeeded
+// so that fields are handled consistently.
+//
+// Fields which Clone leaves zero, e.g. ignored ones, are zeroed in dst as well.
+func generateInto(
+	c *Config,
+	printf func(format string, args ...any),
+	g *typegraph.Graph,
+	importMap imports.ImportMap,
+	node *typegraph.Node,
+) (err error) {
+	// CloneInto does not take memo even if CloneWithMemo is generated.
+	noMemo := *c
+	noMemo.Memo = false
+	c = &noMemo
+
+	typeName := node.Ts.Name.Name + astutil.PrintTypeParamsAst(node.Ts)
+	pkgPath := node.Type.Obj().Pkg().Path()
+	recv := receiver(node, typeName)
+	method := cloneMethod(g, node.Type)
+
+	var cloneCallbacks [][2]string
+
+	printf("//" + directive.DirectivePrefix + directive.DirectiveCommentGenerated + "\n")
+	if node.Type.TypeParams().Len() == 0 {
+		printf("func (%[2]s) %[3]sInto(dst *%[1]s) {\n", typeName, recv, method)
+	} else {
+		cloneCallbacks = gatherCloneCallback(node.Type.TypeParams())
+
+		printf(
+			"func (%[3]s) %[4]sFuncInto(dst *%[1]s, %[2]s) {\n",
+			typeName,
+			stringsiter.Join(
+				", ",
+				hiter.Map(
+					func(s [2]string) string {
+						return fmt.Sprintf("%[1]s func(%[2]s) %[2]s", s[0], s[1])
+					},
+					slices.Values(cloneCallbacks),
+				),
+			),
+			recv, method,
+		)
+	}
+	defer printf("}\n\n")
+
+	edges := node.ChildEdgeMap(c.MatcherConfig.MatchEdge)
+
+	switch x := node.Type.Underlying().(type) {
+	case *types.Struct:
+		for i, f := range pkgsutil.EnumerateFields(x) {
+			edge, _, _, _ := edges.ByFieldPos(i)
+			stmt, err := intoTy(
+				c,
+				pkgPath,
+				importMap,
+				g,
+				node,
+				edge.ChildNode,
+				i,
+				f.Type(),
+				cloneCallbacks,
+			)
+			switch {
+			case errors.Is(err, errParamNotOk):
+				return err
+			case err != nil:
+				// Clone leaves the field zero value. Do the same, or dst keeps its old value.
+				printf(
+					"dst.%s = %s\n",
+					f.Name(),
+					strings.ReplaceAll(zeroExpr(f.Type(), importMap.Qualifier(pkgPath)), "%", "%%"),
+				)
+				continue
+			}
+			printf(strings.ReplaceAll(stmt("dst."+f.Name(), "v."+f.Name()), "%", "%%"))
+			printf("\n")
+		}
+	case *types.Array, *types.Slice, *types.Map:
+		_, edge, _ := edges.First()
+		stmt, err := intoTy(
+			c,
+			pkgPath,
+			importMap,
+			g,
+			node,
+			edge.ChildNode,
+			-1,
+			x,
+			cloneCallbacks,
+		)
+		switch {
+		case errors.Is(err, errParamNotOk):
+			return err
+		case err != nil:
+			return nil
+		}
+		printf(strings.ReplaceAll(stmt("(*dst)", receiverValue(node)), "%", "%%"))
+		printf("\n")
+	}
+	return nil
+}
+
+// intoTy returns a function building statements which clone src into dst, an addressable expression of ty.
+// Slices, maps and pointers dst already has are reused where possible.
+// Values are handled, or ignored, in the same way as cloneTy does.
+func intoTy(
+	c *Config,
+	pkgPath string,
+	importMap imports.ImportMap,
+	g *typegraph.Graph,
+	parent *typegraph.Node,
+	child *typegraph.Node,
+	pos int,
+	ty types.Type,
+	cloneCallbacks [][2]string,
+) (stmt func(dst, src string) string, err error) {
+	unwrapped, stack, handleKind, idx := c.matcherConfig().handleField(
+		pos,
+		parent,
+		child,
+		g,
+		ty,
+	)
+
+	if handleKind == handleKindIgnore {
+		return nil, errNotHandled
+	}
+
+	route := fieldRoute(stack)
+
+	leafTy := types.Unalias(ty)
+	for _, n := range route {
+		leafTy = types.Unalias(unwrapTyOne(leafTy, n.Kind))
+	}
+
+	leaf, err := intoLeaf(c, pkgPath, importMap, g, handleKind, idx, unwrapped, leafTy, cloneCallbacks)
+	if err != nil {
+		return nil, err
+	}
+
+	qualifier := importMap.Qualifier(pkgPath)
+	return func(dst, src string) string {
+		return intoAlongPath(types.Unalias(ty), route, qualifier, handleKind == handleKindAssign, leaf, dst, src, 0)
+	}, nil
+}
+
+// intoLeaf returns a function building statements which clone src into dst,
+// both of leafTy, the type at the end of the route.
+func intoLeaf(
+	c *Config,
+	pkgPath string,
+	importMap imports.ImportMap,
+	g *typegraph.Graph,
+	handleKind handleKind,
+	customHandlerIndex int,
+	unwrapped types.Type,
+	leafTy types.Type,
+	cloneCallbacks [][2]string,
+) (stmt func(dst, src string) string, err error) {
+	switch handleKind {
+	case handleKindCallClone:
+		if method := cloneMethod(g, unwrapped) + "Into"; hasMethod(g, unwrapped, method) {
+			return func(dst, src string) string {
+				return fmt.Sprintf("%s.%s(%s)", src, method, addrOf(dst))
+			}, nil
+		}
+	case handleKindCallCloneFunc:
+		if method := cloneMethod(g, unwrapped) + "FuncInto"; hasMethod(g, unwrapped, method) {
+			args, err := cloneFuncArgs(c, pkgPath, importMap, g, unwrapped, cloneCallbacks)
+			if err != nil {
+				return nil, err
+			}
+			return func(dst, src string) string {
+				return fmt.Sprintf("%s.%s(\n%s,\n%s)", src, method, addrOf(dst), args)
+			}, nil
+		}
+	case handleKindUseCustomHandler:
+		handler := c.matcherConfig().CustomHandlers[customHandlerIndex]
+		if handler.Into != nil {
+			return handler.Into(CustomHandlerExprData{
+				ImportMap: importMap,
+				PkgPath:   pkgPath,
+				Ty:        unwrapped,
+			}), nil
+		}
+	}
+
+	// falls back to assigning the clone.
+	expr, callable, err := cloneLeaf(
+		c,
+		pkgPath,
+		importMap,
+		g,
+		handleKind,
+		customHandlerIndex,
+		unwrapped,
+		leafTy,
+		cloneCallbacks,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return func(dst, src string) string {
+		if callable {
+			return dst + " = " + expr(src) + "(" + src + ")"
+		}
+		return dst + " = " + expr(src)
+	}, nil
+}
+
+// intoAlongPath builds statements which clone src into dst, both of ty, following route.
+// depth is used to name variables declared in nested loops.
+// If assign is true, values at the end of the route are cloned by assignment
+// and innermost slices and arrays are copied at once.
+func intoAlongPath(
+	ty types.Type,
+	route []typegraph.EdgeRouteNode,
+	qualifier types.Qualifier,
+	assign bool,
+	leaf func(dst, src string) string,
+	dst, src string,
+	depth int,
+) string {
+	if len(route) == 0 {
+		return leaf(dst, src)
+	}
+
+	elem := types.Unalias(unwrapTyOne(ty, route[0].Kind))
+	next := func(dst, src string) string {
+		return intoAlongPath(elem, route[1:], qualifier, assign, leaf, dst, src, depth+1)
+	}
+	copyAtOnce := assign && len(route) == 1
+	d := strconv.Itoa(depth)
+
+	switch route[0].Kind {
+	case typegraph.EdgeKindPointer:
+		return fmt.Sprintf(
+			`if %[1]s == nil {
+				%[2]s = nil
+			} else {
+				if %[2]s == nil {
+					%[2]s = new(%[3]s)
+				}
+				%[4]s
+			}`,
+			src, dst, types.TypeString(elem, qualifier), next("(*"+dst+")", "(*"+src+")"),
+		)
+	case typegraph.EdgeKindArray:
+		if copyAtOnce {
+			return dst + " = " + src
+		}
+		return fmt.Sprintf(
+			`for i%[1]s := range %[2]s {
+				%[3]s
+			}`,
+			d, src, next(dst+"[i"+d+"]", src+"[i"+d+"]"),
+		)
+	case typegraph.EdgeKindSlice:
+		fill := fmt.Sprintf("copy(%s, %s)", dst, src)
+		if !copyAtOnce {
+			fill = fmt.Sprintf(
+				`for i%[1]s := range %[2]s {
+					%[3]s
+				}`,
+				d, src, next(dst+"[i"+d+"]", src+"[i"+d+"]"),
+			)
+		}
+		return fmt.Sprintf(
+			`if %[1]s == nil {
+				%[2]s = nil
+			} else {
+				if %[2]s == nil || cap(%[2]s) < len(%[1]s) {
+					%[2]s = make(%[3]s, len(%[1]s), cap(%[1]s))
+				} else {
+					%[2]s = %[2]s[:len(%[1]s)]
+				}
+				%[4]s
+			}`,
+			src, dst, types.TypeString(ty, qualifier), fill,
+		)
+	case typegraph.EdgeKindMap:
+		fill := fmt.Sprintf("%[1]s[k%[2]s] = v%[2]s", dst, d)
+		if !copyAtOnce {
+			fill = fmt.Sprintf(
+				`e%[1]s := %[2]s[k%[1]s]
+				%[3]s
+				%[2]s[k%[1]s] = e%[1]s`,
+				d, dst, next("e"+d, "v"+d),
+			)
+		}
+		return fmt.Sprintf(
+			`if %[1]s == nil {
+				%[2]s = nil
+			} else {
+				if %[2]s == nil {
+					%[2]s = make(%[3]s, len(%[1]s))
+				}
+				for k%[4]s := range %[2]s {
+					if _, ok := %[1]s[k%[4]s]; !ok {
+						delete(%[2]s, k%[4]s)
+					}
+				}
+				for k%[4]s, v%[4]s := range %[1]s {
+					%[5]s
+				}
+			}`,
+			src, dst, types.TypeString(ty, qualifier), d, fill,
+		)
+	}
+	panic(fmt.Errorf("unknown kind: %s", route[0].Kind))
+}
+
+// zeroExpr returns an expression evaluating to the zero value of ty.
+// Structs and arrays are zeroed by composite literals
+// so that assigning no-copy values, e.g. sync.Mutex, does not copy locks.
+func zeroExpr(ty types.Type, qualifier types.Qualifier) string {
+	if _, ok := types.Unalias(ty).(*types.TypeParam); ok {
+		return "*new(" + types.TypeString(ty, qualifier) + ")"
+	}
+	switch x := ty.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case x.Info()&types.IsBoolean != 0:
+			return "false"
+		case x.Info()&types.IsString != 0:
+			return `""`
+		case x.Info()&types.IsNumeric != 0:
+			return "0"
+		}
+		return "nil"
+	case *types.Struct, *types.Array:
+		return types.TypeString(ty, qualifier) + "{}"
+	default:
+		return "nil"
+	}
+}
+
+// addrOf returns an expression taking the address of x.
+// Dereferences that intoAlongPath adds, e.g. (*dst.F), are simply removed.
+func addrOf(x string) string {
+	if inner, ok := strings.CutPrefix(x, "(*"); ok && strings.HasSuffix(inner, ")") {
+		inner = strings.TrimSuffix(inner, ")")
+		if !strings.ContainsAny(inner, "()") {
+			return inner
+		}
+	}
+	return "&" + x
+}
+
+// fieldRoute drops the leading struct node and alias nodes from stack.
+// The rest is the route from the field type to the type handled.
+func fieldRoute(stack []typegraph.EdgeRouteNode) []typegraph.EdgeRouteNode {
+	if len(stack) > 0 && stack[0].Kind == typegraph.EdgeKindStruct {
+		stack = stack[1:]
+	}
+	return slices.DeleteFunc(
+		slices.Clone(stack),
+		func(n typegraph.EdgeRouteNode) bool { return n.Kind == typegraph.EdgeKindAlias },
+	)
+}
@@ -31,6 +31,9 @@ const (
 	_
 	// Only for channel. make a new channel.
 	CopyHandleMake
+	// Only for interface. clone values by type switch over implementors found in loaded packages,
+	// falling back to the registry of [github.com/ngicks/go-codegen/pkg/cloner/runtime].
+	CopyHandleClone
 )
 
 type MatcherConfig struct {
@@ -38,9 +41,25 @@ type MatcherConfig struct {
 	ChannelHandle   CopyHandle
 	FuncHandle      CopyHandle
 	InterfaceHandle CopyHandle
+	// AtomicHandle, if set, takes precedence over NoCopyHandle for values of sync/atomic types,
+	// e.g. atomic.Int64, atomic.Value and atomic.Pointer[T].
+	// CopyHandleClone clones them by Load and Store. Pointees of atomic.Pointer[T] are cloned if T can be cloned.
+	// Values in slices, arrays and maps can not be read without copying, thus left to NoCopyHandle.
+	AtomicHandle CopyHandle
+
+	// ReflectFallback makes values which otherwise would be ignored since they can not be cloned statically,
+	// e.g. named types of other packages having unexported fields but no Clone method,
+	// cloned by DeepClone of [github.com/ngicks/go-codegen/pkg/cloner/runtime].
+	ReflectFallback bool
 
 	CustomHandlers CustomHandlers
 
+	// reflect is set by the reflect directive. The whole field is cloned by reflection.
+	reflect bool
+	// typeDirections is type directives of types in loaded packages.
+	// Fields of named types are examined under them.
+	typeDirections map[*types.TypeName]typeDirection
+
 	logger *slog.Logger
 }
 
@@ -119,6 +138,17 @@ func (c *MatcherConfig) MatchType(node *typegraph.Node, external bool) (ok bool,
 		}
 		node.Priv = v
 	}()
+	reject := func(disallowed bool, rejection string) {
+		priv = priv.Map(func(v clonerPriv) clonerPriv {
+			v.disallowed = v.disallowed || disallowed
+			v.rejection = rejection
+			return v
+		})
+	}
+
+	typeDirection := priv.Value().typeDirection
+	overridden := typeDirection.override(*c)
+	c = &overridden
 
 	switch x := node.Type.Underlying().(type) {
 	default:
@@ -126,6 +156,7 @@ func (c *MatcherConfig) MatchType(node *typegraph.Node, external bool) (ok bool,
 			"not matched: unsupported type",
 			slog.Any("supported", []string{"struct", "array", "slice", "map"}),
 		)
+		reject(false, "unsupported underlying type "+types.TypeString(x, nil)+": must be struct, array, slice or map")
 		return false, nil
 	case *types.Struct:
 		for i, f := range pkgsutil.EnumerateFields(x) {
@@ -136,9 +167,9 @@ func (c *MatcherConfig) MatchType(node *typegraph.Node, external bool) (ok bool,
 				conf = direction.override(conf)
 			}
 			logger := logger.With(slog.Int("at", i), slog.String("fieldName", f.Name()))
-			unwrapped, _, kind, _, fieldOk := conf.matchTy(f.Type(), nil, nil, logger)
-			if !fieldOk {
-				priv = priv.Map(func(v clonerPriv) clonerPriv { v.disallowed = true; return v })
+			unwrapped, _, kind, _, rejection := conf.matchTy(f.Type(), nil, nil, logger)
+			if rejection != "" {
+				reject(true, "field "+f.Name()+": "+rejection)
 				logger.Debug("not matched")
 				return false, nil
 			}
@@ -156,24 +187,33 @@ func (c *MatcherConfig) MatchType(node *typegraph.Node, external bool) (ok bool,
 				ok = true
 			}
 		}
+		if !ok && typeDirection.Generate {
+			logger.Debug("generation forced by " + DirectivePrefix + DirectiveCommentGenerate)
+			ok = true
+		}
 		if ok {
 			logger.Debug("matched")
 		} else {
 			logger.Debug("not matched")
+			reject(false, "no field to clone: every field is ignored or assignable")
 		}
 	case *types.Array, *types.Slice, *types.Map:
 		ty := x.(interface{ Elem() types.Type }).Elem()
-		_, _, kind, _, fieldOk := c.matchTy(ty, nil, nil, logger)
+		_, _, kind, _, rejection := c.matchTy(ty, nil, nil, logger)
 		if kind == handleKindIgnore {
 			logger.Debug("not matched: type ignored")
+			if rejection == "" {
+				rejection = "element type is ignored"
+			}
+			reject(false, "element: "+rejection)
 			return false, nil
 		}
-		if fieldOk {
+		if rejection == "" {
 			logger.Debug("matched: type ok")
 		} else {
-			priv = priv.Map(func(v clonerPriv) clonerPriv { v.disallowed = true; return v })
+			reject(true, "element: "+rejection)
 		}
-		return fieldOk, nil
+		return rejection == "", nil
 	}
 	return
 }
@@ -190,15 +230,22 @@ const (
 	handleKindUseCustomHandler
 	handleKindStructLiteral
 	handleKindCopyPublicField
+	handleKindCloneInterface
+	handleKindReflect
+	handleKindAtomic
 )
 
-func (c *MatcherConfig) matchTy(ty types.Type, graph *typegraph.Graph, visited map[typegraph.Ident]bool, logger *slog.Logger) (unwrapped types.Type, stack []typegraph.EdgeRouteNode, k handleKind, customHandlerIndex int, ok bool) {
+// matchTy decides how ty should be handled.
+// rejection is non empty if ty is disallowed, describing the reason.
+func (c *MatcherConfig) matchTy(ty types.Type, graph *typegraph.Graph, visited map[typegraph.Ident]bool, logger *slog.Logger) (unwrapped types.Type, stack []typegraph.EdgeRouteNode, k handleKind, customHandlerIndex int, rejection string) {
 	if visited == nil {
 		visited = make(map[typegraph.Ident]bool)
 	}
 	k = handleKindIgnore
-	ok = true
 	customHandlerIndex = -1
+	if c.reflect {
+		return ty, nil, handleKindReflect, customHandlerIndex, ""
+	}
 	_ = typegraph.TraverseTypes(
 		ty,
 		func(ty types.Type, currentStack []typegraph.EdgeRouteNode) bool {
@@ -225,7 +272,7 @@ func (c *MatcherConfig) matchTy(ty types.Type, graph *typegraph.Graph, visited m
 					"disallowed route edge node: struct literal or interface literal",
 					slog.Any("stack", stack),
 				)
-				ok = false
+				rejection = "struct literal or interface literal in the route"
 				return nil
 			}
 			if i := slices.IndexFunc(
@@ -249,7 +296,7 @@ func (c *MatcherConfig) matchTy(ty types.Type, graph *typegraph.Graph, visited m
 							"//" + DirectivePrefix + DirectiveCommentCopyPtr +
 							" as field doc comment",
 					)
-					ok = false
+					rejection = "contains channel: ChannelHandle is CopyHandleDisallow"
 				case CopyHandleCopyPointer:
 					// chan itself is a pointer type.
 					k = handleKindAssign
@@ -279,6 +326,28 @@ func (c *MatcherConfig) matchTy(ty types.Type, graph *typegraph.Graph, visited m
 					k = handleKindCallCloneFunc
 				case clonerMatcher.IsImplementor(unwrapped_):
 					k = handleKindCallClone
+				case c.AtomicHandle != copyHandleInvalid && isAtomic(x) &&
+					(c.AtomicHandle != CopyHandleClone || len(stack) == 0 || stack[len(stack)-1].Kind == typegraph.EdgeKindPointer):
+					switch c.AtomicHandle {
+					case CopyHandleIgnore:
+						logger.Debug("ignoring field since it contains atomic value")
+						k = handleKindIgnore
+					case CopyHandleDisallow:
+						logger.Debug("ignoring type since it contains atomic value")
+						rejection = "contains atomic value " + qualifiedName(x) + ": AtomicHandle is CopyHandleDisallow"
+					case CopyHandleCopyPointer:
+						k = handleKindIgnore
+						if len(stack) > 0 && stack[len(stack)-1].Kind == typegraph.EdgeKindPointer {
+							k = handleKindAssign
+							stack = stack[:len(stack)-1] // ignore last pointer.
+						}
+					case CopyHandleClone:
+						k = handleKindAtomic
+						if len(stack) > 0 {
+							stack = stack[:len(stack)-1] // the pointer is cloned along with the value.
+						}
+					}
+					return nil
 				case typematcher.IsNoCopy(x):
 					switch c.NoCopyHandle {
 					case CopyHandleIgnore:
@@ -308,7 +377,7 @@ func (c *MatcherConfig) matchTy(ty types.Type, graph *typegraph.Graph, visited m
 						k = handleKindIgnore
 						return nil
 					}
-					ok = false
+					rejection = "contains no copy object " + qualifiedName(x) + ": NoCopyHandle is CopyHandleDisallow"
 					return nil
 				case typematcher.IsCloneByAssign(unwrapped_, cloneByAssignNamedTypeMatcher(graph)):
 					k = handleKindAssign
@@ -317,6 +386,18 @@ func (c *MatcherConfig) matchTy(ty types.Type, graph *typegraph.Graph, visited m
 				case asUnderlying[*types.Interface](unwrapped_) != nil:
 					k = handleInterface(c, logger).Value()
 				default:
+					// generation targets are cloned by their Clone methods instead. see handleField.
+					if c.ReflectFallback && x.Obj().Exported() && (graph == nil || !isGenerated(graph, x)) {
+						defer func() {
+							if k == handleKindIgnore && rejection == "" {
+								k = handleKindReflect
+								// let DeepClone see the pointer to preserve cycles back to it.
+								if len(stack) > 0 && stack[len(stack)-1].Kind == typegraph.EdgeKindPointer {
+									stack = stack[:len(stack)-1]
+								}
+							}
+						}()
+					}
 					// Watch out for type recursion. A name type can have itself in its struct field as pointer type of it.
 					if visited[typegraph.IdentFromTypesObject(x.Obj())] {
 						// can't generate ad-hoc unnamed function for recursive types.
@@ -324,11 +405,17 @@ func (c *MatcherConfig) matchTy(ty types.Type, graph *typegraph.Graph, visited m
 						return nil
 					}
 					visited[typegraph.IdentFromTypesObject(x.Obj())] = true
+					// fields are examined under the type directives of x.
+					conf := c
+					if d, ok := c.typeDirections[x.Obj()]; ok {
+						overridden := d.override(*c)
+						conf = &overridden
+					}
 					switch x2 := x.Underlying().(type) {
 					default:
-						t, _, k2, _, ok2 := c.matchTy(x.Underlying(), graph, visited, logger.WithGroup(qualifiedName(x)))
-						if !ok2 {
-							ok = false
+						t, _, k2, _, rejection2 := conf.matchTy(x.Underlying(), graph, visited, logger.WithGroup(qualifiedName(x)))
+						if rejection2 != "" {
+							rejection = qualifiedName(x) + ": " + rejection2
 							return nil
 						}
 						if named := as[*types.Named](t); named != nil && !named.Obj().Exported() {
@@ -346,7 +433,7 @@ func (c *MatcherConfig) matchTy(ty types.Type, graph *typegraph.Graph, visited m
 								disabled = true
 								continue
 							}
-							t, _, k2, _, ok2 := c.matchTy(
+							t, _, k2, _, rejection2 := conf.matchTy(
 								f.Type(),
 								graph,
 								visited,
@@ -356,8 +443,8 @@ func (c *MatcherConfig) matchTy(ty types.Type, graph *typegraph.Graph, visited m
 									slog.String("fieldName", f.Name()),
 								),
 							)
-							if !ok2 {
-								ok = false
+							if rejection2 != "" {
+								rejection = qualifiedName(x) + "." + f.Name() + ": " + rejection2
 								return nil
 							}
 							if named := as[*types.Named](t); named != nil && !named.Obj().Exported() {
@@ -379,7 +466,7 @@ func (c *MatcherConfig) matchTy(ty types.Type, graph *typegraph.Graph, visited m
 				} else {
 					atLeastOne := false
 					for i, f := range pkgsutil.EnumerateFields(x) {
-						_, _, k2, _, ok2 := c.matchTy(
+						_, _, k2, _, rejection2 := c.matchTy(
 							f.Type(),
 							graph,
 							visited,
@@ -389,8 +476,8 @@ func (c *MatcherConfig) matchTy(ty types.Type, graph *typegraph.Graph, visited m
 								slog.String("fieldName", f.Name()), // TODO: use WithGroup
 							),
 						)
-						if !ok2 {
-							ok = false
+						if rejection2 != "" {
+							rejection = "struct literal field " + f.Name() + ": " + rejection2
 							return nil
 						}
 						if k2 != handleKindIgnore {
@@ -421,15 +508,15 @@ func (c *MatcherConfig) matchTy(ty types.Type, graph *typegraph.Graph, visited m
 					if pkgPath != "" {
 						pkgPath = strconv.Quote(pkgPath) + "."
 					}
-					_, _, kind, _, argOk := c.matchTy(
+					_, _, kind, _, argRejection := c.matchTy(
 						arg,
 						graph,
 						visited,
 						logger.With("typeArgFor", pkgPath+name),
 					)
-					if !argOk {
+					if argRejection != "" {
 						logger.Debug("ignoring type: type arg at " + strconv.FormatInt(int64(i), 10) + " is disallowed")
-						ok = false
+						rejection = "type arg at " + strconv.FormatInt(int64(i), 10) + " of " + pkgPath + name + ": " + argRejection
 						return nil
 					} else if kind == handleKindIgnore {
 						logger.Debug("ignoring field: type arg at " + strconv.FormatInt(int64(i), 10) + " is ignored")
@@ -500,6 +587,8 @@ func handleInterface(c *MatcherConfig, logger *slog.Logger) option.Option[handle
 	case CopyHandleCopyPointer:
 		// func itself is a pointer type.
 		return option.Some(handleKindAssign)
+	case CopyHandleClone:
+		return option.Some(handleKindCloneInterface)
 	}
 	logger.Debug("unknown kind", slog.Int("kind", int(c.FuncHandle)))
 	return option.Some(handleKindIgnore)
@@ -512,7 +601,7 @@ func (c *MatcherConfig) handleField(
 	graph *typegraph.Graph,
 	ty types.Type,
 ) (unwrapped types.Type, stack []typegraph.EdgeRouteNode, k handleKind, customHandlerIndex int) {
-	conf := *c
+	conf := typeDirectionOf(parent).override(*c)
 	if parent != nil && pos >= 0 {
 		priv, ok := parent.Priv.(clonerPriv)
 		if ok {
@@ -523,22 +612,22 @@ func (c *MatcherConfig) handleField(
 		}
 	}
 
-	unwrapped, stack, k, customHandlerIndex, ok := conf.matchTy(
+	unwrapped, stack, k, customHandlerIndex, rejection := conf.matchTy(
 		ty,
 		graph,
 		nil,
 		noopLogger,
 	)
-	if !ok {
+	if rejection != "" {
 		k = handleKindIgnore
 		return
 	}
 
-	if customHandlerIndex >= 0 {
+	if customHandlerIndex >= 0 || k == handleKindAtomic {
 		return
 	}
 
-	if asStruct(unwrapped) == nil && child != nil && child.Matched&^typegraph.MatchKindExternal > 0 {
+	if !conf.reflect && asStruct(unwrapped) == nil && child != nil && child.Matched&^typegraph.MatchKindExternal > 0 {
 		if child.Type.TypeParams().Len() == 0 {
 			k = handleKindCallClone
 		} else {
@@ -0,0 +1,55 @@
+package cloner
+
+import (
+	"fmt"
+	"go/types"
+
+	"github.com/ngicks/go-codegen/codegen/pkg/typegraph"
+)
+
+// memoParam is the name of the parameter of CloneWithMemo and CloneFuncWithMemo
+// which maps pointers of the source to pointers already cloned.
+const memoParam = "memo"
+
+// memoType is the type of the memo parameter.
+// Keys are pointers of the source stored as any, so that they are compared by both address and type;
+// a pointer to a struct and a pointer to its first field share an address but not a type.
+const memoType = "map[any]any"
+
+// hasMethod reports whether ty has the method, or will have it since it is a generation target in g.
+// Unlike [hasEqual], signatures of the method are not checked.
+func hasMethod(g *typegraph.Graph, ty types.Type, method string) bool {
+	named, ok := types.Unalias(ty).(*types.Named)
+	if !ok {
+		return false
+	}
+	if n, ok := g.GetByType(named); ok && n.Matched&^typegraph.MatchKindExternal > 0 {
+		return true
+	}
+	obj, _, _ := types.LookupFieldOrMethod(named, false, named.Obj().Pkg(), method)
+	_, ok = obj.(*types.Func)
+	return ok
+}
+
+// memoizedPointer returns statements cloning the pointer v into the variable inner.
+// A pointer already cloned is reused, otherwise the cloned pointer p is stored in memo
+// before fill is executed so that cycles back to v resolve to p.
+//
+// elemTy is the type of the pointee. fill is statements which set *p,
+// executed where v is shadowed by the pointee.
+func memoizedPointer(elemTy, fill string) string {
+	return fmt.Sprintf(
+		`if v != nil {
+			if p, ok := %[1]s[v].(*%[2]s); ok {
+				inner = p
+			} else {
+				p := new(%[2]s)
+				%[1]s[v] = p
+				inner = p
+				v := *v
+				%[3]s
+			}
+		}`,
+		memoParam, elemTy, fill,
+	)
+}
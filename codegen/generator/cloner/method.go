@@ -43,6 +43,18 @@ func generateMethod(
 	if err != nil {
 		return err
 	}
+	if c.GenerateInto {
+		err = generateInto(c, printf, g, replacer.ImportMap, node)
+		if err != nil {
+			return err
+		}
+	}
+	if c.GenerateEqual {
+		err = generateEqual(c, printf, g, replacer.ImportMap, node)
+		if err != nil {
+			return err
+		}
+	}
 	err = flush()
 	if err != nil {
 		return err
@@ -62,29 +74,59 @@ func generateCloner(
 	node *typegraph.Node,
 ) (err error) {
 	typeName := node.Ts.Name.Name + astutil.PrintTypeParamsAst(node.Ts)
+	recv := receiver(node, typeName)
+	method := cloneMethod(g, node.Type)
 
 	var cloneCallbacks [][2]string
 
 	printf("//" + directive.DirectivePrefix + directive.DirectiveCommentGenerated + "\n")
 	if node.Type.TypeParams().Len() == 0 {
-		printf("func (v %[1]s) Clone() %[1]s {\n", typeName)
+		if c.Memo {
+			printf(
+				`func (%[3]s) %[4]s() %[1]s {
+	return v.%[4]sWithMemo(make(%[2]s))
+}
+
+`,
+				typeName, memoType, recv, method,
+			)
+			printf("//" + directive.DirectivePrefix + directive.DirectiveCommentGenerated + "\n")
+			printf("func (%[4]s) %[5]sWithMemo(%[2]s %[3]s) %[1]s {\n", typeName, memoParam, memoType, recv, method)
+		} else {
+			printf("func (%[2]s) %[3]s() %[1]s {\n", typeName, recv, method)
+		}
 	} else {
 		// [][2]string{{"cloneT","T"}}
 		cloneCallbacks = gatherCloneCallback(node.Type.TypeParams())
 
-		printf(
-			"func (v %[1]s) CloneFunc(%[2]s) %[1]s {\n",
-			typeName,
-			stringsiter.Join(
-				", ",
-				hiter.Map(
-					func(s [2]string) string {
-						return fmt.Sprintf("%[1]s func(%[2]s) %[2]s", s[0], s[1])
-					},
-					slices.Values(cloneCallbacks),
-				),
+		params := stringsiter.Join(
+			", ",
+			hiter.Map(
+				func(s [2]string) string {
+					return fmt.Sprintf("%[1]s func(%[2]s) %[2]s", s[0], s[1])
+				},
+				slices.Values(cloneCallbacks),
 			),
 		)
+		if c.Memo {
+			printf(
+				`func (%[5]s) %[6]sFunc(%[2]s) %[1]s {
+	return v.%[6]sFuncWithMemo(make(%[3]s), %[4]s)
+}
+
+`,
+				typeName, params, memoType,
+				stringsiter.Join(", ", hiter.Map(func(s [2]string) string { return s[0] }, slices.Values(cloneCallbacks))),
+				recv, method,
+			)
+			printf("//" + directive.DirectivePrefix + directive.DirectiveCommentGenerated + "\n")
+			printf(
+				"func (%[5]s) %[6]sFuncWithMemo(%[2]s %[3]s, %[4]s) %[1]s {\n",
+				typeName, memoParam, memoType, params, recv, method,
+			)
+		} else {
+			printf("func (%[3]s) %[4]sFunc(%[2]s) %[1]s {\n", typeName, params, recv, method)
+		}
 	}
 	defer printf("}\n\n")
 
@@ -142,7 +184,7 @@ func generateCloner(
 			}
 			printf(",\n")
 		}
-		if handled == 0 {
+		if handled == 0 && !typeDirectionOf(node).Generate {
 			err = errNotHandled
 		}
 	case *types.Array, *types.Slice, *types.Map:
@@ -177,11 +219,12 @@ func generateCloner(
 			return err
 		}
 
+		v := receiverValue(node)
 		printf("return ")
 		if callable {
-			printf(strings.ReplaceAll(clonerExpr("v")+"(v)", "%", "%%"))
+			printf(strings.ReplaceAll(clonerExpr(v)+"("+v+")", "%", "%%"))
 		} else {
-			printf(strings.ReplaceAll(clonerExpr("v"), "%", "%%"))
+			printf(strings.ReplaceAll(clonerExpr(v), "%", "%%"))
 		}
 		printf("\n")
 	}
@@ -255,9 +298,50 @@ func cloneTy(
 		importMap.Qualifier(pkgPath),
 		stack,
 		0,
+		c.Memo,
 	)
 
-	var cloneExpr func(s string) string
+	cloneExpr, callable, err := cloneLeaf(
+		c,
+		pkgPath,
+		importMap,
+		g,
+		handleKind,
+		idx,
+		unwrapped,
+		unwrappedTy,
+		cloneCallbacks,
+	)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if unwrapper != nil {
+		if callable {
+			inner := cloneExpr
+			cloneExpr = func(s string) string {
+				return inner("") + "(" + s + ")"
+			}
+		}
+		return func(s string) string { return unwrapper(cloneExpr) }, true, nil
+	} else {
+		return cloneExpr, callable, nil
+	}
+}
+
+// cloneLeaf returns a cloner expression for values of unwrapped, the type at the end of a route, handled as handleKind.
+// unwrappedTy is unwrapped as it is written in the route.
+func cloneLeaf(
+	c *Config,
+	pkgPath string,
+	importMap imports.ImportMap,
+	g *typegraph.Graph,
+	handleKind handleKind,
+	idx int,
+	unwrapped types.Type,
+	unwrappedTy types.Type,
+	cloneCallbacks [][2]string,
+) (cloneExpr func(s string) string, callable bool, err error) {
 	switch handleKind {
 	default:
 		panic(fmt.Errorf("unknown kind: %d", handleKind))
@@ -272,69 +356,25 @@ func cloneTy(
 			return fmt.Sprintf("%s(%s)", cloneCallbacks[unwrapped.(*types.TypeParam).Index()][0], s)
 		}
 	case handleKindCallClone:
-		cloneExpr = func(s string) string { return s + ".Clone()" }
+		method := cloneMethod(g, unwrapped)
+		if c.Memo && hasMethod(g, unwrapped, method+"WithMemo") {
+			cloneExpr = func(s string) string { return s + "." + method + "WithMemo(" + memoParam + ")" }
+		} else {
+			cloneExpr = func(s string) string { return s + "." + method + "()" }
+		}
 	case handleKindCallCloneFunc:
-		builder := strings.Builder{}
-		builder.WriteString(".CloneFunc(\n")
-		// always instantiated
-		for i, t := range hiter.AtterAll(types.Unalias(unwrapped).(*types.Named).TypeArgs()) {
-			switch x := t.(type) {
-			case *types.TypeParam:
-				builder.WriteString(cloneCallbacks[x.Index()][0])
-			default:
-				var childTy types.Type
-				_ = typegraph.TraverseTypes(
-					x,
-					nil,
-					func(ty types.Type, named *types.Named, stack []typegraph.EdgeRouteNode) error {
-						childTy = ty
-						return nil
-					},
-					nil,
-				)
-				child, _ := g.GetByType(childTy)
-
-				var cbs [][2]string
-				named, ok := types.Unalias(x).(*types.Named)
-				if ok {
-					cbs = gatherCloneCallback(named.TypeParams())
-				}
-
-				expr, callable, err := cloneTy(
-					c,
-					pkgPath,
-					importMap,
-					g,
-					nil,
-					child,
-					-1,
-					x,
-					cbs,
-				)
-
-				if err != nil {
-					return nil, false, fmt.Errorf("%w: type param at index %d: %w", errParamNotOk, i, err)
-				}
-
-				if callable {
-					builder.WriteString(expr("v"))
-				} else {
-					builder.WriteString(
-						fmt.Sprintf(
-							`func (v %[1]s) %[1]s {
-								return %[2]s
-							}`,
-							astutil.PrintAstExprPanicking(astutil.TypeToAst(x, pkgPath, importMap)), expr("v"),
-						),
-					)
-				}
-			}
-			builder.WriteString(",\n")
+		base := cloneMethod(g, unwrapped)
+		method := "." + base + "Func(\n"
+		if c.Memo && hasMethod(g, unwrapped, base+"FuncWithMemo") {
+			method = "." + base + "FuncWithMemo(\n" + memoParam + ",\n"
+		}
+		var args string
+		args, err = cloneFuncArgs(c, pkgPath, importMap, g, unwrapped, cloneCallbacks)
+		if err != nil {
+			return nil, false, err
 		}
-		builder.WriteString(")")
-
 		cloneExpr = func(s string) string {
-			return s + builder.String()
+			return s + method + args + ")"
 		}
 	case handleKindUseCustomHandler:
 		cloneExpr, callable = c.matcherConfig().
@@ -371,19 +411,87 @@ func cloneTy(
 		if err != nil {
 			return
 		}
+	case handleKindCloneInterface:
+		callable = true
+		expr := cloneInterface(c, pkgPath, importMap, g, unwrapped)
+		cloneExpr = func(string) string { return expr }
+	case handleKindReflect:
+		runtimeIdent, _ := importMap.Ident(runtimePkgPath)
+		cloneExpr = func(s string) string { return runtimeIdent + ".DeepClone(" + s + ")" }
+	case handleKindAtomic:
+		return cloneAtomic(c, pkgPath, importMap, g, unwrapped, unwrappedTy, cloneCallbacks)
 	}
 
-	if unwrapper != nil {
-		if callable {
-			inner := cloneExpr
-			cloneExpr = func(s string) string {
-				return inner("") + "(" + s + ")"
+	return cloneExpr, callable, nil
+}
+
+// cloneFuncArgs returns arguments passed to CloneFunc of unwrapped, an instantiated generic type,
+// each followed by a comma and a newline.
+func cloneFuncArgs(
+	c *Config,
+	pkgPath string,
+	importMap imports.ImportMap,
+	g *typegraph.Graph,
+	unwrapped types.Type,
+	cloneCallbacks [][2]string,
+) (string, error) {
+	builder := strings.Builder{}
+	// always instantiated
+	for i, t := range hiter.AtterAll(types.Unalias(unwrapped).(*types.Named).TypeArgs()) {
+		switch x := t.(type) {
+		case *types.TypeParam:
+			builder.WriteString(cloneCallbacks[x.Index()][0])
+		default:
+			var childTy types.Type
+			_ = typegraph.TraverseTypes(
+				x,
+				nil,
+				func(ty types.Type, named *types.Named, stack []typegraph.EdgeRouteNode) error {
+					childTy = ty
+					return nil
+				},
+				nil,
+			)
+			child, _ := g.GetByType(childTy)
+
+			var cbs [][2]string
+			named, ok := types.Unalias(x).(*types.Named)
+			if ok {
+				cbs = gatherCloneCallback(named.TypeParams())
+			}
+
+			expr, callable, err := cloneTy(
+				c,
+				pkgPath,
+				importMap,
+				g,
+				nil,
+				child,
+				-1,
+				x,
+				cbs,
+			)
+
+			if err != nil {
+				return "", fmt.Errorf("%w: type param at index %d: %w", errParamNotOk, i, err)
+			}
+
+			if callable {
+				builder.WriteString(expr("v"))
+			} else {
+				builder.WriteString(
+					fmt.Sprintf(
+						`func (v %[1]s) %[1]s {
+							return %[2]s
+						}`,
+						astutil.PrintAstExprPanicking(astutil.TypeToAst(x, pkgPath, importMap)), expr("v"),
+					),
+				)
 			}
 		}
-		return func(s string) string { return unwrapper(cloneExpr) }, true, nil
-	} else {
-		return cloneExpr, callable, nil
+		builder.WriteString(",\n")
 	}
+	return builder.String(), nil
 }
 
 func handleStruct(
@@ -493,11 +601,16 @@ func unwrapTyOne(ty types.Type, kind typegraph.EdgeKind) types.Type {
 	return ty
 }
 
+// unwrapFieldAlongPath returns a function which wraps a cloner expression of the innermost type of stack
+// into a func literal cloning toTy along stack.
+//
+// If memo is true, pointers are cloned through memo so that aliasing and cycles are preserved.
 func unwrapFieldAlongPath(
 	fromTy, toTy types.Type,
 	qualifier types.Qualifier,
 	stack []typegraph.EdgeRouteNode,
 	skip int,
+	memo bool,
 ) (unwrapped types.Type, unwrapper func(wrappee func(string) string) string) {
 	if fromTy == nil || toTy == nil {
 		return toTy, nil
@@ -542,6 +655,21 @@ func unwrapFieldAlongPath(
 		initializerExpr := initializer(unwrapped, p[1].Kind, "inner")
 		switch p[0].Kind {
 		case typegraph.EdgeKindPointer:
+			if memo {
+				wrappers = append(wrappers, func(s string) string {
+					return memoizedPointer(
+						tyExpr,
+						fmt.Sprintf(
+							`var inner %s
+							%s
+							%s
+							*p = inner`,
+							tyExpr, initializerExpr, s,
+						),
+					)
+				})
+				continue
+			}
 			wrappers = append(wrappers, func(s string) string {
 				return fmt.Sprintf(
 					`if v != nil {
@@ -574,6 +702,13 @@ func unwrapFieldAlongPath(
 	// inner most
 	switch s[len(s)-1].Kind {
 	case typegraph.EdgeKindPointer:
+		if memo {
+			elemExpr := types.TypeString(unwrapTyOne(types.Unalias(unwrapped), s[len(s)-1].Kind), qualifier)
+			wrappers = append(wrappers, func(s string) string {
+				return memoizedPointer(elemExpr, "*p = "+s)
+			})
+			break
+		}
 		wrappers = append(wrappers, func(s string) string {
 			return fmt.Sprintf(
 				`if v != nil {
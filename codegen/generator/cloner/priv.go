@@ -18,6 +18,11 @@ const (
 	DirectiveCommentIgnore  = "ignore"
 	DirectiveCommentCopyPtr = "copyptr"
 	DirectiveCommentMake    = "make"
+	DirectiveCommentReflect = "reflect"
+	// Directives below only affect generated Equal methods.
+	DirectiveCommentEqualIgnore   = "eqignore"
+	DirectiveCommentEqualPtr      = "eqptr"
+	DirectiveCommentEqualNilEmpty = "eqnilempty"
 )
 
 var (
@@ -26,7 +31,18 @@ var (
 
 type clonerPriv struct {
 	disallowed bool
-	lines      map[int]direction
+	// rejection describes why the type is not matched, if it is not.
+	rejection     string
+	typeDirection typeDirection
+	lines         map[int]direction
+}
+
+// rejectionOf returns the reason node is not matched, recorded by [MatcherConfig.MatchType].
+func rejectionOf(node *typegraph.Node) []string {
+	if priv, ok := node.Priv.(clonerPriv); ok && priv.rejection != "" {
+		return []string{priv.rejection}
+	}
+	return nil
 }
 
 type direction struct {
@@ -34,6 +50,14 @@ type direction struct {
 	Ignore  bool
 	CopyPtr bool
 	Make    bool
+	// The field is cloned by reflection.
+	Reflect bool
+	// Equal skips the field.
+	EqIgnore bool
+	// Equal compares pointers by identity.
+	EqPtr bool
+	// Equal treats nil and empty slices or maps as equal.
+	EqNilEmpty bool
 }
 
 func (d direction) override(c MatcherConfig) MatcherConfig {
@@ -42,12 +66,16 @@ func (d direction) override(c MatcherConfig) MatcherConfig {
 		c.ChannelHandle = CopyHandleIgnore
 		c.NoCopyHandle = CopyHandleIgnore
 		c.FuncHandle = CopyHandleIgnore
+		c.AtomicHandle = CopyHandleIgnore
 	case d.CopyPtr:
 		c.ChannelHandle = CopyHandleCopyPointer
 		c.NoCopyHandle = CopyHandleCopyPointer
 		c.FuncHandle = CopyHandleCopyPointer
+		c.AtomicHandle = CopyHandleCopyPointer
 	case d.Make:
 		c.ChannelHandle = CopyHandleMake
+	case d.Reflect:
+		c.reflect = true
 	}
 	return c
 }
@@ -60,6 +88,14 @@ func parseNode(n *typegraph.Node) (any, error) {
 		panic(err)
 	}
 
+	td, err := parseTypeDirection(n.File, n.Ts, n.Type)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"parsing %q.%s: %w",
+			n.Type.Obj().Pkg().Path(), n.Type.Obj().Name(), err,
+		)
+	}
+
 	dts := dec.Dst.Nodes[n.Ts].(*dst.TypeSpec)
 
 	st, ok := dts.Type.(*dst.StructType)
@@ -69,7 +105,7 @@ func parseNode(n *typegraph.Node) (any, error) {
 	//   - *SelectorExpr(type based on type defined in other packages)
 	//   - *StarExpr(pointer type)
 	if !ok {
-		return nil, nil
+		return clonerPriv{typeDirection: td}, nil
 	}
 	lines := make(map[int]direction)
 	for i, f := range hiter.Enumerate(astutil.FieldDst(st)) {
@@ -87,6 +123,14 @@ func parseNode(n *typegraph.Node) (any, error) {
 							parsed.CopyPtr = true
 						case DirectiveCommentMake:
 							parsed.Make = true
+						case DirectiveCommentReflect:
+							parsed.Reflect = true
+						case DirectiveCommentEqualIgnore:
+							parsed.EqIgnore = true
+						case DirectiveCommentEqualPtr:
+							parsed.EqPtr = true
+						case DirectiveCommentEqualNilEmpty:
+							parsed.EqNilEmpty = true
 						default:
 							return parsed, fmt.Errorf("%w: %q", ErrUnknownDirective, directive)
 						}
@@ -108,5 +152,5 @@ func parseNode(n *typegraph.Node) (any, error) {
 		}
 	}
 
-	return clonerPriv{lines: lines}, nil
+	return clonerPriv{typeDirection: td, lines: lines}, nil
 }
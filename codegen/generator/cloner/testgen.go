@@ -0,0 +1,142 @@
+package cloner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go/types"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/dave/dst/decorator"
+	"github.com/ngicks/go-codegen/codegen/internal/bufpool"
+	"github.com/ngicks/go-codegen/codegen/pkg/astutil"
+	"github.com/ngicks/go-codegen/codegen/pkg/pkgsutil"
+	"github.com/ngicks/go-codegen/codegen/pkg/suffixwriter"
+	"github.com/ngicks/go-codegen/codegen/pkg/typegraph"
+	"github.com/ngicks/go-codegen/codegen/pkg/typematcher"
+)
+
+const clonetestPkgPath = "github.com/ngicks/go-codegen/pkg/cloner/runtime/clonetest"
+
+// writeTest writes fuzz tests and benchmarks for clone methods generated for data
+// into the test file along the generated file, e.g. foo.clone_test.go for foo.go.
+// Generic types are not tested since type arguments are unknown, nor are no-copy types.
+//
+// It is not cached since the cache only knows the generated file.
+func (c *Config) writeTest(
+	ctx context.Context,
+	w *suffixwriter.Writer,
+	g *typegraph.Graph,
+	data *typegraph.ReplaceData,
+) error {
+	type target struct {
+		typeName string
+		method   string
+		skipped  []string
+	}
+	var targets []target
+	for _, node := range data.TargetNodes {
+		// no-copy types can not be passed to clone functions by value.
+		if node.Type.TypeParams().Len() > 0 || typematcher.IsNoCopy(node.Type) {
+			continue
+		}
+		// only types for which generateMethod generates are tested.
+		err := generateMethod(c, io.Discard, g, node, data)
+		if err != nil {
+			if errors.Is(err, errNotHandled) {
+				continue
+			}
+			return err
+		}
+		targets = append(targets, target{
+			typeName: node.Ts.Name.Name,
+			method:   cloneMethod(g, node.Type),
+			skipped:  skippedFields(c, g, data, node),
+		})
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	buf := bufpool.GetBuf()
+	defer bufpool.PutBuf(buf)
+
+	res := decorator.NewRestorer()
+	af, err := res.RestoreFile(data.DstFile)
+	if err != nil {
+		return fmt.Errorf("converting dst to ast for %q: %w", data.Filename, err)
+	}
+	if err := astutil.PrintFileHeader(buf, af, res.Fset); err != nil {
+		return fmt.Errorf("%q: %w", data.Filename, err)
+	}
+
+	printf, flush := astutil.BufPrintf(buf)
+	printf("import (\n\"testing\"\n\nclonetest %q\n)\n\n", clonetestPkgPath)
+
+	var skips strings.Builder
+	for _, t := range targets {
+		if len(t.skipped) == 0 {
+			continue
+		}
+		quoted := make([]string, len(t.skipped))
+		for i, f := range t.skipped {
+			quoted[i] = strconv.Quote(f)
+		}
+		fmt.Fprintf(&skips, "clonetest.Skip[%s](%s)\n", t.typeName, strings.Join(quoted, ", "))
+	}
+	if skips.Len() > 0 {
+		printf("func init() {\n%s}\n\n", strings.ReplaceAll(skips.String(), "%", "%%"))
+	}
+
+	for _, t := range targets {
+		printf(
+			`func Fuzz%[2]s_%[1]s(f *testing.F) {
+	clonetest.Fuzz(f, func(v %[1]s) %[1]s { return v.%[2]s() })
+}
+
+func Benchmark%[2]s_%[1]s(b *testing.B) {
+	clonetest.Benchmark(b, func(v %[1]s) %[1]s { return v.%[2]s() })
+}
+
+`,
+			t.typeName, t.method,
+		)
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	return w.Write(ctx, testFilename(data.Filename), bytes.Clone(buf.Bytes()))
+}
+
+// testFilename returns the name from which w writes the test file along the file generated for name.
+func testFilename(name string) string {
+	base, _ := strings.CutSuffix(name, ".go")
+	return base + "_test.go"
+}
+
+// skippedFields returns names of fields generated clone methods leave zero value.
+func skippedFields(c *Config, g *typegraph.Graph, data *typegraph.ReplaceData, node *typegraph.Node) []string {
+	st, ok := node.Type.Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+	edges := node.ChildEdgeMap(c.MatcherConfig.MatchEdge)
+	var skipped []string
+	for i, f := range pkgsutil.EnumerateFields(st) {
+		edge, _, _, _ := edges.ByFieldPos(i)
+		_, _, kind, _ := c.matcherConfig().handleField(i, node, edge.ChildNode, g, f.Type())
+		if kind == handleKindIgnore {
+			skipped = append(skipped, f.Name())
+			continue
+		}
+		_, _, err := cloneTy(c, node.Type.Obj().Pkg().Path(), data.ImportMap, g, node, edge.ChildNode, i, f.Type(), nil)
+		if err != nil {
+			skipped = append(skipped, f.Name())
+		}
+	}
+	return skipped
+}
@@ -0,0 +1,202 @@
+package cloner
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"github.com/ngicks/go-codegen/codegen/pkg/directive"
+	"github.com/ngicks/go-codegen/codegen/pkg/pkgsutil"
+	"github.com/ngicks/go-codegen/codegen/pkg/typegraph"
+	"golang.org/x/tools/go/packages"
+)
+
+// Directives placed on type declarations.
+const (
+	// forces generation for a struct type even if no field needs cloning.
+	DirectiveCommentGenerate = "generate"
+	// generated methods have pointer receivers.
+	DirectiveCommentPointer = "ptr"
+	// name=Copy renames Clone to Copy, CloneFunc to CopyFunc, and so on.
+	DirectiveCommentName = "name"
+	// no-copy=, chan=, func=, interface= and atomic= override handles of MatcherConfig for fields of the type.
+	DirectiveCommentNoCopy    = "no-copy"
+	DirectiveCommentChan      = "chan"
+	DirectiveCommentFunc      = "func"
+	DirectiveCommentInterface = "interface"
+	DirectiveCommentAtomic    = "atomic"
+)
+
+var copyHandleNames = map[string]CopyHandle{
+	"ignore":   CopyHandleIgnore,
+	"disallow": CopyHandleDisallow,
+	"copy":     CopyHandleCopyPointer,
+	"make":     CopyHandleMake,
+	"clone":    CopyHandleClone,
+}
+
+// typeDirection is directives placed on a type declaration.
+type typeDirection struct {
+	Generate bool
+	Pointer  bool
+	// Name is the base name of generated clone methods. Empty means "Clone".
+	Name            string
+	NoCopyHandle    CopyHandle
+	ChannelHandle   CopyHandle
+	FuncHandle      CopyHandle
+	InterfaceHandle CopyHandle
+	AtomicHandle    CopyHandle
+}
+
+// override overrides handles of c which are set in d.
+func (d typeDirection) override(c MatcherConfig) MatcherConfig {
+	for _, h := range []struct {
+		src CopyHandle
+		dst *CopyHandle
+	}{
+		{d.NoCopyHandle, &c.NoCopyHandle},
+		{d.ChannelHandle, &c.ChannelHandle},
+		{d.FuncHandle, &c.FuncHandle},
+		{d.InterfaceHandle, &c.InterfaceHandle},
+		{d.AtomicHandle, &c.AtomicHandle},
+	} {
+		if h.src != copyHandleInvalid {
+			*h.dst = h.src
+		}
+	}
+	return c
+}
+
+// parseTypeDirections parses type directives of every type declared in pkgs.
+// Types without directives are not contained in the returned map.
+func parseTypeDirections(pkgs []*packages.Package) (map[*types.TypeName]typeDirection, error) {
+	directions := map[*types.TypeName]typeDirection{}
+	for pkg, fileSeq := range pkgsutil.EnumerateGenDecls(pkgs) {
+		for file, seq := range fileSeq {
+			for genDecl := range seq {
+				if genDecl.Tok != token.TYPE {
+					continue
+				}
+				for _, s := range genDecl.Specs {
+					ts := s.(*ast.TypeSpec)
+					obj, ok := pkg.TypesInfo.Defs[ts.Name].(*types.TypeName)
+					if !ok {
+						continue
+					}
+					d, err := parseTypeDirection(file, ts, obj.Type())
+					if err != nil {
+						return nil, fmt.Errorf("parsing %q.%s: %w", pkg.PkgPath, obj.Name(), err)
+					}
+					if d != (typeDirection{}) {
+						directions[obj] = d
+					}
+				}
+			}
+		}
+	}
+	return directions, nil
+}
+
+// parseTypeDirection parses //cloner: directives in the doc comment of the type declaration ts of ty in file.
+func parseTypeDirection(file *ast.File, ts *ast.TypeSpec, ty types.Type) (typeDirection, error) {
+	var genDecl *ast.GenDecl
+	for _, decl := range file.Decls {
+		if g, ok := decl.(*ast.GenDecl); ok && slices.Contains(g.Specs, ast.Spec(ts)) {
+			genDecl = g
+			break
+		}
+	}
+	d, _, err := directive.ParseTypeDirectiveComment(
+		DirectivePrefix,
+		genDecl,
+		ts,
+		func(lines []string) (typeDirection, error) {
+			var parsed typeDirection
+			for _, line := range lines {
+				for _, directive := range strings.Split(line, ",") {
+					key, value, hasValue := strings.Cut(directive, "=")
+					var allowed []string
+					var dst *CopyHandle
+					switch key {
+					case DirectiveCommentGenerate:
+						parsed.Generate = true
+						continue
+					case DirectiveCommentPointer:
+						parsed.Pointer = true
+						continue
+					case DirectiveCommentName:
+						if !token.IsIdentifier(value) {
+							return parsed, fmt.Errorf("%s: invalid method name %q", DirectiveCommentName, value)
+						}
+						parsed.Name = value
+						continue
+					case DirectiveCommentNoCopy:
+						allowed, dst = []string{"ignore", "disallow", "copy"}, &parsed.NoCopyHandle
+					case DirectiveCommentChan:
+						allowed, dst = []string{"ignore", "disallow", "copy", "make"}, &parsed.ChannelHandle
+					case DirectiveCommentFunc:
+						allowed, dst = []string{"ignore", "disallow", "copy"}, &parsed.FuncHandle
+					case DirectiveCommentInterface:
+						allowed, dst = []string{"ignore", "copy", "clone"}, &parsed.InterfaceHandle
+					case DirectiveCommentAtomic:
+						allowed, dst = []string{"ignore", "disallow", "copy", "clone"}, &parsed.AtomicHandle
+					default:
+						return parsed, fmt.Errorf("%w: %q", ErrUnknownDirective, directive)
+					}
+					if !hasValue || !slices.Contains(allowed, value) {
+						return parsed, fmt.Errorf("%s: must be one of %v but is %q", key, allowed, value)
+					}
+					*dst = copyHandleNames[value]
+				}
+			}
+			return parsed, nil
+		},
+	)
+	if err != nil {
+		return d, err
+	}
+	if _, ok := ty.Underlying().(*types.Struct); d.Generate && !ok {
+		return d, fmt.Errorf("%s: only struct types are allowed", DirectiveCommentGenerate)
+	}
+	return d, nil
+}
+
+func typeDirectionOf(node *typegraph.Node) typeDirection {
+	if node == nil {
+		return typeDirection{}
+	}
+	priv, _ := node.Priv.(clonerPriv)
+	return priv.typeDirection
+}
+
+// cloneMethod returns the base name of clone methods of ty, e.g. "Clone" for Clone, CloneFunc, CloneWithMemo and so on.
+// It differs from "Clone" only if ty is a generation target renamed by the name directive.
+func cloneMethod(g *typegraph.Graph, ty types.Type) string {
+	if named, ok := types.Unalias(ty).(*types.Named); ok {
+		if n, ok := g.GetByType(named); ok && n.Matched&^typegraph.MatchKindExternal > 0 {
+			if name := typeDirectionOf(n).Name; name != "" {
+				return name
+			}
+		}
+	}
+	return "Clone"
+}
+
+// receiver returns the receiver of methods generated for node, e.g. "v T" or "v *T".
+func receiver(node *typegraph.Node, typeName string) string {
+	if typeDirectionOf(node).Pointer {
+		return "v *" + typeName
+	}
+	return "v " + typeName
+}
+
+// receiverValue returns the expression of the receiver value of methods generated for node.
+func receiverValue(node *typegraph.Node) string {
+	if typeDirectionOf(node).Pointer {
+		return "(*v)"
+	}
+	return "v"
+}
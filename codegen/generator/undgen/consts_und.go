@@ -46,6 +46,14 @@ var ConstUnd = ConstSet{
 			Import: imports.Import{Path: "github.com/ngicks/und/conversion", Name: "conversion"},
 			Types:  []string{"Empty"},
 		},
+		{
+			Import: imports.Import{Path: "github.com/ngicks/go-codegen/pkg/undgen/runtime/jsonpatch", Name: "jsonpatch"},
+			Types:  []string{"Operation"},
+		},
+		{
+			Import: imports.Import{Path: "github.com/ngicks/go-codegen/pkg/undgen/runtime/patchpolicy", Name: "patchpolicy"},
+			Types:  []string{},
+		},
 	},
 	ConversionMethod: typematcher.CyclicConversionMethods{
 		Reverse: "UndRaw",
@@ -91,7 +99,9 @@ var (
 )
 
 const (
-	UndPathConversion = "github.com/ngicks/und/conversion"
-	UndPathUndTag     = "github.com/ngicks/und/undtag"
-	UndPathValidate   = "github.com/ngicks/und/validate"
+	UndPathConversion  = "github.com/ngicks/und/conversion"
+	UndPathUndTag      = "github.com/ngicks/und/undtag"
+	UndPathValidate    = "github.com/ngicks/und/validate"
+	UndPathJSONPatch   = "github.com/ngicks/go-codegen/pkg/undgen/runtime/jsonpatch"
+	UndPathPatchPolicy = "github.com/ngicks/go-codegen/pkg/undgen/runtime/patchpolicy"
 )
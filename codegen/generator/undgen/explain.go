@@ -0,0 +1,119 @@
+package undgen
+
+import (
+	"go/types"
+	"reflect"
+
+	"github.com/ngicks/go-codegen/codegen/pkg/genreport"
+	"github.com/ngicks/go-codegen/codegen/pkg/pkgsutil"
+	"github.com/ngicks/go-codegen/codegen/pkg/typegraph"
+	"github.com/ngicks/und/undtag"
+	"golang.org/x/tools/go/packages"
+)
+
+// ExplainPlain builds the type graph of pkgs in the same way as GeneratePlain does
+// and explains why the type identified by ident is, or is not, a target of GeneratePlain.
+func ExplainPlain(pkgs []*packages.Package, ident typegraph.Ident) (*genreport.Explanation, error) {
+	graph, err := PlainGraph(pkgs)
+	if err != nil {
+		return nil, err
+	}
+	return genreport.Explain(
+		graph,
+		ident,
+		isUndPlainAllowedEdge,
+		rejectionOf(ConstUnd.ConversionMethod.Convert),
+		explainUndFields(isUndPlainAllowedEdge, isUndConversionImplementor, ConstUnd.ConversionMethod.Convert),
+	)
+}
+
+// ExplainValidator builds the type graph of pkgs in the same way as GenerateValidator does
+// and explains why the type identified by ident is, or is not, a target of GenerateValidator.
+func ExplainValidator(pkgs []*packages.Package, ident typegraph.Ident) (*genreport.Explanation, error) {
+	graph, err := ValidatorGraph(pkgs)
+	if err != nil {
+		return nil, err
+	}
+	return genreport.Explain(
+		graph,
+		ident,
+		isUndValidatorAllowedEdge,
+		rejectionOf(ConstUnd.ValidatorMethod.Name),
+		explainUndFields(isUndValidatorAllowedEdge, isUndValidatorImplementor, ConstUnd.ValidatorMethod.Name),
+	)
+}
+
+// explainUndFields returns a function that describes how each field, or the element, of a node is treated.
+func explainUndFields(
+	edgeFilter func(edge typegraph.Edge) bool,
+	implementorOf func(named *types.Named) bool,
+	method string,
+) func(node *typegraph.Node) []genreport.Field {
+	return func(node *typegraph.Node) []genreport.Field {
+		qualifier := types.RelativeTo(node.Type.Obj().Pkg())
+		edges := node.ChildEdgeMap(edgeFilter)
+
+		// explain finds the first named type in ty that decides how the field is treated.
+		explain := func(i int, name string, ty types.Type, tagged bool) genreport.Field {
+			f := genreport.Field{
+				Index:    i,
+				Name:     name,
+				Type:     types.TypeString(ty, qualifier),
+				Route:    []string{},
+				Decision: "ignored: neither tagged with `und` nor of a type implementing " + method,
+			}
+			var found bool
+			_ = typegraph.TraverseToNamed(
+				ty,
+				func(named *types.Named, stack []typegraph.EdgeRouteNode) error {
+					if found || !isUndAllowedPointer(named, stack) {
+						return nil
+					}
+					switch {
+					case tagged && isUndType(named):
+						f.Decision = "und type tagged with `und`"
+					case !isUndType(named) && implementorOf(named):
+						f.Decision = "implements " + method
+					case isUndType(named) && named.TypeArgs().Len() == 1 && isImplementorArg(named, implementorOf):
+						f.Decision = "und type wrapping a type implementing " + method
+					default:
+						return nil
+					}
+					found = true
+					for _, r := range stack {
+						f.Route = append(f.Route, r.Kind.String())
+					}
+					return nil
+				},
+				nil,
+			)
+			if !found && i >= 0 {
+				if edge, _, _, ok := edges.ByFieldPos(i); ok {
+					f.Decision = "refers to a target type " + edge.ChildType.String()
+					for _, r := range edge.Stack[1:] {
+						f.Route = append(f.Route, r.Kind.String())
+					}
+				}
+			}
+			return f
+		}
+
+		switch x := node.Type.Underlying().(type) {
+		case *types.Struct:
+			var fields []genreport.Field
+			for i, v := range pkgsutil.EnumerateFields(x) {
+				_, tagged := reflect.StructTag(x.Tag(i)).Lookup(undtag.TagName)
+				fields = append(fields, explain(i, v.Name(), v.Type(), tagged))
+			}
+			return fields
+		case *types.Array, *types.Slice, *types.Map:
+			return []genreport.Field{explain(-1, "", x, false)}
+		}
+		return nil
+	}
+}
+
+func isImplementorArg(named *types.Named, implementorOf func(named *types.Named) bool) bool {
+	arg, ok := named.TypeArgs().At(0).(*types.Named)
+	return ok && !isUndType(arg) && implementorOf(arg)
+}
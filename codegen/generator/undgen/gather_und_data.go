@@ -5,6 +5,7 @@ import (
 	"iter"
 
 	"github.com/ngicks/go-codegen/codegen/pkg/directive"
+	"github.com/ngicks/go-codegen/codegen/pkg/genreport"
 	"github.com/ngicks/go-codegen/codegen/pkg/imports"
 	"github.com/ngicks/go-codegen/codegen/pkg/typegraph"
 	"golang.org/x/tools/go/packages"
@@ -15,19 +16,13 @@ func gatherPlainUndTypes(
 	parser *imports.ImportParser,
 	edgeFilter func(edge typegraph.Edge) bool,
 	seqFactory func(g *typegraph.Graph) iter.Seq2[typegraph.Ident, *typegraph.Node],
+	report *genreport.Run,
 ) (data map[*ast.File]*typegraph.ReplaceData, err error) {
-	graph, err := typegraph.New(
-		pkgs,
-		isUndPlainTarget,
-		directive.ExcludeIgnoredGenDecl,
-		directive.ExcludeIgnoredTypeSpec,
-	)
+	graph, err := undGraph(pkgs, isUndPlainTarget, edgeFilter)
 	if err != nil {
 		return nil, err
 	}
-	if edgeFilter != nil {
-		graph.MarkDependant(edgeFilter)
-	}
+	report.AddGraph(graph, edgeFilter, rejectionOf(ConstUnd.ConversionMethod.Convert))
 	return graph.GatherReplaceData(parser, seqFactory)
 }
 
@@ -36,10 +31,38 @@ func gatherValidatableUndTypes(
 	parser *imports.ImportParser,
 	edgeFilter func(edge typegraph.Edge) bool,
 	seqFactory func(g *typegraph.Graph) iter.Seq2[typegraph.Ident, *typegraph.Node],
+	report *genreport.Run,
 ) (data map[*ast.File]*typegraph.ReplaceData, err error) {
+	graph, err := undGraph(pkgs, isUndValidatorTarget, edgeFilter)
+	if err != nil {
+		return nil, err
+	}
+	report.AddGraph(graph, edgeFilter, rejectionOf(ConstUnd.ValidatorMethod.Name))
+	return graph.GatherReplaceData(parser, seqFactory)
+}
+
+// PlainGraph builds the type graph of pkgs in the same way as GeneratePlain does,
+// with matched and dependant types marked.
+func PlainGraph(pkgs []*packages.Package) (*typegraph.Graph, error) {
+	return undGraph(pkgs, isUndPlainTarget, isUndPlainAllowedEdge)
+}
+
+// ValidatorGraph builds the type graph of pkgs in the same way as GenerateValidator does,
+// with matched and dependant types marked.
+func ValidatorGraph(pkgs []*packages.Package) (*typegraph.Graph, error) {
+	return undGraph(pkgs, isUndValidatorTarget, isUndValidatorAllowedEdge)
+}
+
+// undGraph builds the type graph of pkgs.
+// If edgeFilter is non nil, dependant types are marked by following edges it allows.
+func undGraph(
+	pkgs []*packages.Package,
+	matcher func(node *typegraph.Node, external bool) (bool, error),
+	edgeFilter func(edge typegraph.Edge) bool,
+) (*typegraph.Graph, error) {
 	graph, err := typegraph.New(
 		pkgs,
-		isUndValidatorTarget,
+		matcher,
 		directive.ExcludeIgnoredGenDecl,
 		directive.ExcludeIgnoredTypeSpec,
 	)
@@ -49,5 +72,5 @@ func gatherValidatableUndTypes(
 	if edgeFilter != nil {
 		graph.MarkDependant(edgeFilter)
 	}
-	return graph.GatherReplaceData(parser, seqFactory)
+	return graph, nil
 }
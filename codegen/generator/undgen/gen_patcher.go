@@ -18,10 +18,12 @@ import (
 	"github.com/dave/dst"
 	"github.com/dave/dst/decorator"
 	"github.com/dave/dst/dstutil"
+	"github.com/ngicks/go-codegen/codegen/internal/bufpool"
+	"github.com/ngicks/go-codegen/codegen/internal/workpool"
 	"github.com/ngicks/go-codegen/codegen/pkg/astutil"
 	"github.com/ngicks/go-codegen/codegen/pkg/directive"
 	"github.com/ngicks/go-codegen/codegen/pkg/imports"
-	"github.com/ngicks/go-codegen/codegen/internal/bufpool"
+	"github.com/ngicks/go-codegen/codegen/pkg/pkgsutil"
 	"github.com/ngicks/go-codegen/codegen/pkg/structtag"
 	"github.com/ngicks/go-codegen/codegen/pkg/suffixwriter"
 	"github.com/ngicks/go-codegen/codegen/pkg/typegraph"
@@ -30,13 +32,28 @@ import (
 	"golang.org/x/tools/go/packages"
 )
 
+// GeneratePatcher generates patch types for types named by targetTypeNames, found in pkgs.
+//
+// A name is either a bare type name, which matches types of that name in every package of pkgs,
+// or a name qualified by its package path, e.g. example.com/foo/bar.Baz, which matches only in that package.
+// A single "..." generates patches for every type in pkgs.
+// Names matching no type are ignored.
+//
+// With [WithDeepPatch], fields whose type is a struct type also targeted in the same call
+// are patched by the patch type of the field type instead of being replaced as a whole.
+//
+// Fields may have policies in patch:"..." struct tags.
+// patch:"-" omits the field from the patch type, patch:"nonnull" generates UndValidate which rejects null
+// and patch:"readonly" generates CheckReadOnly and ApplyPatchChecked, which returns an error if the field is set.
 func GeneratePatcher(
 	sourcePrinter *suffixwriter.Writer,
 	verbose bool,
-	pkg *packages.Package,
+	pkgs []*packages.Package,
 	extra []imports.TargetImport,
-	targetTypeNames ...string,
+	targetTypeNames []string,
+	opts ...Option,
 ) error {
+	o := newOptions(opts)
 	if verbose {
 		slog.Debug(
 			"target type names",
@@ -49,126 +66,212 @@ func GeneratePatcher(
 		generateEvery = true
 	}
 
-	parser := imports.NewParserPackages([]*packages.Package{pkg})
+	parser := imports.NewParserPackages(pkgs)
 	parser.AppendExtra(extra...)
+	// UndValidate generated for patch:"nonnull" uses fmt.Errorf.
+	parser.AppendExtra(imports.TargetImport{Import: imports.Import{Path: "fmt", Name: "fmt"}})
+	if o.diff {
+		parser.AppendExtra(imports.TargetImport{Import: imports.Import{Path: "reflect", Name: "reflect"}})
+	}
 	replacerData, err := gatherPlainUndTypes(
-		[]*packages.Package{pkg},
+		pkgs,
 		parser,
 		nil, // no dependant type marking; it is not needed here.
 		func(g *typegraph.Graph) iter.Seq2[typegraph.Ident, *typegraph.Node] {
 			if generateEvery {
 				return g.EnumerateTypes()
 			}
-			return g.EnumerateTypesKeys(
-				hiter.Map(func(s string) typegraph.Ident {
-					return typegraph.Ident{PkgPath: pkg.PkgPath, TypeName: s}
-				},
-					slices.Values(targetTypeNames),
-				),
-			)
+			return g.EnumerateTypesKeys(slices.Values(patchTargetIdents(pkgs, targetTypeNames)))
 		},
+		o.report,
 	)
 	if err != nil {
 		return err
 	}
+	if err := validatePatchPolicies(pkgs, replacerData); err != nil {
+		return err
+	}
 
-	buf := bufpool.GetBuf()
-	defer bufpool.PutBuf(buf)
-
-	for _, data := range hiter.Filter2(
-		func(f *ast.File, data *typegraph.ReplaceData) bool { return f != nil && data != nil },
-		hiter.MapsKeys(replacerData, slices.Values(pkg.Syntax)),
-	) {
-		buf.Reset()
-
-		wrapNonUndFields(data)
-
-		if verbose {
-			slog.Debug(
-				"found",
-				slog.String("filename", data.Filename),
-				slog.Any(
-					"typesNames",
-					slices.Collect(hiter.Map(
-						func(n *typegraph.Node) string { return n.Type.Obj().Name() },
-						slices.Values(data.TargetNodes),
-					)),
-				),
-			)
-		}
-
-		data.ImportMap.AddMissingImports(data.DstFile)
-		res := decorator.NewRestorer()
-		af, err := res.RestoreFile(data.DstFile)
-		if err != nil {
-			return fmt.Errorf("converting dst to ast for %q: %w", data.Filename, err)
+	var targets patchTargets
+	if o.deep {
+		targets = make(patchTargets)
+		for _, data := range replacerData {
+			for _, node := range data.TargetNodes {
+				targets[typegraph.IdentFromTypesObject(node.Type.Obj())] = true
+			}
 		}
+	}
 
-		if err := astutil.PrintFileHeader(buf, af, res.Fset); err != nil {
-			return fmt.Errorf("%q: %w", data.Filename, err)
-		}
+	return workpool.Run(
+		sourcePrinter.Jobs(),
+		slices.Collect(hiter.OmitF(hiter.Filter2(
+			func(f *ast.File, data *typegraph.ReplaceData) bool { return f != nil && data != nil },
+			hiter.MapsKeys(replacerData, pkgsutil.EnumerateFile(pkgs)),
+		))),
+		func(data *typegraph.ReplaceData) error {
+			buf := bufpool.GetBuf()
+			defer bufpool.PutBuf(buf)
+
+			wrapNonUndFields(data, targets)
+
+			if verbose {
+				slog.Debug(
+					"found",
+					slog.String("filename", data.Filename),
+					slog.Any(
+						"typesNames",
+						slices.Collect(hiter.Map(
+							func(n *typegraph.Node) string { return n.Type.Obj().Name() },
+							slices.Values(data.TargetNodes),
+						)),
+					),
+				)
+			}
 
-		for _, node := range data.TargetNodes {
-			dts := data.Dec.Dst.Nodes[node.Ts].(*dst.TypeSpec)
-			ts := res.Ast.Nodes[dts].(*ast.TypeSpec)
-			// type keyword is attached to *ast.GenDecl
-			// But we are not printing gen decl itself since
-			// it could have multiple specs inside it (type (spec1; spec2;...))
-			// surely at least a spec of them is converted but we can't tell all of them were.
-			buf.WriteString("//" + directive.DirectivePrefix + directive.DirectiveCommentGenerated + "\n")
-			buf.WriteString(token.TYPE.String())
-			buf.WriteByte(' ')
-			err = printer.Fprint(buf, res.Fset, ts)
+			// methods are printed after the import decl; imports must be recorded in advance.
+			// unused ones are removed when formatted.
+			if o.jsonPatch {
+				_, _ = data.ImportMap.Ident(UndPathJSONPatch)
+			}
+			if o.diff {
+				_, _ = data.ImportMap.Ident("reflect")
+			}
+			for _, path := range []string{"fmt", UndPathUndTag, UndPathValidate, UndPathPatchPolicy} {
+				_, _ = data.ImportMap.Ident(path)
+			}
+			data.ImportMap.AddMissingImports(data.DstFile)
+			res := decorator.NewRestorer()
+			af, err := res.RestoreFile(data.DstFile)
 			if err != nil {
-				return fmt.Errorf("print.Fprint failed for type %s in file %q: %w", data.Filename, ts.Name.Name, err)
+				return fmt.Errorf("converting dst to ast for %q: %w", data.Filename, err)
 			}
-			buf.WriteString("\n\n")
 
-			for _, gen := range []methodGenSet{
-				{
-					generateFromValue,
-					func() error {
-						return fmt.Errorf("generating FromValue for type %s in file %q: %w", data.Filename, ts.Name.Name, err)
+			if err := astutil.PrintFileHeader(buf, af, res.Fset); err != nil {
+				return fmt.Errorf("%q: %w", data.Filename, err)
+			}
+
+			for _, node := range data.TargetNodes {
+				dts := data.Dec.Dst.Nodes[node.Ts].(*dst.TypeSpec)
+				ts := res.Ast.Nodes[dts].(*ast.TypeSpec)
+				// type keyword is attached to *ast.GenDecl
+				// But we are not printing gen decl itself since
+				// it could have multiple specs inside it (type (spec1; spec2;...))
+				// surely at least a spec of them is converted but we can't tell all of them were.
+				buf.WriteString("//" + directive.DirectivePrefix + directive.DirectiveCommentGenerated + "\n")
+				buf.WriteString(token.TYPE.String())
+				buf.WriteByte(' ')
+				err = printer.Fprint(buf, res.Fset, ts)
+				if err != nil {
+					return fmt.Errorf("print.Fprint failed for type %s in file %q: %w", data.Filename, ts.Name.Name, err)
+				}
+				buf.WriteString("\n\n")
+
+				gens := []methodGenSet{
+					{
+						generateFromValue,
+						func() error {
+							return fmt.Errorf("generating FromValue for type %s in file %q: %w", data.Filename, ts.Name.Name, err)
+						},
 					},
-				},
-				{
-					generateToValue,
-					func() error {
-						return fmt.Errorf("generating ToValue for type %s in file %q: %w", data.Filename, ts.Name.Name, err)
+					{
+						generateToValue,
+						func() error {
+							return fmt.Errorf("generating ToValue for type %s in file %q: %w", data.Filename, ts.Name.Name, err)
+						},
 					},
-				},
-				{
-					generateMerge,
-					func() error {
-						return fmt.Errorf("generating Merge for type %s in file %q: %w", data.Filename, ts.Name.Name, err)
+					{
+						generateMerge,
+						func() error {
+							return fmt.Errorf("generating Merge for type %s in file %q: %w", data.Filename, ts.Name.Name, err)
+						},
 					},
-				},
-				{
-					generateApplyPatch,
-					func() error {
-						return fmt.Errorf("generating ApplyPatch for type %s in file %q: %w", data.Filename, ts.Name.Name, err)
+					{
+						generateApplyPatch,
+						func() error {
+							return fmt.Errorf("generating ApplyPatch for type %s in file %q: %w", data.Filename, ts.Name.Name, err)
+						},
+					},
+					{
+						generateCheckReadOnly,
+						func() error {
+							return fmt.Errorf("generating CheckReadOnly for type %s in file %q: %w", data.Filename, ts.Name.Name, err)
+						},
+					},
+					{
+						generatePatchUndValidate,
+						func() error {
+							return fmt.Errorf("generating UndValidate for type %s in file %q: %w", data.Filename, ts.Name.Name, err)
+						},
 					},
-				},
-			} {
-				err = gen.fn(
-					buf,
-					dts,
-					node,
-					data.ImportMap,
-					"Patch",
-				)
-				if err != nil {
-					return gen.errFunc()
+				}
+				if o.diff {
+					gens = append(
+						gens,
+						methodGenSet{
+							generateDiff,
+							func() error {
+								return fmt.Errorf("generating Diff for type %s in file %q: %w", data.Filename, ts.Name.Name, err)
+							},
+						},
+					)
+				}
+				if o.jsonPatch {
+					gens = append(
+						gens,
+						methodGenSet{
+							generateToJSONPatch,
+							func() error {
+								return fmt.Errorf("generating ToJSONPatch for type %s in file %q: %w", data.Filename, ts.Name.Name, err)
+							},
+						},
+						methodGenSet{
+							generateFromJSONPatch,
+							func() error {
+								return fmt.Errorf("generating FromJSONPatch for type %s in file %q: %w", data.Filename, ts.Name.Name, err)
+							},
+						},
+					)
+				}
+				for _, gen := range gens {
+					err = gen.fn(
+						buf,
+						dts,
+						node,
+						data.ImportMap,
+						targets,
+						"Patch",
+					)
+					if err != nil {
+						return gen.errFunc()
+					}
 				}
 			}
+			// Patches are generated only for types named by the caller; they are not cached.
+			return sourcePrinter.Write(context.Background(), data.Filename, buf.Bytes())
+		},
+	)
+}
+
+// patchTargetIdents resolves names to idents of types.
+// Bare names are expanded to each of pkgs. The result is sorted and has no duplicates.
+func patchTargetIdents(pkgs []*packages.Package, names []string) []typegraph.Ident {
+	var idents []typegraph.Ident
+	for _, name := range names {
+		if i := strings.LastIndex(name, "."); i >= 0 {
+			idents = append(idents, typegraph.Ident{PkgPath: name[:i], TypeName: name[i+1:]})
+			continue
 		}
-		err = sourcePrinter.Write(context.Background(), data.Filename, buf.Bytes())
-		if err != nil {
-			return err
+		for _, pkg := range pkgs {
+			idents = append(idents, typegraph.Ident{PkgPath: pkg.PkgPath, TypeName: name})
 		}
 	}
-
-	return nil
+	slices.SortFunc(idents, func(i, j typegraph.Ident) int {
+		if c := strings.Compare(i.PkgPath, j.PkgPath); c != 0 {
+			return c
+		}
+		return strings.Compare(i.TypeName, j.TypeName)
+	})
+	return slices.Compact(idents)
 }
 
 type methodGenSet struct {
@@ -176,15 +279,56 @@ type methodGenSet struct {
 	errFunc func() error
 }
 
-type methodGenFunc func(w io.Writer, ts *dst.TypeSpec, node *typegraph.Node, imports imports.ImportMap, typeSuffix string) error
+type methodGenFunc func(w io.Writer, ts *dst.TypeSpec, node *typegraph.Node, imports imports.ImportMap, targets patchTargets, typeSuffix string) error
+
+// patchTargets is the set of types whose patch types are generated.
+// It is nil unless deep patches are enabled.
+type patchTargets map[typegraph.Ident]bool
+
+// nested reports whether the field pointed by edge is patched by the patch type of the field type.
+// ok is whether edge is found for the field.
+//
+// Only direct fields of struct types are nested; pointers, slices or other composite types wrapping them are not.
+func (t patchTargets) nested(edge typegraph.Edge, ok bool) bool {
+	if !ok || len(t) == 0 {
+		return false
+	}
+	if _, isStruct := edge.ChildType.Underlying().(*types.Struct); !isStruct {
+		return false
+	}
+	return t[typegraph.IdentFromTypesObject(edge.ChildType.Obj())]
+}
+
+// nestedPatchType returns the type expression of the patch type of ty, e.g. pkg.FooPatch[T].
+func nestedPatchType(ty *types.Named, qualifier types.Qualifier, typeSuffix string) string {
+	s := types.TypeString(ty, qualifier)
+	if i := strings.IndexByte(s, '['); i >= 0 {
+		return s[:i] + typeSuffix + s[i:]
+	}
+	return s + typeSuffix
+}
 
-func wrapNonUndFields(data *typegraph.ReplaceData) {
+// appendTypeNameSuffix appends suffix to the name of the named type expr refers to.
+func appendTypeNameSuffix(expr dst.Expr, suffix string) {
+	switch x := expr.(type) {
+	case *dst.Ident:
+		x.Name += suffix
+	case *dst.SelectorExpr:
+		x.Sel.Name += suffix
+	case *dst.IndexExpr:
+		appendTypeNameSuffix(x.X, suffix)
+	case *dst.IndexListExpr:
+		appendTypeNameSuffix(x.X, suffix)
+	}
+}
+
+func wrapNonUndFields(data *typegraph.ReplaceData, targets patchTargets) {
 	for _, node := range data.TargetNodes {
-		wrapNonUndFieldsWithSliceUnd(data.Dec.Dst.Nodes[node.Ts].(*dst.TypeSpec), node, data.ImportMap)
+		wrapNonUndFieldsWithSliceUnd(data.Dec.Dst.Nodes[node.Ts].(*dst.TypeSpec), node, data.ImportMap, targets)
 	}
 }
 
-func wrapNonUndFieldsWithSliceUnd(ts *dst.TypeSpec, node *typegraph.Node, importMap imports.ImportMap) {
+func wrapNonUndFieldsWithSliceUnd(ts *dst.TypeSpec, node *typegraph.Node, importMap imports.ImportMap, targets patchTargets) {
 	typeName := ts.Name.Name
 	ts.Name.Name = ts.Name.Name + "Patch"
 	edgeMap := node.ChildEdgeMap(patcherEdgeFilter)
@@ -200,6 +344,14 @@ func wrapNonUndFieldsWithSliceUnd(ts *dst.TypeSpec, node *typegraph.Node, import
 					return false
 				}
 
+				if field.Tag != nil {
+					policy, _ := parsePatchPolicy(reflect.StructTag(unquoteBasicLitString(field.Tag.Value)))
+					if policy.omit {
+						c.Delete()
+						return false
+					}
+				}
+
 				edge, _, _, ok := edgeMap.ByFieldName(field.Names[0].Name)
 
 				if field.Tag == nil {
@@ -208,7 +360,23 @@ func wrapNonUndFieldsWithSliceUnd(ts *dst.TypeSpec, node *typegraph.Node, import
 				tag := field.Tag
 
 				isSliceType := true
-				if !ok || !matchUndType(
+				switch {
+				case targets.nested(edge, ok):
+					// T -> sliceund.Und[TPatch]
+					patchType := dst.Clone(field.Type).(dst.Expr)
+					appendTypeNameSuffix(patchType, "Patch")
+					c.Replace(
+						&dst.Field{
+							Names: field.Names,
+							Type: &dst.IndexExpr{
+								X:     importMap.DstExpr(UndTargetTypeSliceUnd),
+								Index: patchType,
+							},
+							Tag:  field.Tag,
+							Decs: field.Decs,
+						},
+					)
+				case !ok || !matchUndType(
 					namedTypeToTargetType(edge.ChildType),
 					false,
 					func() bool {
@@ -231,7 +399,7 @@ func wrapNonUndFieldsWithSliceUnd(ts *dst.TypeSpec, node *typegraph.Node, import
 						isSliceType = isSlice
 						return true
 					},
-				) {
+				):
 					c.Replace(
 						&dst.Field{
 							Names: field.Names,
@@ -323,7 +491,7 @@ func typeObjectFieldsIter(typeInfo types.Type) iter.Seq2[int, *types.Var] {
 //		}
 //	}
 func generateFromValue(
-	w io.Writer, ts *dst.TypeSpec, node *typegraph.Node, imports imports.ImportMap, typeSuffix string,
+	w io.Writer, ts *dst.TypeSpec, node *typegraph.Node, imports imports.ImportMap, targets patchTargets, typeSuffix string,
 ) (err error) {
 	patchTypeName := ts.Name.Name + astutil.PrintTypeParamsDst(ts)
 	orgTypeName := strings.TrimSuffix(ts.Name.Name, typeSuffix) + astutil.PrintTypeParamsDst(ts)
@@ -359,12 +527,26 @@ func generateFromValue(
 `)
 
 	edgeMap := node.ChildEdgeMap(patcherEdgeFilter)
-	for _, f := range typeObjectFieldsIter(node.Type) {
+	for i, f := range typeObjectFieldsIter(node.Type) {
+		if fieldPatchPolicy(node.Type, i).omit {
+			continue
+		}
 		// There's 3 possible conversions.
 		// T -> sliceund.Und[T]
 		// option.Option[T] -> sliceund.Und[T]
 		// conserve type other than that e.g. for und.Und, elastic.Elastic.
+		// With deep patches, T -> sliceund.Und[TPatch] is added.
 		edge, _, _, ok := edgeMap.ByFieldName(f.Name())
+		if targets.nested(edge, ok) {
+			sliceUndImportIdent, _ := imports.Ident(UndTargetTypeSliceUnd.ImportPath)
+			printf(
+				`%[1]s: %[2]s.Defined(func() (nested %[3]s) { nested.FromValue(v.%[1]s); return }()),
+`,
+				f.Name(), sliceUndImportIdent,
+				nestedPatchType(edge.ChildType, imports.Qualifier(node.Type.Obj().Pkg().Path()), typeSuffix),
+			)
+			continue
+		}
 		if !ok || !matchUndType(
 			namedTypeToTargetType(edge.ChildType),
 			false,
@@ -419,7 +601,7 @@ func generateFromValue(
 //		}
 //	}
 func generateToValue(
-	w io.Writer, ts *dst.TypeSpec, node *typegraph.Node, imports imports.ImportMap, typeSuffix string,
+	w io.Writer, ts *dst.TypeSpec, node *typegraph.Node, imports imports.ImportMap, targets patchTargets, typeSuffix string,
 ) (err error) {
 	patchTypeName := ts.Name.Name + astutil.PrintTypeParamsDst(ts)
 	orgTypeName := strings.TrimSuffix(ts.Name.Name, typeSuffix) + astutil.PrintTypeParamsDst(ts)
@@ -456,12 +638,24 @@ func generateToValue(
 	edgeMap := node.ChildEdgeMap(func(edge typegraph.Edge) bool {
 		return len(edge.Stack) == 1 && edge.Stack[0].Kind == typegraph.EdgeKindStruct
 	})
-	for _, f := range typeObjectFieldsIter(node.Type) {
+	for i, f := range typeObjectFieldsIter(node.Type) {
+		if fieldPatchPolicy(node.Type, i).omit {
+			continue
+		}
 		edge, _, _, ok := edgeMap.ByFieldName(f.Name())
 		// Like FromValue, there's 3 possible back-conversions.
 		// sliceund.Und[T] -> T
 		// sliceund.Und[T] -> option.Option[T]
 		// conserve type other than that e.g. for und.Und, elastic.Elastic.
+		// With deep patches, sliceund.Und[TPatch] -> T is added.
+		if targets.nested(edge, ok) {
+			printf(
+				`%[1]s: p.%[1]s.Value().ToValue(),
+`,
+				f.Name(),
+			)
+			continue
+		}
 		if !ok || !matchUndType(
 			namedTypeToTargetType(edge.ChildType),
 			false,
@@ -510,7 +704,7 @@ func generateToValue(
 //		}
 //	}
 func generateMerge(
-	w io.Writer, ts *dst.TypeSpec, node *typegraph.Node, imports imports.ImportMap, _ string,
+	w io.Writer, ts *dst.TypeSpec, node *typegraph.Node, imports imports.ImportMap, targets patchTargets, typeSuffix string,
 ) (err error) {
 	patchTypeName := ts.Name.Name + astutil.PrintTypeParamsDst(ts)
 
@@ -540,12 +734,30 @@ func generateMerge(
 	defer printf(`}
 `)
 	edgeMap := node.ChildEdgeMap(patcherEdgeFilter)
-	for _, f := range typeObjectFieldsIter(node.Type) {
+	for i, f := range typeObjectFieldsIter(node.Type) {
+		if fieldPatchPolicy(node.Type, i).omit {
+			continue
+		}
 		edge, _, _, ok := edgeMap.ByFieldName(f.Name())
 		// Like FromValue, there's 2 possible Or logic.
 		// both und like type.
 		// both elastic like type.
+		// With deep patches, nested patches are merged if both are defined.
 		undImportIdent, _ := imports.Ident(UndTargetTypeSliceUnd.ImportPath)
+		if targets.nested(edge, ok) {
+			printf(
+				`%[1]s: func() %[2]s.Und[%[3]s] {
+	if p.%[1]s.IsDefined() && r.%[1]s.IsDefined() {
+		return %[2]s.Defined(p.%[1]s.Value().Merge(r.%[1]s.Value()))
+	}
+	return %[2]s.FromOption(r.%[1]s.Unwrap().Or(p.%[1]s.Unwrap()))
+}(),
+`,
+				f.Name(), undImportIdent,
+				nestedPatchType(edge.ChildType, imports.Qualifier(node.Type.Obj().Pkg().Path()), typeSuffix),
+			)
+			continue
+		}
 		if !ok || !matchUndType(
 			namedTypeToTargetType(edge.ChildType),
 			false,
@@ -594,11 +806,24 @@ func generateMerge(
 //		merged := orgP.Merge(p)
 //		return merged.ToValue()
 //	}
+//
+// Fields omitted by patch:"-" are copied from v.
+// Fields nested by deep patches are applied by their ApplyPatch to also keep their omitted fields,
+// unless the nested patch is null, which resets the field to zero.
+// ApplyPatch does not check policies. If the type has read-only fields, a checked variant is also generated.
+//
+//	func (p Patch[T, U,...]) ApplyPatchChecked(v OrgType[T, U,...]) (OrgType[T, U,...], error) {
+//		if err := p.CheckReadOnly(); err != nil {
+//			return v, err
+//		}
+//		return p.ApplyPatch(v), nil
+//	}
 func generateApplyPatch(
-	w io.Writer, ts *dst.TypeSpec, _ *typegraph.Node, _ imports.ImportMap, typeSuffix string,
+	w io.Writer, ts *dst.TypeSpec, node *typegraph.Node, _ imports.ImportMap, targets patchTargets, typeSuffix string,
 ) (err error) {
 	patchTypeName := ts.Name.Name + astutil.PrintTypeParamsDst(ts)
 	orgTypeName := strings.TrimSuffix(ts.Name.Name, typeSuffix) + astutil.PrintTypeParamsDst(ts)
+	readOnly := hasReadOnly(node.Type, targets)
 
 	printf, flush := astutil.BufPrintf(w)
 	defer func() {
@@ -610,8 +835,9 @@ func generateApplyPatch(
 `,
 		directive.DirectivePrefix, directive.DirectiveCommentGenerated,
 	) // note this is generated method.
-	printf(
-		`func (p %[1]s) ApplyPatch(v %[2]s) %[2]s {
+	if !hasOmitted(node.Type, targets) {
+		printf(
+			`func (p %[1]s) ApplyPatch(v %[2]s) %[2]s {
 		var orgP %[1]s
 		orgP.FromValue(v)
 		merged := orgP.Merge(p)
@@ -619,6 +845,58 @@ func generateApplyPatch(
 	}
 
 `, patchTypeName, orgTypeName)
+	} else {
+		printf(
+			`func (p %[1]s) ApplyPatch(v %[2]s) %[2]s {
+	var orgP %[1]s
+	orgP.FromValue(v)
+	merged := orgP.Merge(p)
+	out := merged.ToValue()
+`,
+			patchTypeName, orgTypeName,
+		)
+		edgeMap := node.ChildEdgeMap(patcherEdgeFilter)
+		for i, f := range typeObjectFieldsIter(node.Type) {
+			if fieldPatchPolicy(node.Type, i).omit {
+				printf(`out.%[1]s = v.%[1]s
+`,
+					f.Name(),
+				)
+				continue
+			}
+			if f.Embedded() {
+				continue
+			}
+			if edge, _, _, ok := edgeMap.ByFieldName(f.Name()); targets.nested(edge, ok) && hasOmitted(edge.ChildType, targets) {
+				printf(`if !p.%[1]s.IsNull() {
+	out.%[1]s = p.%[1]s.Value().ApplyPatch(v.%[1]s)
+}
+`,
+					f.Name(),
+				)
+			}
+		}
+		printf(`return out
+}
+
+`,
+		)
+	}
+
+	if readOnly {
+		printf(
+			`//%[3]s%[4]s
+func (p %[1]s) ApplyPatchChecked(v %[2]s) (%[2]s, error) {
+	if err := p.CheckReadOnly(); err != nil {
+		return v, err
+	}
+	return p.ApplyPatch(v), nil
+}
+
+`,
+			patchTypeName, orgTypeName, directive.DirectivePrefix, directive.DirectiveCommentGenerated,
+		)
+	}
 
 	return
 }
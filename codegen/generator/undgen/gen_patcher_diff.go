@@ -0,0 +1,173 @@
+package undgen
+
+import (
+	"go/types"
+	"io"
+	"strings"
+
+	"github.com/dave/dst"
+	"github.com/ngicks/go-codegen/codegen/pkg/astutil"
+	"github.com/ngicks/go-codegen/codegen/pkg/directive"
+	"github.com/ngicks/go-codegen/codegen/pkg/imports"
+	"github.com/ngicks/go-codegen/codegen/pkg/typegraph"
+)
+
+// generates a function which computes the patch between 2 values
+//
+//	func DiffOrgType[T, U,...](before, after OrgType[T, U,...]) Patch[T, U,...] {
+//		var p, full Patch[T, U,...]
+//		full.FromValue(after)
+//		if before.field != after.field {
+//			p.field = full.field
+//		}
+//		// ...
+//		return p
+//	}
+//
+// Fields are compared by Equal methods if they have one whose signature is func(T) bool,
+// by == if values can be compared without panicking, or by reflect.DeepEqual otherwise.
+// Fields of deep patches are set to the diff of nested values.
+// Embedded fields are compared like other fields.
+// Fields omitted by patch:"-" are not compared.
+// Fields tagged with patch:"readonly" are compared and set if they differ,
+// so that CheckReadOnly and ApplyPatchChecked report the change instead of losing it silently.
+func generateDiff(
+	w io.Writer, ts *dst.TypeSpec, node *typegraph.Node, imports imports.ImportMap, targets patchTargets, typeSuffix string,
+) (err error) {
+	orgName := strings.TrimSuffix(ts.Name.Name, typeSuffix)
+	patchTypeName := ts.Name.Name + astutil.PrintTypeParamsDst(ts)
+	orgTypeName := orgName + astutil.PrintTypeParamsDst(ts)
+	qualifier := imports.Qualifier(node.Type.Obj().Pkg().Path())
+
+	printf, flush := astutil.BufPrintf(w)
+	defer func() {
+		err = flush()
+	}()
+
+	printf(
+		`//%s%s
+`,
+		directive.DirectivePrefix, directive.DirectiveCommentGenerated,
+	)
+	printf(
+		`func Diff%s%s(before, after %s) %s {
+`,
+		orgName, typeParamsDecl(node.Type, qualifier), orgTypeName, patchTypeName,
+	)
+	defer printf(`}
+
+`)
+
+	var (
+		body strings.Builder
+		full bool
+	)
+	edgeMap := node.ChildEdgeMap(patcherEdgeFilter)
+	for i, f := range typeObjectFieldsIter(node.Type) {
+		if fieldPatchPolicy(node.Type, i).omit {
+			continue
+		}
+		body.WriteString("if " + diffNotEqual(f.Type(), "before."+f.Name(), "after."+f.Name(), imports) + " {\n")
+		if edge, _, _, ok := edgeMap.ByFieldName(f.Name()); targets.nested(edge, ok) {
+			sliceUndImportIdent, _ := imports.Ident(UndTargetTypeSliceUnd.ImportPath)
+			diffFunc := "Diff" + edge.ChildType.Obj().Name()
+			if q := qualifier(edge.ChildType.Obj().Pkg()); q != "" {
+				diffFunc = q + "." + diffFunc
+			}
+			body.WriteString(
+				"p." + f.Name() + " = " + sliceUndImportIdent +
+					".Defined(" + diffFunc + "(before." + f.Name() + ", after." + f.Name() + "))\n",
+			)
+		} else {
+			full = true
+			body.WriteString("p." + f.Name() + " = full." + f.Name() + "\n")
+		}
+		body.WriteString("}\n")
+	}
+
+	if full {
+		printf(`var p, full %[1]s
+full.FromValue(after)
+`,
+			patchTypeName,
+		)
+	} else {
+		printf(`var p %s
+`,
+			patchTypeName,
+		)
+	}
+	printf("%s", body.String())
+	printf(`return p
+`)
+	return
+}
+
+// typeParamsDecl returns the type parameter list of ty with constraints, e.g. [T any, U comparable].
+// It returns an empty string if ty has no type parameter.
+func typeParamsDecl(ty *types.Named, qualifier types.Qualifier) string {
+	tps := ty.TypeParams()
+	if tps.Len() == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteByte('[')
+	for i := range tps.Len() {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		tp := tps.At(i)
+		b.WriteString(tp.Obj().Name() + " " + types.TypeString(tp.Constraint(), qualifier))
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+// diffNotEqual returns an expression which reports whether x and y, both of type ty, are not equal.
+func diffNotEqual(ty types.Type, x, y string, imports imports.ImportMap) string {
+	switch {
+	case hasEqualMethod(ty):
+		return "!" + x + ".Equal(" + y + ")"
+	case comparableWithoutPanic(ty):
+		return x + " != " + y
+	}
+	reflectIdent, _ := imports.Ident("reflect")
+	return "!" + reflectIdent + ".DeepEqual(" + x + ", " + y + ")"
+}
+
+// hasEqualMethod reports whether ty has the method Equal(ty) bool.
+// Pointer methods are included since fields compared are always addressable.
+func hasEqualMethod(ty types.Type) bool {
+	obj, _, _ := types.LookupFieldOrMethod(ty, true, nil, "Equal")
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+	sig := fn.Type().(*types.Signature)
+	return sig.Params().Len() == 1 &&
+		types.Identical(sig.Params().At(0).Type(), ty) &&
+		sig.Results().Len() == 1 &&
+		types.Identical(sig.Results().At(0).Type(), types.Typ[types.Bool])
+}
+
+// comparableWithoutPanic reports whether values of ty can be compared by == and it never panics.
+// Interfaces, type parameters and types containing them are not.
+func comparableWithoutPanic(ty types.Type) bool {
+	if _, ok := ty.(*types.TypeParam); ok {
+		return false
+	}
+	switch x := ty.Underlying().(type) {
+	case *types.Basic, *types.Pointer, *types.Chan:
+		return true
+	case *types.Array:
+		return comparableWithoutPanic(x.Elem())
+	case *types.Struct:
+		for i := range x.NumFields() {
+			if !comparableWithoutPanic(x.Field(i).Type()) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
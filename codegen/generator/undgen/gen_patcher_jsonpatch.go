@@ -0,0 +1,240 @@
+package undgen
+
+import (
+	"go/types"
+	"io"
+	"iter"
+	"strconv"
+
+	"github.com/dave/dst"
+	"github.com/ngicks/go-codegen/codegen/pkg/astutil"
+	"github.com/ngicks/go-codegen/codegen/pkg/directive"
+	"github.com/ngicks/go-codegen/codegen/pkg/imports"
+	"github.com/ngicks/go-codegen/codegen/pkg/typegraph"
+)
+
+// jsonPatchField is a field of a patch type which appears in JSON documents.
+type jsonPatchField struct {
+	field *types.Var
+	name  string
+	// nested is non-nil if the field is patched by the patch type of its type.
+	nested *types.Named
+}
+
+// jsonPatchFields enumerates fields of node which appear in JSON documents.
+// Embedded and unexported fields, and fields tagged with json:"-" or patch:"-" are skipped.
+func jsonPatchFields(node *typegraph.Node, targets patchTargets) iter.Seq[jsonPatchField] {
+	return func(yield func(jsonPatchField) bool) {
+		st, ok := node.Type.Underlying().(*types.Struct)
+		if !ok {
+			return
+		}
+		edgeMap := node.ChildEdgeMap(patcherEdgeFilter)
+		for i := range st.NumFields() {
+			f := st.Field(i)
+			if f.Embedded() || !f.Exported() || fieldPatchPolicy(node.Type, i).omit {
+				continue
+			}
+			name := fieldJsonName(st, i)
+			switch name {
+			case "-":
+				continue
+			case "":
+				name = f.Name()
+			}
+			jf := jsonPatchField{field: f, name: name}
+			if edge, _, _, ok := edgeMap.ByFieldName(f.Name()); targets.nested(edge, ok) {
+				jf.nested = edge.ChildType
+			}
+			if !yield(jf) {
+				return
+			}
+		}
+	}
+}
+
+// generates methods on the patch type
+//
+//	func (p Patch[T, U,...]) ToJSONPatch() ([]jsonpatch.Operation, error) {
+//		var (
+//			ops []jsonpatch.Operation
+//			err error
+//		)
+//		if ops, err = jsonpatch.AppendField(ops, "field", p.Field); err != nil {
+//			return nil, err
+//		}
+//		// ...
+//		return ops, nil
+//	}
+func generateToJSONPatch(
+	w io.Writer, ts *dst.TypeSpec, node *typegraph.Node, imports imports.ImportMap, targets patchTargets, _ string,
+) (err error) {
+	patchTypeName := ts.Name.Name + astutil.PrintTypeParamsDst(ts)
+	jsonPatchIdent, _ := imports.Ident(UndPathJSONPatch)
+
+	printf, flush := astutil.BufPrintf(w)
+	defer func() {
+		err = flush()
+	}()
+
+	printf(
+		`//%s%s
+`,
+		directive.DirectivePrefix, directive.DirectiveCommentGenerated,
+	)
+	printf(
+		`func (p %s) ToJSONPatch() ([]%s.Operation, error) {
+`,
+		patchTypeName, jsonPatchIdent,
+	)
+	defer printf(`}
+
+`)
+
+	var found bool
+	for f := range jsonPatchFields(node, targets) {
+		if !found {
+			found = true
+			printf(
+				`var (
+	ops []%s.Operation
+	err error
+)
+`,
+				jsonPatchIdent,
+			)
+		}
+		fn := "AppendField"
+		if f.nested != nil {
+			fn = "AppendNested"
+		}
+		printf(
+			`if ops, err = %[1]s.%[2]s(ops, %[3]s, p.%[4]s); err != nil {
+	return nil, err
+}
+`,
+			jsonPatchIdent, fn, strconv.Quote(f.name), f.field.Name(),
+		)
+	}
+	if !found {
+		printf(`return nil, nil
+`)
+		return
+	}
+	printf(`return ops, nil
+`)
+	return
+}
+
+// generates methods on the patch type
+//
+//	func (p *Patch[T, U,...]) FromJSONPatch(ops []jsonpatch.Operation) error {
+//		for _, op := range ops {
+//			if err := jsonpatch.CheckOp(op); err != nil {
+//				return err
+//			}
+//			name, rest, err := jsonpatch.Split(op.Path)
+//			if err != nil {
+//				return err
+//			}
+//			switch name {
+//			case "field":
+//				err = jsonpatch.DecodeField(op, rest, &p.Field)
+//			// ...
+//			default:
+//				err = jsonpatch.PathNotFound(op)
+//			}
+//			if err != nil {
+//				return err
+//			}
+//		}
+//		return nil
+//	}
+func generateFromJSONPatch(
+	w io.Writer, ts *dst.TypeSpec, node *typegraph.Node, imports imports.ImportMap, targets patchTargets, _ string,
+) (err error) {
+	patchTypeName := ts.Name.Name + astutil.PrintTypeParamsDst(ts)
+	jsonPatchIdent, _ := imports.Ident(UndPathJSONPatch)
+	qualifier := imports.Qualifier(node.Type.Obj().Pkg().Path())
+
+	printf, flush := astutil.BufPrintf(w)
+	defer func() {
+		err = flush()
+	}()
+
+	printf(
+		`//%s%s
+`,
+		directive.DirectivePrefix, directive.DirectiveCommentGenerated,
+	)
+	printf(
+		`func (p *%s) FromJSONPatch(ops []%s.Operation) error {
+`,
+		patchTypeName, jsonPatchIdent,
+	)
+	defer printf(`}
+
+`)
+
+	var found bool
+	for f := range jsonPatchFields(node, targets) {
+		if !found {
+			found = true
+			printf(
+				`for _, op := range ops {
+	if err := %[1]s.CheckOp(op); err != nil {
+		return err
+	}
+	name, rest, err := %[1]s.Split(op.Path)
+	if err != nil {
+		return err
+	}
+	switch name {
+`,
+				jsonPatchIdent,
+			)
+		}
+		if f.nested != nil {
+			printf(
+				`case %[1]s:
+	err = %[2]s.DecodeNested[%[3]s](op, rest, &p.%[4]s)
+`,
+				strconv.Quote(f.name), jsonPatchIdent, types.TypeString(f.nested, qualifier), f.field.Name(),
+			)
+			continue
+		}
+		printf(
+			`case %[1]s:
+	err = %[2]s.DecodeField(op, rest, &p.%[3]s)
+`,
+			strconv.Quote(f.name), jsonPatchIdent, f.field.Name(),
+		)
+	}
+	if !found {
+		printf(
+			`if len(ops) > 0 {
+	if err := %[1]s.CheckOp(ops[0]); err != nil {
+		return err
+	}
+	return %[1]s.PathNotFound(ops[0])
+}
+return nil
+`,
+			jsonPatchIdent,
+		)
+		return
+	}
+	printf(
+		`	default:
+		err = %s.PathNotFound(op)
+	}
+	if err != nil {
+		return err
+	}
+}
+return nil
+`,
+		jsonPatchIdent,
+	)
+	return
+}
@@ -0,0 +1,345 @@
+package undgen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/dave/dst"
+	"github.com/ngicks/go-codegen/codegen/pkg/astutil"
+	"github.com/ngicks/go-codegen/codegen/pkg/directive"
+	"github.com/ngicks/go-codegen/codegen/pkg/imports"
+	"github.com/ngicks/go-codegen/codegen/pkg/pkgsutil"
+	"github.com/ngicks/go-codegen/codegen/pkg/structtag"
+	"github.com/ngicks/go-codegen/codegen/pkg/typegraph"
+	"github.com/ngicks/go-iterator-helper/hiter"
+	"golang.org/x/tools/go/packages"
+)
+
+// patchTagName is the struct tag key of patch policies.
+const patchTagName = "patch"
+
+// patchPolicy is a field-level patch policy read from patch:"..." struct tags.
+type patchPolicy struct {
+	// omit is set by patch:"-". The field is not in the patch type.
+	omit bool
+	// nonnull is set by patch:"nonnull". UndValidate reports an error if the field is null.
+	nonnull bool
+	// readonly is set by patch:"readonly". CheckReadOnly and ApplyPatchChecked report an error if the field is set.
+	readonly bool
+}
+
+// parsePatchPolicy parses the patch policy of tag.
+// Options are comma separated, e.g. patch:"nonnull,readonly". "-" must be used alone.
+func parsePatchPolicy(tag reflect.StructTag) (patchPolicy, error) {
+	tags, err := structtag.ParseStructTag(tag)
+	if err != nil {
+		return patchPolicy{}, err
+	}
+	var policy patchPolicy
+	for _, t := range tags {
+		if t.Key != patchTagName {
+			continue
+		}
+		if t.Value == "-" {
+			policy.omit = true
+			continue
+		}
+		for opt := range strings.SplitSeq(t.Value, ",") {
+			switch opt {
+			case "":
+			case "nonnull":
+				policy.nonnull = true
+			case "readonly":
+				policy.readonly = true
+			default:
+				return patchPolicy{}, fmt.Errorf("unknown option %q in %s:%q", opt, patchTagName, t.Value)
+			}
+		}
+	}
+	if policy.omit && (policy.nonnull || policy.readonly) {
+		return patchPolicy{}, fmt.Errorf("%s:\"-\" can not be combined with other options", patchTagName)
+	}
+	return policy, nil
+}
+
+// fieldPatchPolicy returns the patch policy of i-th field of ty.
+// Malformed tags are rejected by validatePatchPolicies beforehand and result in zero value here.
+func fieldPatchPolicy(ty types.Type, i int) patchPolicy {
+	st, ok := ty.Underlying().(*types.Struct)
+	if !ok {
+		return patchPolicy{}
+	}
+	policy, _ := parsePatchPolicy(reflect.StructTag(st.Tag(i)))
+	return policy
+}
+
+// validatePatchPolicies reports an error if any field of target types has a malformed patch policy.
+func validatePatchPolicies(pkgs []*packages.Package, replacerData map[*ast.File]*typegraph.ReplaceData) error {
+	for _, data := range hiter.MapsKeys(replacerData, pkgsutil.EnumerateFile(pkgs)) {
+		if data == nil {
+			continue
+		}
+		for _, node := range data.TargetNodes {
+			for i, f := range typeObjectFieldsIter(node.Type) {
+				st := node.Type.Underlying().(*types.Struct)
+				policy, err := parsePatchPolicy(reflect.StructTag(st.Tag(i)))
+				if err != nil {
+					return fmt.Errorf("field %s of type %s: %w", f.Name(), node.Type.Obj().Name(), err)
+				}
+				if f.Embedded() && policy != (patchPolicy{}) {
+					return fmt.Errorf(
+						"field %s of type %s: %s tag on embedded fields is not supported",
+						f.Name(), node.Type.Obj().Name(), patchTagName,
+					)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// hasPolicy reports whether ty or types of its fields nested by deep patches have a field whose policy satisfies fn.
+func hasPolicy(ty *types.Named, targets patchTargets, fn func(p patchPolicy) bool) bool {
+	for i, f := range typeObjectFieldsIter(ty) {
+		policy := fieldPatchPolicy(ty, i)
+		if policy.omit || f.Embedded() {
+			continue
+		}
+		if fn(policy) {
+			return true
+		}
+		if nested, ok := nestedFieldType(f, targets); ok && hasPolicy(nested, targets, fn) {
+			return true
+		}
+	}
+	return false
+}
+
+// nestedFieldType returns the type of f if f is patched by the patch type of its type.
+// It is same as patchTargets.nested but works without edges, for types other than the one being generated.
+func nestedFieldType(f *types.Var, targets patchTargets) (*types.Named, bool) {
+	named, ok := f.Type().(*types.Named)
+	if !ok {
+		return nil, false
+	}
+	if _, isStruct := named.Underlying().(*types.Struct); !isStruct {
+		return nil, false
+	}
+	return named, targets[typegraph.IdentFromTypesObject(named.Obj())]
+}
+
+// hasOmitted reports whether ty or types of its fields nested by deep patches have a field tagged with patch:"-".
+func hasOmitted(ty *types.Named, targets patchTargets) bool {
+	for i, f := range typeObjectFieldsIter(ty) {
+		if fieldPatchPolicy(ty, i).omit {
+			return true
+		}
+		if f.Embedded() {
+			continue
+		}
+		if nested, ok := nestedFieldType(f, targets); ok && hasOmitted(nested, targets) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasReadOnly(ty *types.Named, targets patchTargets) bool {
+	return hasPolicy(ty, targets, func(p patchPolicy) bool { return p.readonly })
+}
+
+func hasNonnull(ty *types.Named, targets patchTargets) bool {
+	return hasPolicy(ty, targets, func(p patchPolicy) bool { return p.nonnull })
+}
+
+// patchFieldName returns the name of i-th field of ty used in validation errors.
+func patchFieldName(ty types.Type, i int) string {
+	st := ty.Underlying().(*types.Struct)
+	if name := fieldJsonName(st, i); name != "" && name != "-" {
+		return name
+	}
+	return st.Field(i).Name()
+}
+
+// generates a method on the patch type if the type has fields tagged with patch:"readonly"
+//
+//	func (p Patch[T, U,...]) CheckReadOnly() error {
+//		if !p.field.IsUndefined() {
+//			return validate.AppendValidationErrorDot(patchpolicy.ErrReadOnly, "field")
+//		}
+//		// ...
+//		return nil
+//	}
+//
+// Nested fields of deep patches which have read-only fields are checked recursively,
+// and can not be null.
+func generateCheckReadOnly(
+	w io.Writer, ts *dst.TypeSpec, node *typegraph.Node, imports imports.ImportMap, targets patchTargets, _ string,
+) (err error) {
+	if !hasReadOnly(node.Type, targets) {
+		return nil
+	}
+
+	patchTypeName := ts.Name.Name + astutil.PrintTypeParamsDst(ts)
+	validateIdent, _ := imports.Ident(UndPathValidate)
+	patchPolicyIdent, _ := imports.Ident(UndPathPatchPolicy)
+
+	printf, flush := astutil.BufPrintf(w)
+	defer func() {
+		err = flush()
+	}()
+
+	printf(
+		`//%s%s
+`,
+		directive.DirectivePrefix, directive.DirectiveCommentGenerated,
+	)
+	printf(
+		`func (p %s) CheckReadOnly() error {
+`,
+		patchTypeName,
+	)
+	defer printf(`return nil
+}
+
+`)
+
+	edgeMap := node.ChildEdgeMap(patcherEdgeFilter)
+	for i, f := range typeObjectFieldsIter(node.Type) {
+		policy := fieldPatchPolicy(node.Type, i)
+		if policy.omit || f.Embedded() {
+			continue
+		}
+		name := patchFieldName(node.Type, i)
+		if policy.readonly {
+			printf(
+				`if !p.%[1]s.IsUndefined() {
+	return %[2]s.AppendValidationErrorDot(%[3]s.ErrReadOnly, %[4]q)
+}
+`,
+				f.Name(), validateIdent, patchPolicyIdent, name,
+			)
+			continue
+		}
+		if edge, _, _, ok := edgeMap.ByFieldName(f.Name()); targets.nested(edge, ok) && hasReadOnly(edge.ChildType, targets) {
+			printf(
+				`if p.%[1]s.IsNull() {
+	return %[2]s.AppendValidationErrorDot(%[3]s.ErrReadOnly, %[4]q)
+}
+if p.%[1]s.IsDefined() {
+	if err := p.%[1]s.Value().CheckReadOnly(); err != nil {
+		return %[2]s.AppendValidationErrorDot(err, %[4]q)
+	}
+}
+`,
+				f.Name(), validateIdent, patchPolicyIdent, name,
+			)
+		}
+	}
+	return
+}
+
+// generates a method on the patch type if the type has fields tagged with patch:"nonnull"
+//
+//	func (p Patch[T, U,...]) UndValidate() (err error) {
+//		{
+//			validator := undtag.UndOptExport{States: &undtag.StateValidator{Def: true, Und: true}}.Into()
+//			if !validator.ValidUnd(p.field) {
+//				err = fmt.Errorf("%s: value is %s", validator.Describe(), validate.ReportState(p.field))
+//			}
+//			if err != nil {
+//				return validate.AppendValidationErrorDot(err, "field")
+//			}
+//		}
+//		// ...
+//		return
+//	}
+//
+// Nested fields of deep patches which have non-null fields are validated recursively.
+func generatePatchUndValidate(
+	w io.Writer, ts *dst.TypeSpec, node *typegraph.Node, imports imports.ImportMap, targets patchTargets, _ string,
+) (err error) {
+	if !hasNonnull(node.Type, targets) {
+		return nil
+	}
+
+	patchTypeName := ts.Name.Name + astutil.PrintTypeParamsDst(ts)
+	undtagIdent, _ := imports.Ident(UndPathUndTag)
+	validateIdent, _ := imports.Ident(UndPathValidate)
+	fmtIdent, _ := imports.Ident("fmt")
+
+	printf, flush := astutil.BufPrintf(w)
+	defer func() {
+		err = flush()
+	}()
+
+	printf(
+		`//%s%s
+`,
+		directive.DirectivePrefix, directive.DirectiveCommentGenerated,
+	)
+	printf(
+		`func (p %s) UndValidate() (err error) {
+`,
+		patchTypeName,
+	)
+	defer printf(`return
+}
+
+`)
+
+	edgeMap := node.ChildEdgeMap(patcherEdgeFilter)
+	for i, f := range typeObjectFieldsIter(node.Type) {
+		policy := fieldPatchPolicy(node.Type, i)
+		if policy.omit || f.Embedded() {
+			continue
+		}
+		edge, _, _, ok := edgeMap.ByFieldName(f.Name())
+		nested := targets.nested(edge, ok) && hasNonnull(edge.ChildType, targets)
+		if !policy.nonnull && !nested {
+			continue
+		}
+
+		printf("{\n")
+		if policy.nonnull {
+			validMethod := "ValidUnd"
+			if ok && !targets.nested(edge, ok) {
+				// elastic types are kept as they are in patch types.
+				switch namedTypeToTargetType(edge.ChildType) {
+				case UndTargetTypeElastic, UndTargetTypeSliceElastic:
+					validMethod = "ValidElastic"
+				}
+			}
+			printf(
+				`validator := %[1]s.UndOptExport{States: &%[1]s.StateValidator{Def: true, Und: true}}.Into()
+if !validator.%[2]s(p.%[3]s) {
+	err = %[5]s.Errorf("%%s: value is %%s", validator.Describe(), %[4]s.ReportState(p.%[3]s))
+}
+`,
+				undtagIdent, validMethod, f.Name(), validateIdent, fmtIdent,
+			)
+		}
+		if nested {
+			printf(
+				`if err == nil && p.%[1]s.IsDefined() {
+	err = p.%[1]s.Value().UndValidate()
+}
+`,
+				f.Name(),
+			)
+		}
+		printf(
+			`if err != nil {
+	return %s.AppendValidationErrorDot(err, %q)
+}
+}
+`,
+			validateIdent, patchFieldName(node.Type, i),
+		)
+	}
+	return
+}
@@ -1,6 +1,7 @@
 package undgen
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"go/ast"
@@ -14,10 +15,10 @@ import (
 
 	"github.com/dave/dst"
 	"github.com/dave/dst/decorator"
+	"github.com/ngicks/go-codegen/codegen/internal/workpool"
 	"github.com/ngicks/go-codegen/codegen/pkg/astutil"
 	"github.com/ngicks/go-codegen/codegen/pkg/directive"
 	"github.com/ngicks/go-codegen/codegen/pkg/imports"
-	"github.com/ngicks/go-codegen/codegen/internal/bufpool"
 	"github.com/ngicks/go-codegen/codegen/pkg/pkgsutil"
 	"github.com/ngicks/go-codegen/codegen/pkg/suffixwriter"
 	"github.com/ngicks/go-codegen/codegen/pkg/typegraph"
@@ -30,7 +31,9 @@ func GeneratePlain(
 	verbose bool,
 	pkgs []*packages.Package,
 	extra []imports.TargetImport,
+	opts ...Option,
 ) error {
+	o := newOptions(opts)
 	parser := imports.NewParserPackages(pkgs)
 	parser.AppendExtra(extra...)
 	replacerData, err := gatherPlainUndTypes(
@@ -40,100 +43,98 @@ func GeneratePlain(
 		func(g *typegraph.Graph) iter.Seq2[typegraph.Ident, *typegraph.Node] {
 			return g.IterUpward(true, isUndPlainAllowedEdge)
 		},
+		o.report,
 	)
 	if err != nil {
 		return err
 	}
 
-	buf := bufpool.GetBuf()
-	defer bufpool.PutBuf(buf)
-
-	for _, data := range hiter.Filter2(
-		func(f *ast.File, data *typegraph.ReplaceData) bool { return f != nil && data != nil },
-		hiter.MapsKeys(replacerData, pkgsutil.EnumerateFile(pkgs)),
-	) {
-		buf.Reset()
-
-		slog.Debug(
-			"found",
-			slog.String("filename", data.Filename),
-		)
-
-		modified := hiter.Collect2(hiter.Filter2(
-			func(node *typegraph.Node, exprMap map[string]fieldDstExprSet) bool {
-				return node != nil && exprMap != nil
-			},
-			hiter.Divide(
-				func(node *typegraph.Node) (*typegraph.Node, map[string]fieldDstExprSet) {
-					exprMap, ok := _replaceToPlainTypes(data, node)
-					if !ok {
-						return nil, nil
-					}
-					slog.Debug(
-						"rewritten",
-						slog.String("package", node.Type.Obj().Pkg().Path()),
-						slog.String("type", node.Type.Obj().Name()),
-					)
-					return node, exprMap
-				},
-				slices.Values(data.TargetNodes),
-			),
-		))
-
-		if len(modified) == 0 {
-			continue
-		}
-
-		data.ImportMap.AddMissingImports(data.DstFile)
-		res := decorator.NewRestorer()
-		af, err := res.RestoreFile(data.DstFile)
-		if err != nil {
-			return fmt.Errorf("converting dst to ast for %q: %w", data.Filename, err)
-		}
-
-		if err := astutil.PrintFileHeader(buf, af, res.Fset); err != nil {
-			return fmt.Errorf("%q: %w", data.Filename, err)
-		}
-
-		for node, exprMap := range hiter.Values2(modified) {
-			dts := data.Dec.Dst.Nodes[node.Ts].(*dst.TypeSpec)
-			ats := res.Ast.Nodes[dts].(*ast.TypeSpec)
-
-			astExprMap := maps.Collect(
-				hiter.Map2(
-					func(s string, expr fieldDstExprSet) (string, fieldAstExprSet) {
-						return s, fieldAstExprSet{
-							Wrapped:   res.Ast.Nodes[expr.Wrapped].(ast.Expr),
-							Unwrapped: res.Ast.Nodes[expr.Unwrapped].(ast.Expr),
-						}
+	return workpool.Run(
+		sourcePrinter.Jobs(),
+		slices.Collect(hiter.OmitF(hiter.Filter2(
+			func(f *ast.File, data *typegraph.ReplaceData) bool { return f != nil && data != nil },
+			hiter.MapsKeys(replacerData, pkgsutil.EnumerateFile(pkgs)),
+		))),
+		func(data *typegraph.ReplaceData) error {
+			return o.cache.Write(context.Background(), sourcePrinter, data, func(buf *bytes.Buffer) (written bool, err error) {
+				slog.Debug(
+					"found",
+					slog.String("filename", data.Filename),
+				)
+
+				modified := hiter.Collect2(hiter.Filter2(
+					func(node *typegraph.Node, exprMap map[string]fieldDstExprSet) bool {
+						return node != nil && exprMap != nil
 					},
-					maps.All(exprMap),
-				),
-			)
-
-			buf.WriteString("//" + directive.DirectivePrefix + directive.DirectiveCommentGenerated + "\n")
-			buf.WriteString(token.TYPE.String())
-			buf.WriteByte(' ')
-			err = printer.Fprint(buf, res.Fset, ats)
-			if err != nil {
-				return fmt.Errorf("print.Fprint failed for type %s in file %q: %w", data.Filename, ats.Name.Name, err)
-			}
-			buf.WriteString("\n\n")
+					hiter.Divide(
+						func(node *typegraph.Node) (*typegraph.Node, map[string]fieldDstExprSet) {
+							exprMap, ok := _replaceToPlainTypes(data, node)
+							if !ok {
+								return nil, nil
+							}
+							slog.Debug(
+								"rewritten",
+								slog.String("package", node.Type.Obj().Pkg().Path()),
+								slog.String("type", node.Type.Obj().Name()),
+							)
+							return node, exprMap
+						},
+						slices.Values(data.TargetNodes),
+					),
+				))
+
+				if len(modified) == 0 {
+					return false, nil
+				}
+
+				data.ImportMap.AddMissingImports(data.DstFile)
+				res := decorator.NewRestorer()
+				af, err := res.RestoreFile(data.DstFile)
+				if err != nil {
+					return false, fmt.Errorf("converting dst to ast for %q: %w", data.Filename, err)
+				}
+
+				if err := astutil.PrintFileHeader(buf, af, res.Fset); err != nil {
+					return false, fmt.Errorf("%q: %w", data.Filename, err)
+				}
+
+				for node, exprMap := range hiter.Values2(modified) {
+					dts := data.Dec.Dst.Nodes[node.Ts].(*dst.TypeSpec)
+					ats := res.Ast.Nodes[dts].(*ast.TypeSpec)
+
+					astExprMap := maps.Collect(
+						hiter.Map2(
+							func(s string, expr fieldDstExprSet) (string, fieldAstExprSet) {
+								return s, fieldAstExprSet{
+									Wrapped:   res.Ast.Nodes[expr.Wrapped].(ast.Expr),
+									Unwrapped: res.Ast.Nodes[expr.Unwrapped].(ast.Expr),
+								}
+							},
+							maps.All(exprMap),
+						),
+					)
 
-			err = generateConversionMethod(buf, data, node, astExprMap)
-			if err != nil {
-				return err
-			}
+					buf.WriteString("//" + directive.DirectivePrefix + directive.DirectiveCommentGenerated + "\n")
+					buf.WriteString(token.TYPE.String())
+					buf.WriteByte(' ')
+					err = printer.Fprint(buf, res.Fset, ats)
+					if err != nil {
+						return false, fmt.Errorf("print.Fprint failed for type %s in file %q: %w", data.Filename, ats.Name.Name, err)
+					}
+					buf.WriteString("\n\n")
 
-			buf.WriteString("\n\n")
-		}
+					err = generateConversionMethod(buf, data, node, astExprMap)
+					if err != nil {
+						return false, err
+					}
 
-		err = sourcePrinter.Write(context.Background(), data.Filename, buf.Bytes())
-		if err != nil {
-			return err
-		}
-	}
-	return nil
+					buf.WriteString("\n\n")
+				}
+
+				return true, nil
+			})
+		},
+	)
 }
 
 func sliceSuffix(isSlice bool) string {
@@ -1,6 +1,7 @@
 package undgen
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"go/ast"
@@ -14,10 +15,11 @@ import (
 
 	"github.com/dave/dst"
 	"github.com/dave/dst/decorator"
+	"github.com/ngicks/go-codegen/codegen/internal/bufpool"
+	"github.com/ngicks/go-codegen/codegen/internal/workpool"
 	"github.com/ngicks/go-codegen/codegen/pkg/astutil"
 	"github.com/ngicks/go-codegen/codegen/pkg/directive"
 	"github.com/ngicks/go-codegen/codegen/pkg/imports"
-	"github.com/ngicks/go-codegen/codegen/internal/bufpool"
 	"github.com/ngicks/go-codegen/codegen/pkg/pkgsutil"
 	"github.com/ngicks/go-codegen/codegen/pkg/suffixwriter"
 	"github.com/ngicks/go-codegen/codegen/pkg/typegraph"
@@ -31,7 +33,9 @@ func GenerateValidator(
 	verbose bool,
 	pkgs []*packages.Package,
 	extra []imports.TargetImport,
+	opts ...Option,
 ) error {
+	o := newOptions(opts)
 	parser := imports.NewParserPackages(pkgs)
 	parser.AppendExtra(extra...)
 	// The generated code uses fmt.Errorf.
@@ -44,64 +48,60 @@ func GenerateValidator(
 		func(g *typegraph.Graph) iter.Seq2[typegraph.Ident, *typegraph.Node] {
 			return g.IterUpward(true, isUndValidatorAllowedEdge)
 		},
+		o.report,
 	)
 	if err != nil {
 		return err
 	}
 
-	buf := bufpool.GetBuf()
-	defer bufpool.PutBuf(buf)
-
-	for _, data := range hiter.Filter2(
-		func(f *ast.File, data *typegraph.ReplaceData) bool { return f != nil && data != nil },
-		hiter.MapsKeys(replacerData, pkgsutil.EnumerateFile(pkgs)),
-	) {
-		buf.Reset()
-
-		if verbose {
-			slog.Debug(
-				"found",
-				slog.String("filename", data.Filename),
-			)
-		}
+	return workpool.Run(
+		sourcePrinter.Jobs(),
+		slices.Collect(hiter.OmitF(hiter.Filter2(
+			func(f *ast.File, data *typegraph.ReplaceData) bool { return f != nil && data != nil },
+			hiter.MapsKeys(replacerData, pkgsutil.EnumerateFile(pkgs)),
+		))),
+		func(data *typegraph.ReplaceData) error {
+			return o.cache.Write(context.Background(), sourcePrinter, data, func(buf *bytes.Buffer) (written bool, err error) {
+				if verbose {
+					slog.Debug(
+						"found",
+						slog.String("filename", data.Filename),
+					)
+				}
 
-		data.ImportMap.AddMissingImports(data.DstFile)
-		res := decorator.NewRestorer()
-		af, err := res.RestoreFile(data.DstFile)
-		if err != nil {
-			return fmt.Errorf("converting dst to ast for %q: %w", data.Filename, err)
-		}
+				data.ImportMap.AddMissingImports(data.DstFile)
+				res := decorator.NewRestorer()
+				af, err := res.RestoreFile(data.DstFile)
+				if err != nil {
+					return false, fmt.Errorf("converting dst to ast for %q: %w", data.Filename, err)
+				}
 
-		if err := astutil.PrintFileHeader(buf, af, res.Fset); err != nil {
-			return fmt.Errorf("%q: %w", data.Filename, err)
-		}
+				if err := astutil.PrintFileHeader(buf, af, res.Fset); err != nil {
+					return false, fmt.Errorf("%q: %w", data.Filename, err)
+				}
 
-		var atLeastOne bool
-		for _, node := range data.TargetNodes {
-			dts := data.Dec.Dst.Nodes[node.Ts].(*dst.TypeSpec)
-			written, err := generateUndValidate(
-				buf,
-				dts,
-				node,
-				data.ImportMap,
-			)
-			if written {
-				atLeastOne = true
-			}
-			if err != nil {
-				return fmt.Errorf("generating UndValidate for type %s in file %q: %w", node.Ts.Name.Name, data.Filename, err)
-			}
-			buf.WriteString("\n\n")
-		}
+				var atLeastOne bool
+				for _, node := range data.TargetNodes {
+					dts := data.Dec.Dst.Nodes[node.Ts].(*dst.TypeSpec)
+					written, err := generateUndValidate(
+						buf,
+						dts,
+						node,
+						data.ImportMap,
+					)
+					if written {
+						atLeastOne = true
+					}
+					if err != nil {
+						return false, fmt.Errorf("generating UndValidate for type %s in file %q: %w", node.Ts.Name.Name, data.Filename, err)
+					}
+					buf.WriteString("\n\n")
+				}
 
-		if atLeastOne {
-			err = sourcePrinter.Write(context.Background(), data.Filename, buf.Bytes())
-			if err != nil {
-				return err
-			}
-		}
-	}
-	return nil
+				return atLeastOne, nil
+			})
+		},
+	)
 }
 
 // generates methods on the patch type
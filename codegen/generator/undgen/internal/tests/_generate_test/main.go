@@ -49,9 +49,9 @@ func Test_%[1]s_patcher(t *testing.T) {
 	err := undgen.GeneratePatcher(
 		testPrinter.Writer,
 		true,
-		pkgs[0],
+		pkgs,
 		undgen.ConstUnd.Imports,
-		"...",
+		[]string{"..."},
 	)
 	assert.NilError(t, err)
 	results := testPrinter.Results()
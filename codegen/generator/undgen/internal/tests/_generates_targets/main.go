@@ -5,6 +5,7 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"maps"
 	"os"
 	"os/exec"
 	"os/signal"
@@ -13,10 +14,14 @@ import (
 )
 
 var (
-	excludes = flag.String("e", "", "")
+	excludes  = flag.String("e", "", "")
+	deep      = flag.String("deep", "", "")
+	jsonPatch = flag.String("json-patch", "", "")
+	diff      = flag.String("diff", "", "")
 )
 
 func main() {
+	flag.Parse()
 	ctx, cancel := signal.NotifyContext(context.Background())
 	defer cancel()
 	commands := []string{
@@ -28,16 +33,31 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+	// patch targets are grouped by flags they are generated with.
+	patchPkgs := make(map[string][]string)
 	for _, dirent := range dirents {
 		name := dirent.Name()
 		if !dirent.IsDir() || slices.Contains(strings.Split(*excludes, ","), name) {
 			continue
 		}
+		var flags string
+		if slices.Contains(strings.Split(*deep, ","), name) {
+			flags += " --deep"
+		}
+		if slices.Contains(strings.Split(*jsonPatch, ","), name) {
+			flags += " --json-patch"
+		}
+		if slices.Contains(strings.Split(*diff, ","), name) {
+			flags += " --diff"
+		}
+		patchPkgs[flags] = append(patchPkgs[flags], fmt.Sprintf("--pkg ./%s/...", name))
+	}
+	for _, flags := range slices.Sorted(maps.Keys(patchPkgs)) {
 		commands = append(
 			commands,
 			fmt.Sprintf(
-				"go run github.com/ngicks/go-codegen/codegen undgen patch -v --ignore-generated --dir ../testtargets --pkg ./%s ...",
-				name,
+				"go run github.com/ngicks/go-codegen/codegen undgen patch -v --ignore-generated --dir ../testtargets%s %s ...",
+				flags, strings.Join(patchPkgs[flags], " "),
 			),
 		)
 	}
@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ngicks/go-codegen/codegen/generator/undgen/internal/testtargets/deeppatch"
+	"github.com/ngicks/go-codegen/codegen/generator/undgen/internal/testtargets/deeppatch/meta"
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/option"
+	"gotest.tools/v3/assert"
+)
+
+func Test_deeppatch_ApplyPatch(t *testing.T) {
+	org := deeppatch.User{
+		Name: "foo",
+		Address: deeppatch.Address{
+			City:   "Tokyo",
+			Street: und.Defined("Chuo"),
+			Geo:    deeppatch.Geo{Lat: 35.6, Lng: 139.7},
+		},
+		Home: &deeppatch.Address{City: "Osaka"},
+		Pair: deeppatch.Pair[int]{L: 1, R: 2},
+		Meta: meta.Meta{Version: 3, Note: option.Some("note")},
+	}
+
+	type testCase struct {
+		name     string
+		patch    string
+		expected func(u deeppatch.User) deeppatch.User
+	}
+	for _, tc := range []testCase{
+		{
+			"empty",
+			`{}`,
+			func(u deeppatch.User) deeppatch.User { return u },
+		},
+		{
+			"nested field",
+			`{"address":{"city":"Kyoto","geo":{"lat":35.0}}}`,
+			func(u deeppatch.User) deeppatch.User {
+				u.Address.City = "Kyoto"
+				u.Address.Geo.Lat = 35.0
+				return u
+			},
+		},
+		{
+			"nested null",
+			`{"address":null,"pair":{"r":5},"meta":{"note":null}}`,
+			func(u deeppatch.User) deeppatch.User {
+				u.Address = deeppatch.Address{}
+				u.Pair.R = 5
+				u.Meta.Note = option.None[string]()
+				return u
+			},
+		},
+		{
+			"pointer is replaced",
+			`{"home":{"street":"Dotonbori"}}`,
+			func(u deeppatch.User) deeppatch.User {
+				u.Home = &deeppatch.Address{Street: und.Defined("Dotonbori")}
+				return u
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var p deeppatch.UserPatch
+			assert.NilError(t, json.Unmarshal([]byte(tc.patch), &p))
+			assert.DeepEqual(
+				t,
+				tc.expected(org),
+				p.ApplyPatch(org),
+				compareUndString,
+				compareOptionString,
+			)
+		})
+	}
+}
+
+func Test_deeppatch_FromValue_ToValue(t *testing.T) {
+	org := deeppatch.User{
+		Name:    "foo",
+		Address: deeppatch.Address{City: "Tokyo", Street: und.Null[string]()},
+		Meta:    meta.Meta{Version: 1},
+	}
+	var p deeppatch.UserPatch
+	p.FromValue(org)
+	assert.Assert(t, p.Address.IsDefined())
+	assert.Equal(t, "Tokyo", p.Address.Value().City.Value())
+	assert.DeepEqual(t, org, p.ToValue(), compareUndString, compareOptionString)
+}
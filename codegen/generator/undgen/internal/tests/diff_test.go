@@ -0,0 +1,214 @@
+package tests
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	gocmp "github.com/google/go-cmp/cmp"
+	"github.com/ngicks/go-codegen/codegen/generator/undgen/internal/testtargets/deeppatch"
+	"github.com/ngicks/go-codegen/codegen/generator/undgen/internal/testtargets/deeppatch/meta"
+	"github.com/ngicks/go-codegen/codegen/generator/undgen/internal/testtargets/diffpatch"
+	"github.com/ngicks/go-codegen/codegen/generator/undgen/internal/testtargets/fieldpolicy"
+	"github.com/ngicks/go-codegen/codegen/generator/undgen/internal/testtargets/typeparam"
+	"github.com/ngicks/go-codegen/pkg/undgen/runtime/patchpolicy"
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/option"
+	"github.com/ngicks/und/sliceund"
+	"gotest.tools/v3/assert"
+)
+
+func Test_diff_Event(t *testing.T) {
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	org := diffpatch.Event{
+		Id:       "foo",
+		At:       at,
+		Tags:     []string{"a", "b"},
+		Payload:  map[string]any{"key": "value"},
+		Point:    diffpatch.Point{X: 1, Y: 2},
+		Opt:      option.Some(5),
+		Und:      und.Defined("und"),
+		SliceUnd: sliceund.Defined([]int{1, 2}),
+		Custom:   diffpatch.Custom{Values: []int{1, 2, 3}},
+	}
+	clone := func(e diffpatch.Event) diffpatch.Event {
+		e.Tags = append([]string(nil), e.Tags...)
+		e.Payload = map[string]any{"key": "value"}
+		e.SliceUnd = sliceund.Defined([]int{1, 2})
+		e.Custom.Values = append([]int(nil), e.Custom.Values...)
+		return e
+	}
+
+	type testCase struct {
+		name    string
+		modify  func(e diffpatch.Event) diffpatch.Event
+		changed []string
+	}
+	for _, tc := range []testCase{
+		{
+			"equal",
+			func(e diffpatch.Event) diffpatch.Event { return e },
+			nil,
+		},
+		{
+			"time in other location",
+			func(e diffpatch.Event) diffpatch.Event {
+				e.At = at.In(time.FixedZone("JST", 9*60*60))
+				return e
+			},
+			nil,
+		},
+		{
+			"custom equal",
+			func(e diffpatch.Event) diffpatch.Event {
+				e.Custom.Values = []int{3, 2, 1}
+				return e
+			},
+			nil,
+		},
+		{
+			"comparable",
+			func(e diffpatch.Event) diffpatch.Event {
+				e.Id = "bar"
+				e.Point.Y = 3
+				e.Opt = option.None[int]()
+				e.Und = und.Null[string]()
+				return e
+			},
+			[]string{"Id", "Point", "Opt", "Und"},
+		},
+		{
+			"non comparable",
+			func(e diffpatch.Event) diffpatch.Event {
+				e.At = at.Add(time.Second)
+				e.Tags = append(e.Tags, "c")
+				e.Payload = map[string]any{"key": "other"}
+				e.SliceUnd = sliceund.Null[[]int]()
+				e.Custom.Values = []int{4}
+				return e
+			},
+			[]string{"At", "Tags", "Payload", "SliceUnd", "Custom"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			after := tc.modify(clone(org))
+			p := diffpatch.DiffEvent(org, after)
+			undefined := map[string]bool{
+				"Id":       p.Id.IsUndefined(),
+				"At":       p.At.IsUndefined(),
+				"Tags":     p.Tags.IsUndefined(),
+				"Payload":  p.Payload.IsUndefined(),
+				"Point":    p.Point.IsUndefined(),
+				"Opt":      p.Opt.IsUndefined(),
+				"Und":      p.Und.IsUndefined(),
+				"SliceUnd": p.SliceUnd.IsUndefined(),
+				"Custom":   p.Custom.IsUndefined(),
+			}
+			for name, isUndefined := range undefined {
+				assert.Equal(t, !isUndefined, slices.Contains(tc.changed, name), "field %s", name)
+			}
+			if len(tc.changed) > 0 {
+				assert.DeepEqual(
+					t,
+					after,
+					p.ApplyPatch(org),
+					compareOptionInt,
+					compareUndString,
+					gocmp.Comparer(func(i, j sliceund.Und[[]int]) bool {
+						return i.EqualFunc(j, slices.Equal)
+					}),
+				)
+			}
+		})
+	}
+}
+
+func Test_diff_deep(t *testing.T) {
+	before := deeppatch.User{
+		Name: "foo",
+		Address: deeppatch.Address{
+			City:   "Tokyo",
+			Street: und.Defined("Chuo"),
+			Geo:    deeppatch.Geo{Lat: 35.6, Lng: 139.7},
+		},
+		Pair: deeppatch.Pair[int]{L: 1, R: 2},
+		Meta: meta.Meta{Version: 3, Note: option.Some("note")},
+	}
+	after := before
+	after.Address.Geo.Lng = 135.5
+	after.Meta.Note = option.None[string]()
+
+	p := deeppatch.DiffUser(before, after)
+	assert.Assert(t, !p.Name.IsDefined())
+	assert.Assert(t, !p.Home.IsDefined())
+	assert.Assert(t, !p.Pair.IsDefined())
+	assert.Assert(t, p.Address.IsDefined())
+	address := p.Address.Value()
+	assert.Assert(t, !address.City.IsDefined())
+	assert.Assert(t, !address.Street.IsDefined())
+	assert.Assert(t, !address.Geo.Value().Lat.IsDefined())
+	assert.Equal(t, 135.5, address.Geo.Value().Lng.Value())
+	assert.Assert(t, !p.Meta.Value().Version.IsDefined())
+	assert.Assert(t, p.Meta.Value().Note.IsNull())
+
+	assert.DeepEqual(t, after, p.ApplyPatch(before), compareUndString, compareOptionString)
+}
+
+func Test_diff_typeparam(t *testing.T) {
+	before := typeparam.WithTypeParam[[]int]{
+		Foo: "foo",
+		Bar: []int{1, 2},
+		Baz: option.Some([]int{3}),
+	}
+	after := before
+	after.Bar = []int{1, 2, 3}
+
+	p := typeparam.DiffWithTypeParam(before, after)
+	assert.Assert(t, !p.Foo.IsDefined())
+	assert.Assert(t, !p.Baz.IsDefined())
+	assert.Assert(t, !p.Qux.IsDefined())
+	assert.DeepEqual(t, []int{1, 2, 3}, p.Bar.Value())
+}
+
+func Test_diff_embedded(t *testing.T) {
+	before := diffpatch.Tagged{Und: und.Defined("foo"), Name: "foo"}
+	after := diffpatch.Tagged{Und: und.Null[string](), Name: "foo"}
+
+	p := diffpatch.DiffTagged(before, after)
+	assert.Assert(t, p.Und.IsNull())
+	assert.Assert(t, !p.Name.IsDefined())
+	assert.Assert(t, p.ApplyPatch(before).Und.IsNull())
+
+	p = diffpatch.DiffTagged(before, before)
+	assert.Assert(t, p.Und.IsUndefined())
+}
+
+func Test_diff_readonly(t *testing.T) {
+	before := fieldpolicy.Item{Id: "id", Name: "foo", Owner: fieldpolicy.Owner{Id: "owner", Name: "baz"}}
+	after := fieldpolicy.Item{Id: "id2", Name: "bar", Owner: fieldpolicy.Owner{Id: "owner2", Name: "qux"}}
+
+	p := fieldpolicy.DiffItem(before, after)
+	assert.Equal(t, "id2", p.Id.Value())
+	assert.Equal(t, "bar", p.Name.Value())
+	assert.Equal(t, "owner2", p.Owner.Value().Id.Value())
+	assert.Equal(t, "qux", p.Owner.Value().Name.Value())
+	assert.ErrorIs(t, p.CheckReadOnly(), patchpolicy.ErrReadOnly)
+	_, err := p.ApplyPatchChecked(before)
+	assert.ErrorIs(t, err, patchpolicy.ErrReadOnly)
+
+	// only read-only fields of the nested value differ.
+	after = before
+	after.Owner.Id = "owner2"
+	p = fieldpolicy.DiffItem(before, after)
+	assert.Assert(t, p.Id.IsUndefined())
+	assert.ErrorIs(t, p.CheckReadOnly(), patchpolicy.ErrReadOnly)
+
+	after = before
+	after.Name = "bar"
+	p = fieldpolicy.DiffItem(before, after)
+	assert.NilError(t, p.CheckReadOnly())
+	applied, err := p.ApplyPatchChecked(before)
+	assert.NilError(t, err)
+	assert.Equal(t, "bar", applied.Name)
+	assert.Equal(t, "id", applied.Id)
+}
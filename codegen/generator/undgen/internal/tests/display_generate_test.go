@@ -16,9 +16,9 @@ func Test_display_patcher(t *testing.T) {
 	err := undgen.GeneratePatcher(
 		testPrinter.Writer,
 		true,
-		pkgs[0],
+		pkgs,
 		undgen.ConstUnd.Imports,
-		"...",
+		[]string{"..."},
 	)
 	assert.NilError(t, err)
 	results := testPrinter.Results()
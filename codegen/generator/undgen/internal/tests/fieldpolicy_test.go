@@ -0,0 +1,136 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ngicks/go-codegen/codegen/generator/undgen/internal/testtargets/fieldpolicy"
+	"github.com/ngicks/go-codegen/pkg/undgen/runtime/patchpolicy"
+	"github.com/ngicks/und/validate"
+	"gotest.tools/v3/assert"
+)
+
+func Test_fieldpolicy_omit(t *testing.T) {
+	var p fieldpolicy.ItemPatch
+	assert.NilError(t, json.Unmarshal([]byte(`{"created_at":"2024-01-01T00:00:00Z","name":"bar"}`), &p))
+
+	createdAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	org := fieldpolicy.Item{Id: "id", CreatedAt: createdAt, Name: "foo"}
+	applied := p.ApplyPatch(org)
+	assert.Equal(t, "bar", applied.Name)
+	assert.Equal(t, createdAt, applied.CreatedAt)
+
+	d := fieldpolicy.DiffItem(org, fieldpolicy.Item{Id: "id", Name: "foo"})
+	ops, err := d.ToJSONPatch()
+	assert.NilError(t, err)
+	assert.Equal(t, 0, len(ops))
+
+	archive := fieldpolicy.ArchivePatch{}
+	assert.NilError(t, json.Unmarshal([]byte(`{"key":"k","body":"b"}`), &archive))
+	assert.DeepEqual(
+		t,
+		fieldpolicy.Archive{Key: "org", Body: "b"},
+		archive.ApplyPatch(fieldpolicy.Archive{Key: "org", Body: "a"}),
+	)
+}
+
+func Test_fieldpolicy_omit_nested(t *testing.T) {
+	createdAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	org := fieldpolicy.Item{
+		Id:    "id",
+		Name:  "foo",
+		Owner: fieldpolicy.Owner{Id: "owner", Name: "baz", CreatedAt: createdAt},
+	}
+
+	for _, patch := range []string{`{}`, `{"name":"bar"}`, `{"owner":{"name":"qux","created_at":"2024-01-01T00:00:00Z"}}`} {
+		var p fieldpolicy.ItemPatch
+		assert.NilError(t, json.Unmarshal([]byte(patch), &p))
+		applied := p.ApplyPatch(org)
+		assert.Equal(t, createdAt, applied.Owner.CreatedAt, "patch = %s", patch)
+		assert.Equal(t, "owner", applied.Owner.Id, "patch = %s", patch)
+	}
+
+	var p fieldpolicy.ItemPatch
+	assert.NilError(t, json.Unmarshal([]byte(`{"owner":{"name":"qux"}}`), &p))
+	assert.Equal(t, "qux", p.ApplyPatch(org).Owner.Name)
+}
+
+func Test_fieldpolicy_readonly(t *testing.T) {
+	org := fieldpolicy.Item{
+		Id:    "id",
+		Name:  "foo",
+		Owner: fieldpolicy.Owner{Id: "owner", Name: "bar"},
+	}
+
+	type testCase struct {
+		name  string
+		patch string
+		path  string
+	}
+	for _, tc := range []testCase{
+		{"defined", `{"id":"other"}`, "/id"},
+		{"null", `{"id":null}`, "/id"},
+		{"nested", `{"owner":{"id":"other"}}`, "/owner/id"},
+		{"nested null", `{"owner":null}`, "/owner"},
+		{"allowed", `{"name":"baz","owner":{"name":"qux"}}`, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var p fieldpolicy.ItemPatch
+			assert.NilError(t, json.Unmarshal([]byte(tc.patch), &p))
+			applied, err := p.ApplyPatchChecked(org)
+			if tc.path == "" {
+				assert.NilError(t, err)
+				assert.Equal(t, "baz", applied.Name)
+				assert.Equal(t, "qux", applied.Owner.Name)
+				assert.Equal(t, "owner", applied.Owner.Id)
+				return
+			}
+			assert.ErrorIs(t, err, patchpolicy.ErrReadOnly)
+			var vErr *validate.ValidationError
+			assert.Assert(t, errors.As(err, &vErr))
+			assert.Equal(t, tc.path, vErr.Pointer())
+			assert.DeepEqual(t, org, applied, compareOptionString, compareUndString, compareElasticString)
+			// ApplyPatch does not check policies.
+			assert.Assert(t, p.ApplyPatch(org).Id != "id" || p.ApplyPatch(org).Owner.Id != "owner")
+		})
+	}
+}
+
+func Test_fieldpolicy_nonnull(t *testing.T) {
+	type testCase struct {
+		name  string
+		patch string
+		path  string
+	}
+	for _, tc := range []testCase{
+		{"undefined", `{}`, ""},
+		{"defined", `{"name":"foo","alias":"bar","labels":["baz",null],"owner":{"name":"qux"}}`, ""},
+		{"null", `{"name":null}`, "/name"},
+		{"und", `{"alias":null}`, "/alias"},
+		{"elastic", `{"labels":null}`, "/labels"},
+		{"nested", `{"owner":{"name":null}}`, "/owner/name"},
+		{"nullable", `{"note":null,"owner":null}`, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var p fieldpolicy.ItemPatch
+			assert.NilError(t, json.Unmarshal([]byte(tc.patch), &p))
+			err := p.UndValidate()
+			if tc.path == "" {
+				assert.NilError(t, err)
+				return
+			}
+			var vErr *validate.ValidationError
+			assert.Assert(t, errors.As(err, &vErr))
+			assert.Equal(t, tc.path, vErr.Pointer())
+		})
+	}
+}
+
+func Test_fieldpolicy_aliased_fmt(t *testing.T) {
+	var p fieldpolicy.LabelPatch
+	assert.NilError(t, json.Unmarshal([]byte(`{"text":null}`), &p))
+	assert.ErrorContains(t, p.UndValidate(), "text")
+	assert.NilError(t, fieldpolicy.LabelPatch{}.UndValidate())
+}
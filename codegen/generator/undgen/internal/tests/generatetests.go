@@ -1,4 +1,4 @@
 package tests
 
 //go:generate go run -race ./_generate_test -e _generate_test,implementor
-//go:generate go run -race ./_generates_targets -e _generate_test,implementor
+//go:generate go run -race ./_generates_targets -e _generate_test,implementor -deep deeppatch,fieldpolicy -json-patch deeppatch,patchtarget,fieldpolicy -diff deeppatch,diffpatch,typeparam,fieldpolicy
@@ -0,0 +1,200 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ngicks/go-codegen/codegen/generator/undgen/internal/testtargets/deeppatch"
+	"github.com/ngicks/go-codegen/codegen/generator/undgen/internal/testtargets/deeppatch/meta"
+	"github.com/ngicks/go-codegen/codegen/generator/undgen/internal/testtargets/patchtarget"
+	"github.com/ngicks/go-codegen/pkg/undgen/runtime/jsonpatch"
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/elastic"
+	"github.com/ngicks/und/option"
+	"github.com/ngicks/und/sliceund"
+	"gotest.tools/v3/assert"
+)
+
+func Test_jsonpatch_ToJSONPatch(t *testing.T) {
+	p := deeppatch.UserPatch{
+		Name: sliceund.Defined("foo"),
+		Address: sliceund.Defined(deeppatch.AddressPatch{
+			City: sliceund.Defined("Kyoto"),
+			Geo:  sliceund.Null[deeppatch.GeoPatch](),
+		}),
+		Home: sliceund.Null[*deeppatch.Address](),
+		Meta: sliceund.Defined(meta.MetaPatch{Note: sliceund.Defined("note")}),
+	}
+	ops, err := p.ToJSONPatch()
+	assert.NilError(t, err)
+	bin, err := json.Marshal(ops)
+	assert.NilError(t, err)
+	assert.Equal(
+		t,
+		`[`+
+			`{"op":"add","path":"/name","value":"foo"},`+
+			`{"op":"add","path":"/address/city","value":"Kyoto"},`+
+			`{"op":"add","path":"/address/geo","value":null},`+
+			`{"op":"add","path":"/home","value":null},`+
+			`{"op":"add","path":"/meta/note","value":"note"}`+
+			`]`,
+		string(bin),
+	)
+
+	var decoded deeppatch.UserPatch
+	assert.NilError(t, decoded.FromJSONPatch(ops))
+	org := deeppatch.User{
+		Name:    "bar",
+		Address: deeppatch.Address{City: "Tokyo", Street: und.Defined("Chuo"), Geo: deeppatch.Geo{Lat: 1}},
+		Home:    &deeppatch.Address{},
+		Meta:    meta.Meta{Version: 2},
+	}
+	assert.DeepEqual(t, p.ApplyPatch(org), decoded.ApplyPatch(org), compareUndString, compareOptionString)
+}
+
+func Test_jsonpatch_FromJSONPatch(t *testing.T) {
+	org := deeppatch.User{
+		Name:    "foo",
+		Address: deeppatch.Address{City: "Tokyo", Street: und.Defined("Chuo"), Geo: deeppatch.Geo{Lat: 1, Lng: 2}},
+		Pair:    deeppatch.Pair[int]{L: 1, R: 2},
+		Meta:    meta.Meta{Version: 1, Note: option.Some("note")},
+	}
+
+	type testCase struct {
+		name     string
+		ops      string
+		expected func(u deeppatch.User) deeppatch.User
+	}
+	for _, tc := range []testCase{
+		{
+			"nested path",
+			`[{"op":"replace","path":"/address/geo/lat","value":5},{"op":"add","path":"/pair/r","value":3}]`,
+			func(u deeppatch.User) deeppatch.User {
+				u.Address.Geo.Lat = 5
+				u.Pair.R = 3
+				return u
+			},
+		},
+		{
+			"replace whole nested",
+			`[{"op":"replace","path":"/address","value":{"city":"Kyoto"}}]`,
+			func(u deeppatch.User) deeppatch.User {
+				// undefined fields of und types are left unchanged.
+				u.Address = deeppatch.Address{City: "Kyoto", Street: u.Address.Street}
+				return u
+			},
+		},
+		{
+			"remove",
+			`[{"op":"remove","path":"/meta/note"},{"op":"remove","path":"/address/street"}]`,
+			func(u deeppatch.User) deeppatch.User {
+				u.Meta.Note = option.None[string]()
+				u.Address.Street = und.Null[string]()
+				return u
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var ops []jsonpatch.Operation
+			assert.NilError(t, json.Unmarshal([]byte(tc.ops), &ops))
+			var p deeppatch.UserPatch
+			assert.NilError(t, p.FromJSONPatch(ops))
+			assert.DeepEqual(t, tc.expected(org), p.ApplyPatch(org), compareUndString, compareOptionString)
+		})
+	}
+}
+
+func Test_jsonpatch_FromJSONPatch_error(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		ops  string
+		err  error
+	}{
+		{"unknown field", `[{"op":"add","path":"/unknown","value":1}]`, jsonpatch.ErrPathNotFound},
+		{"unknown nested field", `[{"op":"add","path":"/address/unknown","value":1}]`, jsonpatch.ErrPathNotFound},
+		{"inside of non deep field", `[{"op":"add","path":"/home/city","value":"foo"}]`, jsonpatch.ErrUnsupported},
+		{"move", `[{"op":"move","from":"/name","path":"/address/city"}]`, jsonpatch.ErrUnsupported},
+		{"copy", `[{"op":"copy","from":"/name","path":"/address/city"}]`, jsonpatch.ErrUnsupported},
+		{"test", `[{"op":"test","path":"/name","value":"foo"}]`, jsonpatch.ErrUnsupported},
+		{"test unknown field", `[{"op":"test","path":"/unknown","value":1}]`, jsonpatch.ErrUnsupported},
+		{"move after add", `[{"op":"add","path":"/name","value":"foo"},{"op":"move","from":"/name","path":"/home"}]`, jsonpatch.ErrUnsupported},
+		{"whole document", `[{"op":"replace","path":"","value":{}}]`, jsonpatch.ErrUnsupported},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var ops []jsonpatch.Operation
+			assert.NilError(t, json.Unmarshal([]byte(tc.ops), &ops))
+			var p deeppatch.UserPatch
+			assert.ErrorIs(t, p.FromJSONPatch(ops), tc.err)
+		})
+	}
+}
+
+func Test_jsonpatch_flat(t *testing.T) {
+	p := patchtarget.AllPatch{
+		Bar:     sliceund.Defined(ptr(5)),
+		Opt:     sliceund.Null[string](),
+		Und:     und.Defined("und"),
+		Elastic: elastic.FromValues("a", "b"),
+	}
+	ops, err := p.ToJSONPatch()
+	assert.NilError(t, err)
+	bin, err := json.Marshal(ops)
+	assert.NilError(t, err)
+	assert.Equal(
+		t,
+		`[`+
+			`{"op":"add","path":"/Bar","value":5},`+
+			`{"op":"add","path":"/opt","value":null},`+
+			`{"op":"add","path":"/und","value":"und"},`+
+			`{"op":"add","path":"/Elastic","value":["a","b"]}`+
+			`]`,
+		string(bin),
+	)
+
+	var decoded patchtarget.AllPatch
+	assert.NilError(t, decoded.FromJSONPatch(ops))
+	org := patchtarget.All{Foo: "foo"}
+	assert.DeepEqual(t, p.ApplyPatch(org), decoded.ApplyPatch(org), compareUndString, compareOptionString, compareElasticString)
+}
+
+func Test_jsonpatch_merge_patch(t *testing.T) {
+	// patch types are merge patch documents as they are.
+	var p deeppatch.UserPatch
+	assert.NilError(t, json.Unmarshal([]byte(`{"address":{"geo":{"lng":5}},"meta":null}`), &p))
+	org := deeppatch.User{
+		Name:    "foo",
+		Address: deeppatch.Address{City: "Tokyo", Geo: deeppatch.Geo{Lat: 1, Lng: 2}},
+		Meta:    meta.Meta{Version: 1},
+	}
+	expected := org
+	expected.Address.Geo.Lng = 5
+	expected.Meta = meta.Meta{}
+	assert.DeepEqual(t, expected, p.ApplyPatch(org), compareUndString, compareOptionString)
+
+	bin, err := json.Marshal(p)
+	assert.NilError(t, err)
+	assert.Equal(t, `{"address":{"geo":{"lng":5}},"meta":null}`, string(bin))
+}
+
+func Test_jsonpatch_diff(t *testing.T) {
+	before := patchtarget.All{Foo: "foo", Opt: option.Some("opt"), Und: und.Defined("und")}
+	after := patchtarget.All{Foo: "bar", Und: und.Null[string]()}
+
+	ops, err := patchtarget.DiffAll(before, after).ToJSONPatch()
+	assert.NilError(t, err)
+	bin, err := json.Marshal(ops)
+	assert.NilError(t, err)
+	assert.Equal(
+		t,
+		`[`+
+			`{"op":"add","path":"/Foo","value":"bar"},`+
+			`{"op":"add","path":"/opt","value":null},`+
+			`{"op":"add","path":"/und","value":null}`+
+			`]`,
+		string(bin),
+	)
+
+	var decoded patchtarget.AllPatch
+	assert.NilError(t, decoded.FromJSONPatch(ops))
+	assert.DeepEqual(t, after, decoded.ApplyPatch(before), compareUndString, compareOptionString, compareElasticString)
+}
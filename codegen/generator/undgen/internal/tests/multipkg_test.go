@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"maps"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ngicks/go-codegen/codegen/generator/undgen"
+	"github.com/ngicks/go-codegen/codegen/generator/undgen/internal/testtargets/multipkg"
+	"github.com/ngicks/go-codegen/codegen/generator/undgen/internal/testtargets/multipkg/item"
+	"github.com/ngicks/go-codegen/codegen/pkg/suffixwriter"
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/option"
+	"github.com/ngicks/und/sliceund"
+	"gotest.tools/v3/assert"
+)
+
+func Test_multipkg_patcher_type_names(t *testing.T) {
+	type testCase struct {
+		names    []string
+		expected []string
+	}
+	root, err := filepath.Abs("../testtargets")
+	assert.NilError(t, err)
+	for _, tc := range []testCase{
+		{[]string{"Order"}, []string{"multipkg/item/item.und_patch.go", "multipkg/multipkg.und_patch.go"}},
+		{[]string{"Item"}, []string{"multipkg/item/item.und_patch.go"}},
+		{
+			[]string{"github.com/ngicks/go-codegen/codegen/generator/undgen/internal/testtargets/multipkg.Order"},
+			[]string{"multipkg/multipkg.und_patch.go"},
+		},
+		{[]string{"example.com/unknown.Order"}, nil},
+	} {
+		t.Run(tc.names[0], func(t *testing.T) {
+			testPrinter := suffixwriter.NewTestWriter(".und_patch", suffixwriter.WithCwd("../testtargets"))
+			err := undgen.GeneratePatcher(
+				testPrinter.Writer,
+				false,
+				testTargets["multipkg"],
+				undgen.ConstUnd.Imports,
+				tc.names,
+			)
+			assert.NilError(t, err)
+			var names []string
+			for _, k := range slices.Sorted(maps.Keys(testPrinter.Results())) {
+				rel, err := filepath.Rel(root, k)
+				assert.NilError(t, err)
+				names = append(names, filepath.ToSlash(rel))
+			}
+			assert.DeepEqual(t, tc.expected, names)
+		})
+	}
+}
+
+func Test_multipkg_ApplyPatch(t *testing.T) {
+	org := multipkg.Order{
+		Id:   "foo",
+		Item: item.Item{Name: "bar", Count: und.Defined(5)},
+		Note: option.Some("baz"),
+	}
+	patched := multipkg.OrderPatch{
+		Item: sliceund.Defined(item.Item{Name: "qux"}),
+		Note: sliceund.Null[string](),
+	}.ApplyPatch(org)
+	assert.Equal(t, "foo", patched.Id)
+	assert.Equal(t, "qux", patched.Item.Name)
+	assert.Assert(t, patched.Item.Count.IsUndefined())
+	assert.Assert(t, patched.Note.IsNone())
+
+	items := item.OrderPatch{
+		Items: sliceund.Defined([]item.Item{{Name: "quux"}}),
+	}.ApplyPatch(item.Order{})
+	assert.Equal(t, 1, len(items.Items))
+	assert.Equal(t, "quux", items.Items[0].Name)
+}
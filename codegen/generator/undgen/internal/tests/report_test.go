@@ -0,0 +1,36 @@
+package tests
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ngicks/go-codegen/codegen/generator/undgen"
+	"github.com/ngicks/go-codegen/codegen/pkg/genreport"
+	"github.com/ngicks/go-codegen/codegen/pkg/suffixwriter"
+	"gotest.tools/v3/assert"
+)
+
+func Test_patcher_report(t *testing.T) {
+	const patchtarget = "github.com/ngicks/go-codegen/codegen/generator/undgen/internal/testtargets/patchtarget"
+
+	testPrinter := suffixwriter.NewTestWriter(".und_patcher", suffixwriter.WithCwd("../testtargets"))
+	run := (&genreport.Report{}).NewRun("undgen-patch")
+	err := undgen.GeneratePatcher(
+		testPrinter.Writer,
+		false,
+		testTargets["patchtarget"],
+		undgen.ConstUnd.Imports,
+		[]string{"All"},
+		undgen.WithReport(run),
+	)
+	assert.NilError(t, err)
+
+	var names []string
+	for _, ty := range run.Types {
+		if ty.PkgPath == patchtarget {
+			names = append(names, ty.Name)
+		}
+	}
+	slices.Sort(names)
+	assert.DeepEqual(t, []string{"All", "Hmm"}, names)
+}
@@ -0,0 +1,30 @@
+package deeppatch
+
+import (
+	"github.com/ngicks/go-codegen/codegen/generator/undgen/internal/testtargets/deeppatch/meta"
+	"github.com/ngicks/und"
+)
+
+type User struct {
+	Name    string    `json:"name"`
+	Address Address   `json:"address"`
+	Home    *Address  `json:"home"`
+	Pair    Pair[int] `json:"pair"`
+	Meta    meta.Meta `json:"meta"`
+}
+
+type Address struct {
+	City   string          `json:"city"`
+	Street und.Und[string] `json:"street"`
+	Geo    Geo             `json:"geo"`
+}
+
+type Geo struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+type Pair[T any] struct {
+	L T `json:"l"`
+	R T `json:"r"`
+}
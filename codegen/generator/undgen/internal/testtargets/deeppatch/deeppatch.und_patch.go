@@ -0,0 +1,458 @@
+// Code generated by github.com/ngicks/go-codegen/codegen DO NOT EDIT.
+// to regenerate the code, refer to help by invoking
+// go run github.com/ngicks/go-codegen/codegen undgen patch --help
+
+package deeppatch
+
+import (
+	"reflect"
+
+	"github.com/ngicks/go-codegen/codegen/generator/undgen/internal/testtargets/deeppatch/meta"
+	"github.com/ngicks/go-codegen/pkg/undgen/runtime/jsonpatch"
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/sliceund"
+)
+
+//codegen:generated
+type UserPatch struct {
+	Name    sliceund.Und[string]         `json:"name,omitempty"`
+	Address sliceund.Und[AddressPatch]   `json:"address,omitempty"`
+	Home    sliceund.Und[*Address]       `json:"home,omitempty"`
+	Pair    sliceund.Und[PairPatch[int]] `json:"pair,omitempty"`
+	Meta    sliceund.Und[meta.MetaPatch] `json:"meta,omitempty"`
+}
+
+//codegen:generated
+func (p *UserPatch) FromValue(v User) {
+	//nolint
+	*p = UserPatch{
+		Name:    sliceund.Defined(v.Name),
+		Address: sliceund.Defined(func() (nested AddressPatch) { nested.FromValue(v.Address); return }()),
+		Home:    sliceund.Defined(v.Home),
+		Pair:    sliceund.Defined(func() (nested PairPatch[int]) { nested.FromValue(v.Pair); return }()),
+		Meta:    sliceund.Defined(func() (nested meta.MetaPatch) { nested.FromValue(v.Meta); return }()),
+	}
+}
+
+//codegen:generated
+func (p UserPatch) ToValue() User {
+	//nolint
+	return User{
+		Name:    p.Name.Value(),
+		Address: p.Address.Value().ToValue(),
+		Home:    p.Home.Value(),
+		Pair:    p.Pair.Value().ToValue(),
+		Meta:    p.Meta.Value().ToValue(),
+	}
+}
+
+//codegen:generated
+func (p UserPatch) Merge(r UserPatch) UserPatch {
+	//nolint
+	return UserPatch{
+		Name: sliceund.FromOption(r.Name.Unwrap().Or(p.Name.Unwrap())),
+		Address: func() sliceund.Und[AddressPatch] {
+			if p.Address.IsDefined() && r.Address.IsDefined() {
+				return sliceund.Defined(p.Address.Value().Merge(r.Address.Value()))
+			}
+			return sliceund.FromOption(r.Address.Unwrap().Or(p.Address.Unwrap()))
+		}(),
+		Home: sliceund.FromOption(r.Home.Unwrap().Or(p.Home.Unwrap())),
+		Pair: func() sliceund.Und[PairPatch[int]] {
+			if p.Pair.IsDefined() && r.Pair.IsDefined() {
+				return sliceund.Defined(p.Pair.Value().Merge(r.Pair.Value()))
+			}
+			return sliceund.FromOption(r.Pair.Unwrap().Or(p.Pair.Unwrap()))
+		}(),
+		Meta: func() sliceund.Und[meta.MetaPatch] {
+			if p.Meta.IsDefined() && r.Meta.IsDefined() {
+				return sliceund.Defined(p.Meta.Value().Merge(r.Meta.Value()))
+			}
+			return sliceund.FromOption(r.Meta.Unwrap().Or(p.Meta.Unwrap()))
+		}(),
+	}
+}
+
+//codegen:generated
+func (p UserPatch) ApplyPatch(v User) User {
+	var orgP UserPatch
+	orgP.FromValue(v)
+	merged := orgP.Merge(p)
+	return merged.ToValue()
+}
+
+//codegen:generated
+func DiffUser(before, after User) UserPatch {
+	var p, full UserPatch
+	full.FromValue(after)
+	if before.Name != after.Name {
+		p.Name = full.Name
+	}
+	if before.Address != after.Address {
+		p.Address = sliceund.Defined(DiffAddress(before.Address, after.Address))
+	}
+	if before.Home != after.Home {
+		p.Home = full.Home
+	}
+	if before.Pair != after.Pair {
+		p.Pair = sliceund.Defined(DiffPair(before.Pair, after.Pair))
+	}
+	if before.Meta != after.Meta {
+		p.Meta = sliceund.Defined(meta.DiffMeta(before.Meta, after.Meta))
+	}
+	return p
+}
+
+//codegen:generated
+func (p UserPatch) ToJSONPatch() ([]jsonpatch.Operation, error) {
+	var (
+		ops []jsonpatch.Operation
+		err error
+	)
+	if ops, err = jsonpatch.AppendField(ops, "name", p.Name); err != nil {
+		return nil, err
+	}
+	if ops, err = jsonpatch.AppendNested(ops, "address", p.Address); err != nil {
+		return nil, err
+	}
+	if ops, err = jsonpatch.AppendField(ops, "home", p.Home); err != nil {
+		return nil, err
+	}
+	if ops, err = jsonpatch.AppendNested(ops, "pair", p.Pair); err != nil {
+		return nil, err
+	}
+	if ops, err = jsonpatch.AppendNested(ops, "meta", p.Meta); err != nil {
+		return nil, err
+	}
+	return ops, nil
+}
+
+//codegen:generated
+func (p *UserPatch) FromJSONPatch(ops []jsonpatch.Operation) error {
+	for _, op := range ops {
+		if err := jsonpatch.CheckOp(op); err != nil {
+			return err
+		}
+		name, rest, err := jsonpatch.Split(op.Path)
+		if err != nil {
+			return err
+		}
+		switch name {
+		case "name":
+			err = jsonpatch.DecodeField(op, rest, &p.Name)
+		case "address":
+			err = jsonpatch.DecodeNested[Address](op, rest, &p.Address)
+		case "home":
+			err = jsonpatch.DecodeField(op, rest, &p.Home)
+		case "pair":
+			err = jsonpatch.DecodeNested[Pair[int]](op, rest, &p.Pair)
+		case "meta":
+			err = jsonpatch.DecodeNested[meta.Meta](op, rest, &p.Meta)
+		default:
+			err = jsonpatch.PathNotFound(op)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//codegen:generated
+type AddressPatch struct {
+	City   sliceund.Und[string]   `json:"city,omitempty"`
+	Street und.Und[string]        `json:"street,omitzero"`
+	Geo    sliceund.Und[GeoPatch] `json:"geo,omitempty"`
+}
+
+//codegen:generated
+func (p *AddressPatch) FromValue(v Address) {
+	//nolint
+	*p = AddressPatch{
+		City:   sliceund.Defined(v.City),
+		Street: v.Street,
+		Geo:    sliceund.Defined(func() (nested GeoPatch) { nested.FromValue(v.Geo); return }()),
+	}
+}
+
+//codegen:generated
+func (p AddressPatch) ToValue() Address {
+	//nolint
+	return Address{
+		City:   p.City.Value(),
+		Street: p.Street,
+		Geo:    p.Geo.Value().ToValue(),
+	}
+}
+
+//codegen:generated
+func (p AddressPatch) Merge(r AddressPatch) AddressPatch {
+	//nolint
+	return AddressPatch{
+		City:   sliceund.FromOption(r.City.Unwrap().Or(p.City.Unwrap())),
+		Street: und.FromOption(r.Street.Unwrap().Or(p.Street.Unwrap())),
+		Geo: func() sliceund.Und[GeoPatch] {
+			if p.Geo.IsDefined() && r.Geo.IsDefined() {
+				return sliceund.Defined(p.Geo.Value().Merge(r.Geo.Value()))
+			}
+			return sliceund.FromOption(r.Geo.Unwrap().Or(p.Geo.Unwrap()))
+		}(),
+	}
+}
+
+//codegen:generated
+func (p AddressPatch) ApplyPatch(v Address) Address {
+	var orgP AddressPatch
+	orgP.FromValue(v)
+	merged := orgP.Merge(p)
+	return merged.ToValue()
+}
+
+//codegen:generated
+func DiffAddress(before, after Address) AddressPatch {
+	var p, full AddressPatch
+	full.FromValue(after)
+	if before.City != after.City {
+		p.City = full.City
+	}
+	if before.Street != after.Street {
+		p.Street = full.Street
+	}
+	if before.Geo != after.Geo {
+		p.Geo = sliceund.Defined(DiffGeo(before.Geo, after.Geo))
+	}
+	return p
+}
+
+//codegen:generated
+func (p AddressPatch) ToJSONPatch() ([]jsonpatch.Operation, error) {
+	var (
+		ops []jsonpatch.Operation
+		err error
+	)
+	if ops, err = jsonpatch.AppendField(ops, "city", p.City); err != nil {
+		return nil, err
+	}
+	if ops, err = jsonpatch.AppendField(ops, "street", p.Street); err != nil {
+		return nil, err
+	}
+	if ops, err = jsonpatch.AppendNested(ops, "geo", p.Geo); err != nil {
+		return nil, err
+	}
+	return ops, nil
+}
+
+//codegen:generated
+func (p *AddressPatch) FromJSONPatch(ops []jsonpatch.Operation) error {
+	for _, op := range ops {
+		if err := jsonpatch.CheckOp(op); err != nil {
+			return err
+		}
+		name, rest, err := jsonpatch.Split(op.Path)
+		if err != nil {
+			return err
+		}
+		switch name {
+		case "city":
+			err = jsonpatch.DecodeField(op, rest, &p.City)
+		case "street":
+			err = jsonpatch.DecodeField(op, rest, &p.Street)
+		case "geo":
+			err = jsonpatch.DecodeNested[Geo](op, rest, &p.Geo)
+		default:
+			err = jsonpatch.PathNotFound(op)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//codegen:generated
+type GeoPatch struct {
+	Lat sliceund.Und[float64] `json:"lat,omitempty"`
+	Lng sliceund.Und[float64] `json:"lng,omitempty"`
+}
+
+//codegen:generated
+func (p *GeoPatch) FromValue(v Geo) {
+	//nolint
+	*p = GeoPatch{
+		Lat: sliceund.Defined(v.Lat),
+		Lng: sliceund.Defined(v.Lng),
+	}
+}
+
+//codegen:generated
+func (p GeoPatch) ToValue() Geo {
+	//nolint
+	return Geo{
+		Lat: p.Lat.Value(),
+		Lng: p.Lng.Value(),
+	}
+}
+
+//codegen:generated
+func (p GeoPatch) Merge(r GeoPatch) GeoPatch {
+	//nolint
+	return GeoPatch{
+		Lat: sliceund.FromOption(r.Lat.Unwrap().Or(p.Lat.Unwrap())),
+		Lng: sliceund.FromOption(r.Lng.Unwrap().Or(p.Lng.Unwrap())),
+	}
+}
+
+//codegen:generated
+func (p GeoPatch) ApplyPatch(v Geo) Geo {
+	var orgP GeoPatch
+	orgP.FromValue(v)
+	merged := orgP.Merge(p)
+	return merged.ToValue()
+}
+
+//codegen:generated
+func DiffGeo(before, after Geo) GeoPatch {
+	var p, full GeoPatch
+	full.FromValue(after)
+	if before.Lat != after.Lat {
+		p.Lat = full.Lat
+	}
+	if before.Lng != after.Lng {
+		p.Lng = full.Lng
+	}
+	return p
+}
+
+//codegen:generated
+func (p GeoPatch) ToJSONPatch() ([]jsonpatch.Operation, error) {
+	var (
+		ops []jsonpatch.Operation
+		err error
+	)
+	if ops, err = jsonpatch.AppendField(ops, "lat", p.Lat); err != nil {
+		return nil, err
+	}
+	if ops, err = jsonpatch.AppendField(ops, "lng", p.Lng); err != nil {
+		return nil, err
+	}
+	return ops, nil
+}
+
+//codegen:generated
+func (p *GeoPatch) FromJSONPatch(ops []jsonpatch.Operation) error {
+	for _, op := range ops {
+		if err := jsonpatch.CheckOp(op); err != nil {
+			return err
+		}
+		name, rest, err := jsonpatch.Split(op.Path)
+		if err != nil {
+			return err
+		}
+		switch name {
+		case "lat":
+			err = jsonpatch.DecodeField(op, rest, &p.Lat)
+		case "lng":
+			err = jsonpatch.DecodeField(op, rest, &p.Lng)
+		default:
+			err = jsonpatch.PathNotFound(op)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//codegen:generated
+type PairPatch[T any] struct {
+	L sliceund.Und[T] `json:"l,omitempty"`
+	R sliceund.Und[T] `json:"r,omitempty"`
+}
+
+//codegen:generated
+func (p *PairPatch[T]) FromValue(v Pair[T]) {
+	//nolint
+	*p = PairPatch[T]{
+		L: sliceund.Defined(v.L),
+		R: sliceund.Defined(v.R),
+	}
+}
+
+//codegen:generated
+func (p PairPatch[T]) ToValue() Pair[T] {
+	//nolint
+	return Pair[T]{
+		L: p.L.Value(),
+		R: p.R.Value(),
+	}
+}
+
+//codegen:generated
+func (p PairPatch[T]) Merge(r PairPatch[T]) PairPatch[T] {
+	//nolint
+	return PairPatch[T]{
+		L: sliceund.FromOption(r.L.Unwrap().Or(p.L.Unwrap())),
+		R: sliceund.FromOption(r.R.Unwrap().Or(p.R.Unwrap())),
+	}
+}
+
+//codegen:generated
+func (p PairPatch[T]) ApplyPatch(v Pair[T]) Pair[T] {
+	var orgP PairPatch[T]
+	orgP.FromValue(v)
+	merged := orgP.Merge(p)
+	return merged.ToValue()
+}
+
+//codegen:generated
+func DiffPair[T any](before, after Pair[T]) PairPatch[T] {
+	var p, full PairPatch[T]
+	full.FromValue(after)
+	if !reflect.DeepEqual(before.L, after.L) {
+		p.L = full.L
+	}
+	if !reflect.DeepEqual(before.R, after.R) {
+		p.R = full.R
+	}
+	return p
+}
+
+//codegen:generated
+func (p PairPatch[T]) ToJSONPatch() ([]jsonpatch.Operation, error) {
+	var (
+		ops []jsonpatch.Operation
+		err error
+	)
+	if ops, err = jsonpatch.AppendField(ops, "l", p.L); err != nil {
+		return nil, err
+	}
+	if ops, err = jsonpatch.AppendField(ops, "r", p.R); err != nil {
+		return nil, err
+	}
+	return ops, nil
+}
+
+//codegen:generated
+func (p *PairPatch[T]) FromJSONPatch(ops []jsonpatch.Operation) error {
+	for _, op := range ops {
+		if err := jsonpatch.CheckOp(op); err != nil {
+			return err
+		}
+		name, rest, err := jsonpatch.Split(op.Path)
+		if err != nil {
+			return err
+		}
+		switch name {
+		case "l":
+			err = jsonpatch.DecodeField(op, rest, &p.L)
+		case "r":
+			err = jsonpatch.DecodeField(op, rest, &p.R)
+		default:
+			err = jsonpatch.PathNotFound(op)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,10 @@
+package meta
+
+import (
+	"github.com/ngicks/und/option"
+)
+
+type Meta struct {
+	Version int                   `json:"version"`
+	Note    option.Option[string] `json:"note"`
+}
@@ -0,0 +1,105 @@
+// Code generated by github.com/ngicks/go-codegen/codegen DO NOT EDIT.
+// to regenerate the code, refer to help by invoking
+// go run github.com/ngicks/go-codegen/codegen undgen patch --help
+
+package meta
+
+import (
+	"github.com/ngicks/go-codegen/pkg/undgen/runtime/jsonpatch"
+	"github.com/ngicks/und/option"
+	"github.com/ngicks/und/sliceund"
+)
+
+//codegen:generated
+type MetaPatch struct {
+	Version sliceund.Und[int]    `json:"version,omitempty"`
+	Note    sliceund.Und[string] `json:"note,omitempty"`
+}
+
+//codegen:generated
+func (p *MetaPatch) FromValue(v Meta) {
+	//nolint
+	*p = MetaPatch{
+		Version: sliceund.Defined(v.Version),
+		Note:    option.MapOr(v.Note, sliceund.Null[string](), sliceund.Defined[string]),
+	}
+}
+
+//codegen:generated
+func (p MetaPatch) ToValue() Meta {
+	//nolint
+	return Meta{
+		Version: p.Version.Value(),
+		Note:    option.Flatten(p.Note.Unwrap()),
+	}
+}
+
+//codegen:generated
+func (p MetaPatch) Merge(r MetaPatch) MetaPatch {
+	//nolint
+	return MetaPatch{
+		Version: sliceund.FromOption(r.Version.Unwrap().Or(p.Version.Unwrap())),
+		Note:    sliceund.FromOption(r.Note.Unwrap().Or(p.Note.Unwrap())),
+	}
+}
+
+//codegen:generated
+func (p MetaPatch) ApplyPatch(v Meta) Meta {
+	var orgP MetaPatch
+	orgP.FromValue(v)
+	merged := orgP.Merge(p)
+	return merged.ToValue()
+}
+
+//codegen:generated
+func DiffMeta(before, after Meta) MetaPatch {
+	var p, full MetaPatch
+	full.FromValue(after)
+	if before.Version != after.Version {
+		p.Version = full.Version
+	}
+	if before.Note != after.Note {
+		p.Note = full.Note
+	}
+	return p
+}
+
+//codegen:generated
+func (p MetaPatch) ToJSONPatch() ([]jsonpatch.Operation, error) {
+	var (
+		ops []jsonpatch.Operation
+		err error
+	)
+	if ops, err = jsonpatch.AppendField(ops, "version", p.Version); err != nil {
+		return nil, err
+	}
+	if ops, err = jsonpatch.AppendField(ops, "note", p.Note); err != nil {
+		return nil, err
+	}
+	return ops, nil
+}
+
+//codegen:generated
+func (p *MetaPatch) FromJSONPatch(ops []jsonpatch.Operation) error {
+	for _, op := range ops {
+		if err := jsonpatch.CheckOp(op); err != nil {
+			return err
+		}
+		name, rest, err := jsonpatch.Split(op.Path)
+		if err != nil {
+			return err
+		}
+		switch name {
+		case "version":
+			err = jsonpatch.DecodeField(op, rest, &p.Version)
+		case "note":
+			err = jsonpatch.DecodeField(op, rest, &p.Note)
+		default:
+			err = jsonpatch.PathNotFound(op)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
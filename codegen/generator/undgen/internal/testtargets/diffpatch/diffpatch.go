@@ -0,0 +1,45 @@
+package diffpatch
+
+import (
+	"slices"
+	"time"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/option"
+	"github.com/ngicks/und/sliceund"
+)
+
+type Event struct {
+	Id       string              `json:"id"`
+	At       time.Time           `json:"at"`
+	Tags     []string            `json:"tags"`
+	Payload  any                 `json:"payload"`
+	Point    Point               `json:"point"`
+	Opt      option.Option[int]  `json:"opt"`
+	Und      und.Und[string]     `json:"und"`
+	SliceUnd sliceund.Und[[]int] `json:"slice_und"`
+	Custom   Custom              `json:"custom"`
+}
+
+type Point struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type Custom struct {
+	Values []int `json:"values"`
+}
+
+// Equal ignores the order of values.
+func (c *Custom) Equal(o Custom) bool {
+	x, y := slices.Clone(c.Values), slices.Clone(o.Values)
+	slices.Sort(x)
+	slices.Sort(y)
+	return slices.Equal(x, y)
+}
+
+// Tagged embeds an und type, which is kept embedded in the patch type.
+type Tagged struct {
+	und.Und[string]
+	Name string `json:"name"`
+}
@@ -0,0 +1,273 @@
+// Code generated by github.com/ngicks/go-codegen/codegen DO NOT EDIT.
+// to regenerate the code, refer to help by invoking
+// go run github.com/ngicks/go-codegen/codegen undgen patch --help
+
+package diffpatch
+
+import (
+	"time"
+
+	"reflect"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/option"
+	"github.com/ngicks/und/sliceund"
+)
+
+//codegen:generated
+type EventPatch struct {
+	Id       sliceund.Und[string]    `json:"id,omitempty"`
+	At       sliceund.Und[time.Time] `json:"at,omitempty"`
+	Tags     sliceund.Und[[]string]  `json:"tags,omitempty"`
+	Payload  sliceund.Und[any]       `json:"payload,omitempty"`
+	Point    sliceund.Und[Point]     `json:"point,omitempty"`
+	Opt      sliceund.Und[int]       `json:"opt,omitempty"`
+	Und      und.Und[string]         `json:"und,omitzero"`
+	SliceUnd sliceund.Und[[]int]     `json:"slice_und,omitempty"`
+	Custom   sliceund.Und[Custom]    `json:"custom,omitempty"`
+}
+
+//codegen:generated
+func (p *EventPatch) FromValue(v Event) {
+	//nolint
+	*p = EventPatch{
+		Id:       sliceund.Defined(v.Id),
+		At:       sliceund.Defined(v.At),
+		Tags:     sliceund.Defined(v.Tags),
+		Payload:  sliceund.Defined(v.Payload),
+		Point:    sliceund.Defined(v.Point),
+		Opt:      option.MapOr(v.Opt, sliceund.Null[int](), sliceund.Defined[int]),
+		Und:      v.Und,
+		SliceUnd: v.SliceUnd,
+		Custom:   sliceund.Defined(v.Custom),
+	}
+}
+
+//codegen:generated
+func (p EventPatch) ToValue() Event {
+	//nolint
+	return Event{
+		Id:       p.Id.Value(),
+		At:       p.At.Value(),
+		Tags:     p.Tags.Value(),
+		Payload:  p.Payload.Value(),
+		Point:    p.Point.Value(),
+		Opt:      option.Flatten(p.Opt.Unwrap()),
+		Und:      p.Und,
+		SliceUnd: p.SliceUnd,
+		Custom:   p.Custom.Value(),
+	}
+}
+
+//codegen:generated
+func (p EventPatch) Merge(r EventPatch) EventPatch {
+	//nolint
+	return EventPatch{
+		Id:       sliceund.FromOption(r.Id.Unwrap().Or(p.Id.Unwrap())),
+		At:       sliceund.FromOption(r.At.Unwrap().Or(p.At.Unwrap())),
+		Tags:     sliceund.FromOption(r.Tags.Unwrap().Or(p.Tags.Unwrap())),
+		Payload:  sliceund.FromOption(r.Payload.Unwrap().Or(p.Payload.Unwrap())),
+		Point:    sliceund.FromOption(r.Point.Unwrap().Or(p.Point.Unwrap())),
+		Opt:      sliceund.FromOption(r.Opt.Unwrap().Or(p.Opt.Unwrap())),
+		Und:      und.FromOption(r.Und.Unwrap().Or(p.Und.Unwrap())),
+		SliceUnd: sliceund.FromOption(r.SliceUnd.Unwrap().Or(p.SliceUnd.Unwrap())),
+		Custom:   sliceund.FromOption(r.Custom.Unwrap().Or(p.Custom.Unwrap())),
+	}
+}
+
+//codegen:generated
+func (p EventPatch) ApplyPatch(v Event) Event {
+	var orgP EventPatch
+	orgP.FromValue(v)
+	merged := orgP.Merge(p)
+	return merged.ToValue()
+}
+
+//codegen:generated
+func DiffEvent(before, after Event) EventPatch {
+	var p, full EventPatch
+	full.FromValue(after)
+	if before.Id != after.Id {
+		p.Id = full.Id
+	}
+	if !before.At.Equal(after.At) {
+		p.At = full.At
+	}
+	if !reflect.DeepEqual(before.Tags, after.Tags) {
+		p.Tags = full.Tags
+	}
+	if !reflect.DeepEqual(before.Payload, after.Payload) {
+		p.Payload = full.Payload
+	}
+	if before.Point != after.Point {
+		p.Point = full.Point
+	}
+	if before.Opt != after.Opt {
+		p.Opt = full.Opt
+	}
+	if before.Und != after.Und {
+		p.Und = full.Und
+	}
+	if !reflect.DeepEqual(before.SliceUnd, after.SliceUnd) {
+		p.SliceUnd = full.SliceUnd
+	}
+	if !before.Custom.Equal(after.Custom) {
+		p.Custom = full.Custom
+	}
+	return p
+}
+
+//codegen:generated
+type PointPatch struct {
+	X sliceund.Und[int] `json:"x,omitempty"`
+	Y sliceund.Und[int] `json:"y,omitempty"`
+}
+
+//codegen:generated
+func (p *PointPatch) FromValue(v Point) {
+	//nolint
+	*p = PointPatch{
+		X: sliceund.Defined(v.X),
+		Y: sliceund.Defined(v.Y),
+	}
+}
+
+//codegen:generated
+func (p PointPatch) ToValue() Point {
+	//nolint
+	return Point{
+		X: p.X.Value(),
+		Y: p.Y.Value(),
+	}
+}
+
+//codegen:generated
+func (p PointPatch) Merge(r PointPatch) PointPatch {
+	//nolint
+	return PointPatch{
+		X: sliceund.FromOption(r.X.Unwrap().Or(p.X.Unwrap())),
+		Y: sliceund.FromOption(r.Y.Unwrap().Or(p.Y.Unwrap())),
+	}
+}
+
+//codegen:generated
+func (p PointPatch) ApplyPatch(v Point) Point {
+	var orgP PointPatch
+	orgP.FromValue(v)
+	merged := orgP.Merge(p)
+	return merged.ToValue()
+}
+
+//codegen:generated
+func DiffPoint(before, after Point) PointPatch {
+	var p, full PointPatch
+	full.FromValue(after)
+	if before.X != after.X {
+		p.X = full.X
+	}
+	if before.Y != after.Y {
+		p.Y = full.Y
+	}
+	return p
+}
+
+//codegen:generated
+type CustomPatch struct {
+	Values sliceund.Und[[]int] `json:"values,omitempty"`
+}
+
+//codegen:generated
+func (p *CustomPatch) FromValue(v Custom) {
+	//nolint
+	*p = CustomPatch{
+		Values: sliceund.Defined(v.Values),
+	}
+}
+
+//codegen:generated
+func (p CustomPatch) ToValue() Custom {
+	//nolint
+	return Custom{
+		Values: p.Values.Value(),
+	}
+}
+
+//codegen:generated
+func (p CustomPatch) Merge(r CustomPatch) CustomPatch {
+	//nolint
+	return CustomPatch{
+		Values: sliceund.FromOption(r.Values.Unwrap().Or(p.Values.Unwrap())),
+	}
+}
+
+//codegen:generated
+func (p CustomPatch) ApplyPatch(v Custom) Custom {
+	var orgP CustomPatch
+	orgP.FromValue(v)
+	merged := orgP.Merge(p)
+	return merged.ToValue()
+}
+
+//codegen:generated
+func DiffCustom(before, after Custom) CustomPatch {
+	var p, full CustomPatch
+	full.FromValue(after)
+	if !reflect.DeepEqual(before.Values, after.Values) {
+		p.Values = full.Values
+	}
+	return p
+}
+
+//codegen:generated
+type TaggedPatch struct {
+	und.Und[string]
+	Name sliceund.Und[string] `json:"name,omitempty"`
+}
+
+//codegen:generated
+func (p *TaggedPatch) FromValue(v Tagged) {
+	//nolint
+	*p = TaggedPatch{
+		Und:  v.Und,
+		Name: sliceund.Defined(v.Name),
+	}
+}
+
+//codegen:generated
+func (p TaggedPatch) ToValue() Tagged {
+	//nolint
+	return Tagged{
+		Und:  p.Und,
+		Name: p.Name.Value(),
+	}
+}
+
+//codegen:generated
+func (p TaggedPatch) Merge(r TaggedPatch) TaggedPatch {
+	//nolint
+	return TaggedPatch{
+		Und:  und.FromOption(r.Und.Unwrap().Or(p.Und.Unwrap())),
+		Name: sliceund.FromOption(r.Name.Unwrap().Or(p.Name.Unwrap())),
+	}
+}
+
+//codegen:generated
+func (p TaggedPatch) ApplyPatch(v Tagged) Tagged {
+	var orgP TaggedPatch
+	orgP.FromValue(v)
+	merged := orgP.Merge(p)
+	return merged.ToValue()
+}
+
+//codegen:generated
+func DiffTagged(before, after Tagged) TaggedPatch {
+	var p, full TaggedPatch
+	full.FromValue(after)
+	if before.Und != after.Und {
+		p.Und = full.Und
+	}
+	if before.Name != after.Name {
+		p.Name = full.Name
+	}
+	return p
+}
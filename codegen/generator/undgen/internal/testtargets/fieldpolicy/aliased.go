@@ -0,0 +1,14 @@
+package fieldpolicy
+
+import (
+	stdfmt "fmt"
+)
+
+// Label is in a file importing fmt under another name.
+type Label struct {
+	Text string `json:"text" patch:"nonnull"`
+}
+
+func (l Label) String() string {
+	return stdfmt.Sprintf("label(%s)", l.Text)
+}
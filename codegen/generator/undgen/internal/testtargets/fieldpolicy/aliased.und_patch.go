@@ -0,0 +1,110 @@
+// Code generated by github.com/ngicks/go-codegen/codegen DO NOT EDIT.
+// to regenerate the code, refer to help by invoking
+// go run github.com/ngicks/go-codegen/codegen undgen patch --help
+
+package fieldpolicy
+
+import (
+	stdfmt "fmt"
+
+	"github.com/ngicks/go-codegen/pkg/undgen/runtime/jsonpatch"
+	"github.com/ngicks/und/sliceund"
+	"github.com/ngicks/und/undtag"
+	"github.com/ngicks/und/validate"
+)
+
+//codegen:generated
+type LabelPatch struct {
+	Text sliceund.Und[string] `json:"text,omitempty" patch:"nonnull"`
+}
+
+//codegen:generated
+func (p *LabelPatch) FromValue(v Label) {
+	//nolint
+	*p = LabelPatch{
+		Text: sliceund.Defined(v.Text),
+	}
+}
+
+//codegen:generated
+func (p LabelPatch) ToValue() Label {
+	//nolint
+	return Label{
+		Text: p.Text.Value(),
+	}
+}
+
+//codegen:generated
+func (p LabelPatch) Merge(r LabelPatch) LabelPatch {
+	//nolint
+	return LabelPatch{
+		Text: sliceund.FromOption(r.Text.Unwrap().Or(p.Text.Unwrap())),
+	}
+}
+
+//codegen:generated
+func (p LabelPatch) ApplyPatch(v Label) Label {
+	var orgP LabelPatch
+	orgP.FromValue(v)
+	merged := orgP.Merge(p)
+	return merged.ToValue()
+}
+
+//codegen:generated
+func (p LabelPatch) UndValidate() (err error) {
+	{
+		validator := undtag.UndOptExport{States: &undtag.StateValidator{Def: true, Und: true}}.Into()
+		if !validator.ValidUnd(p.Text) {
+			err = stdfmt.Errorf("%s: value is %s", validator.Describe(), validate.ReportState(p.Text))
+		}
+		if err != nil {
+			return validate.AppendValidationErrorDot(err, "text")
+		}
+	}
+	return
+}
+
+//codegen:generated
+func DiffLabel(before, after Label) LabelPatch {
+	var p, full LabelPatch
+	full.FromValue(after)
+	if before.Text != after.Text {
+		p.Text = full.Text
+	}
+	return p
+}
+
+//codegen:generated
+func (p LabelPatch) ToJSONPatch() ([]jsonpatch.Operation, error) {
+	var (
+		ops []jsonpatch.Operation
+		err error
+	)
+	if ops, err = jsonpatch.AppendField(ops, "text", p.Text); err != nil {
+		return nil, err
+	}
+	return ops, nil
+}
+
+//codegen:generated
+func (p *LabelPatch) FromJSONPatch(ops []jsonpatch.Operation) error {
+	for _, op := range ops {
+		if err := jsonpatch.CheckOp(op); err != nil {
+			return err
+		}
+		name, rest, err := jsonpatch.Split(op.Path)
+		if err != nil {
+			return err
+		}
+		switch name {
+		case "text":
+			err = jsonpatch.DecodeField(op, rest, &p.Text)
+		default:
+			err = jsonpatch.PathNotFound(op)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
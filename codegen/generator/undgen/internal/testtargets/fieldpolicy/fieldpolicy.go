@@ -0,0 +1,31 @@
+package fieldpolicy
+
+import (
+	"time"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/elastic"
+	"github.com/ngicks/und/option"
+)
+
+type Item struct {
+	Id        string                  `json:"id" patch:"readonly"`
+	CreatedAt time.Time               `json:"created_at" patch:"-"`
+	Name      string                  `json:"name" patch:"nonnull"`
+	Note      option.Option[string]   `json:"note"`
+	Alias     und.Und[string]         `json:"alias" patch:"nonnull"`
+	Labels    elastic.Elastic[string] `json:"labels" patch:"nonnull"`
+	Owner     Owner                   `json:"owner"`
+}
+
+type Owner struct {
+	Id        string    `json:"id" patch:"readonly"`
+	Name      string    `json:"name" patch:"nonnull"`
+	CreatedAt time.Time `json:"created_at" patch:"-"`
+}
+
+// Archive has only omitted fields and fields without policies.
+type Archive struct {
+	Key  string `json:"key" patch:"-"`
+	Body string `json:"body"`
+}
@@ -0,0 +1,445 @@
+// Code generated by github.com/ngicks/go-codegen/codegen DO NOT EDIT.
+// to regenerate the code, refer to help by invoking
+// go run github.com/ngicks/go-codegen/codegen undgen patch --help
+
+package fieldpolicy
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/ngicks/go-codegen/pkg/undgen/runtime/jsonpatch"
+	"github.com/ngicks/go-codegen/pkg/undgen/runtime/patchpolicy"
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/elastic"
+	"github.com/ngicks/und/option"
+	"github.com/ngicks/und/sliceund"
+	"github.com/ngicks/und/undtag"
+	"github.com/ngicks/und/validate"
+)
+
+//codegen:generated
+type ItemPatch struct {
+	Id     sliceund.Und[string]     `json:"id,omitempty" patch:"readonly"`
+	Name   sliceund.Und[string]     `json:"name,omitempty" patch:"nonnull"`
+	Note   sliceund.Und[string]     `json:"note,omitempty"`
+	Alias  und.Und[string]          `json:"alias,omitzero" patch:"nonnull"`
+	Labels elastic.Elastic[string]  `json:"labels,omitzero" patch:"nonnull"`
+	Owner  sliceund.Und[OwnerPatch] `json:"owner,omitempty"`
+}
+
+//codegen:generated
+func (p *ItemPatch) FromValue(v Item) {
+	//nolint
+	*p = ItemPatch{
+		Id:     sliceund.Defined(v.Id),
+		Name:   sliceund.Defined(v.Name),
+		Note:   option.MapOr(v.Note, sliceund.Null[string](), sliceund.Defined[string]),
+		Alias:  v.Alias,
+		Labels: v.Labels,
+		Owner:  sliceund.Defined(func() (nested OwnerPatch) { nested.FromValue(v.Owner); return }()),
+	}
+}
+
+//codegen:generated
+func (p ItemPatch) ToValue() Item {
+	//nolint
+	return Item{
+		Id:     p.Id.Value(),
+		Name:   p.Name.Value(),
+		Note:   option.Flatten(p.Note.Unwrap()),
+		Alias:  p.Alias,
+		Labels: p.Labels,
+		Owner:  p.Owner.Value().ToValue(),
+	}
+}
+
+//codegen:generated
+func (p ItemPatch) Merge(r ItemPatch) ItemPatch {
+	//nolint
+	return ItemPatch{
+		Id:     sliceund.FromOption(r.Id.Unwrap().Or(p.Id.Unwrap())),
+		Name:   sliceund.FromOption(r.Name.Unwrap().Or(p.Name.Unwrap())),
+		Note:   sliceund.FromOption(r.Note.Unwrap().Or(p.Note.Unwrap())),
+		Alias:  und.FromOption(r.Alias.Unwrap().Or(p.Alias.Unwrap())),
+		Labels: elastic.FromUnd(und.FromOption(r.Labels.Unwrap().Unwrap().Or(p.Labels.Unwrap().Unwrap()))),
+		Owner: func() sliceund.Und[OwnerPatch] {
+			if p.Owner.IsDefined() && r.Owner.IsDefined() {
+				return sliceund.Defined(p.Owner.Value().Merge(r.Owner.Value()))
+			}
+			return sliceund.FromOption(r.Owner.Unwrap().Or(p.Owner.Unwrap()))
+		}(),
+	}
+}
+
+//codegen:generated
+func (p ItemPatch) ApplyPatch(v Item) Item {
+	var orgP ItemPatch
+	orgP.FromValue(v)
+	merged := orgP.Merge(p)
+	out := merged.ToValue()
+	out.CreatedAt = v.CreatedAt
+	if !p.Owner.IsNull() {
+		out.Owner = p.Owner.Value().ApplyPatch(v.Owner)
+	}
+	return out
+}
+
+//codegen:generated
+func (p ItemPatch) ApplyPatchChecked(v Item) (Item, error) {
+	if err := p.CheckReadOnly(); err != nil {
+		return v, err
+	}
+	return p.ApplyPatch(v), nil
+}
+
+//codegen:generated
+func (p ItemPatch) CheckReadOnly() error {
+	if !p.Id.IsUndefined() {
+		return validate.AppendValidationErrorDot(patchpolicy.ErrReadOnly, "id")
+	}
+	if p.Owner.IsNull() {
+		return validate.AppendValidationErrorDot(patchpolicy.ErrReadOnly, "owner")
+	}
+	if p.Owner.IsDefined() {
+		if err := p.Owner.Value().CheckReadOnly(); err != nil {
+			return validate.AppendValidationErrorDot(err, "owner")
+		}
+	}
+	return nil
+}
+
+//codegen:generated
+func (p ItemPatch) UndValidate() (err error) {
+	{
+		validator := undtag.UndOptExport{States: &undtag.StateValidator{Def: true, Und: true}}.Into()
+		if !validator.ValidUnd(p.Name) {
+			err = fmt.Errorf("%s: value is %s", validator.Describe(), validate.ReportState(p.Name))
+		}
+		if err != nil {
+			return validate.AppendValidationErrorDot(err, "name")
+		}
+	}
+	{
+		validator := undtag.UndOptExport{States: &undtag.StateValidator{Def: true, Und: true}}.Into()
+		if !validator.ValidUnd(p.Alias) {
+			err = fmt.Errorf("%s: value is %s", validator.Describe(), validate.ReportState(p.Alias))
+		}
+		if err != nil {
+			return validate.AppendValidationErrorDot(err, "alias")
+		}
+	}
+	{
+		validator := undtag.UndOptExport{States: &undtag.StateValidator{Def: true, Und: true}}.Into()
+		if !validator.ValidElastic(p.Labels) {
+			err = fmt.Errorf("%s: value is %s", validator.Describe(), validate.ReportState(p.Labels))
+		}
+		if err != nil {
+			return validate.AppendValidationErrorDot(err, "labels")
+		}
+	}
+	{
+		if err == nil && p.Owner.IsDefined() {
+			err = p.Owner.Value().UndValidate()
+		}
+		if err != nil {
+			return validate.AppendValidationErrorDot(err, "owner")
+		}
+	}
+	return
+}
+
+//codegen:generated
+func DiffItem(before, after Item) ItemPatch {
+	var p, full ItemPatch
+	full.FromValue(after)
+	if before.Id != after.Id {
+		p.Id = full.Id
+	}
+	if before.Name != after.Name {
+		p.Name = full.Name
+	}
+	if before.Note != after.Note {
+		p.Note = full.Note
+	}
+	if before.Alias != after.Alias {
+		p.Alias = full.Alias
+	}
+	if !reflect.DeepEqual(before.Labels, after.Labels) {
+		p.Labels = full.Labels
+	}
+	if before.Owner != after.Owner {
+		p.Owner = sliceund.Defined(DiffOwner(before.Owner, after.Owner))
+	}
+	return p
+}
+
+//codegen:generated
+func (p ItemPatch) ToJSONPatch() ([]jsonpatch.Operation, error) {
+	var (
+		ops []jsonpatch.Operation
+		err error
+	)
+	if ops, err = jsonpatch.AppendField(ops, "id", p.Id); err != nil {
+		return nil, err
+	}
+	if ops, err = jsonpatch.AppendField(ops, "name", p.Name); err != nil {
+		return nil, err
+	}
+	if ops, err = jsonpatch.AppendField(ops, "note", p.Note); err != nil {
+		return nil, err
+	}
+	if ops, err = jsonpatch.AppendField(ops, "alias", p.Alias); err != nil {
+		return nil, err
+	}
+	if ops, err = jsonpatch.AppendField(ops, "labels", p.Labels); err != nil {
+		return nil, err
+	}
+	if ops, err = jsonpatch.AppendNested(ops, "owner", p.Owner); err != nil {
+		return nil, err
+	}
+	return ops, nil
+}
+
+//codegen:generated
+func (p *ItemPatch) FromJSONPatch(ops []jsonpatch.Operation) error {
+	for _, op := range ops {
+		if err := jsonpatch.CheckOp(op); err != nil {
+			return err
+		}
+		name, rest, err := jsonpatch.Split(op.Path)
+		if err != nil {
+			return err
+		}
+		switch name {
+		case "id":
+			err = jsonpatch.DecodeField(op, rest, &p.Id)
+		case "name":
+			err = jsonpatch.DecodeField(op, rest, &p.Name)
+		case "note":
+			err = jsonpatch.DecodeField(op, rest, &p.Note)
+		case "alias":
+			err = jsonpatch.DecodeField(op, rest, &p.Alias)
+		case "labels":
+			err = jsonpatch.DecodeField(op, rest, &p.Labels)
+		case "owner":
+			err = jsonpatch.DecodeNested[Owner](op, rest, &p.Owner)
+		default:
+			err = jsonpatch.PathNotFound(op)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//codegen:generated
+type OwnerPatch struct {
+	Id   sliceund.Und[string] `json:"id,omitempty" patch:"readonly"`
+	Name sliceund.Und[string] `json:"name,omitempty" patch:"nonnull"`
+}
+
+//codegen:generated
+func (p *OwnerPatch) FromValue(v Owner) {
+	//nolint
+	*p = OwnerPatch{
+		Id:   sliceund.Defined(v.Id),
+		Name: sliceund.Defined(v.Name),
+	}
+}
+
+//codegen:generated
+func (p OwnerPatch) ToValue() Owner {
+	//nolint
+	return Owner{
+		Id:   p.Id.Value(),
+		Name: p.Name.Value(),
+	}
+}
+
+//codegen:generated
+func (p OwnerPatch) Merge(r OwnerPatch) OwnerPatch {
+	//nolint
+	return OwnerPatch{
+		Id:   sliceund.FromOption(r.Id.Unwrap().Or(p.Id.Unwrap())),
+		Name: sliceund.FromOption(r.Name.Unwrap().Or(p.Name.Unwrap())),
+	}
+}
+
+//codegen:generated
+func (p OwnerPatch) ApplyPatch(v Owner) Owner {
+	var orgP OwnerPatch
+	orgP.FromValue(v)
+	merged := orgP.Merge(p)
+	out := merged.ToValue()
+	out.CreatedAt = v.CreatedAt
+	return out
+}
+
+//codegen:generated
+func (p OwnerPatch) ApplyPatchChecked(v Owner) (Owner, error) {
+	if err := p.CheckReadOnly(); err != nil {
+		return v, err
+	}
+	return p.ApplyPatch(v), nil
+}
+
+//codegen:generated
+func (p OwnerPatch) CheckReadOnly() error {
+	if !p.Id.IsUndefined() {
+		return validate.AppendValidationErrorDot(patchpolicy.ErrReadOnly, "id")
+	}
+	return nil
+}
+
+//codegen:generated
+func (p OwnerPatch) UndValidate() (err error) {
+	{
+		validator := undtag.UndOptExport{States: &undtag.StateValidator{Def: true, Und: true}}.Into()
+		if !validator.ValidUnd(p.Name) {
+			err = fmt.Errorf("%s: value is %s", validator.Describe(), validate.ReportState(p.Name))
+		}
+		if err != nil {
+			return validate.AppendValidationErrorDot(err, "name")
+		}
+	}
+	return
+}
+
+//codegen:generated
+func DiffOwner(before, after Owner) OwnerPatch {
+	var p, full OwnerPatch
+	full.FromValue(after)
+	if before.Id != after.Id {
+		p.Id = full.Id
+	}
+	if before.Name != after.Name {
+		p.Name = full.Name
+	}
+	return p
+}
+
+//codegen:generated
+func (p OwnerPatch) ToJSONPatch() ([]jsonpatch.Operation, error) {
+	var (
+		ops []jsonpatch.Operation
+		err error
+	)
+	if ops, err = jsonpatch.AppendField(ops, "id", p.Id); err != nil {
+		return nil, err
+	}
+	if ops, err = jsonpatch.AppendField(ops, "name", p.Name); err != nil {
+		return nil, err
+	}
+	return ops, nil
+}
+
+//codegen:generated
+func (p *OwnerPatch) FromJSONPatch(ops []jsonpatch.Operation) error {
+	for _, op := range ops {
+		if err := jsonpatch.CheckOp(op); err != nil {
+			return err
+		}
+		name, rest, err := jsonpatch.Split(op.Path)
+		if err != nil {
+			return err
+		}
+		switch name {
+		case "id":
+			err = jsonpatch.DecodeField(op, rest, &p.Id)
+		case "name":
+			err = jsonpatch.DecodeField(op, rest, &p.Name)
+		default:
+			err = jsonpatch.PathNotFound(op)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//codegen:generated
+type ArchivePatch struct {
+	Body sliceund.Und[string] `json:"body,omitempty"`
+}
+
+//codegen:generated
+func (p *ArchivePatch) FromValue(v Archive) {
+	//nolint
+	*p = ArchivePatch{
+		Body: sliceund.Defined(v.Body),
+	}
+}
+
+//codegen:generated
+func (p ArchivePatch) ToValue() Archive {
+	//nolint
+	return Archive{
+		Body: p.Body.Value(),
+	}
+}
+
+//codegen:generated
+func (p ArchivePatch) Merge(r ArchivePatch) ArchivePatch {
+	//nolint
+	return ArchivePatch{
+		Body: sliceund.FromOption(r.Body.Unwrap().Or(p.Body.Unwrap())),
+	}
+}
+
+//codegen:generated
+func (p ArchivePatch) ApplyPatch(v Archive) Archive {
+	var orgP ArchivePatch
+	orgP.FromValue(v)
+	merged := orgP.Merge(p)
+	out := merged.ToValue()
+	out.Key = v.Key
+	return out
+}
+
+//codegen:generated
+func DiffArchive(before, after Archive) ArchivePatch {
+	var p, full ArchivePatch
+	full.FromValue(after)
+	if before.Body != after.Body {
+		p.Body = full.Body
+	}
+	return p
+}
+
+//codegen:generated
+func (p ArchivePatch) ToJSONPatch() ([]jsonpatch.Operation, error) {
+	var (
+		ops []jsonpatch.Operation
+		err error
+	)
+	if ops, err = jsonpatch.AppendField(ops, "body", p.Body); err != nil {
+		return nil, err
+	}
+	return ops, nil
+}
+
+//codegen:generated
+func (p *ArchivePatch) FromJSONPatch(ops []jsonpatch.Operation) error {
+	for _, op := range ops {
+		if err := jsonpatch.CheckOp(op); err != nil {
+			return err
+		}
+		name, rest, err := jsonpatch.Split(op.Path)
+		if err != nil {
+			return err
+		}
+		switch name {
+		case "body":
+			err = jsonpatch.DecodeField(op, rest, &p.Body)
+		default:
+			err = jsonpatch.PathNotFound(op)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+package item
+
+import (
+	"github.com/ngicks/und"
+)
+
+type Item struct {
+	Name  string       `json:"name"`
+	Count und.Und[int] `json:"count"`
+}
+
+// Order has the same name as multipkg.Order.
+type Order struct {
+	Items []Item `json:"items"`
+}
@@ -0,0 +1,88 @@
+// Code generated by github.com/ngicks/go-codegen/codegen DO NOT EDIT.
+// to regenerate the code, refer to help by invoking
+// go run github.com/ngicks/go-codegen/codegen undgen patch --help
+
+package item
+
+import (
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/sliceund"
+)
+
+//codegen:generated
+type ItemPatch struct {
+	Name  sliceund.Und[string] `json:"name,omitempty"`
+	Count und.Und[int]         `json:"count,omitzero"`
+}
+
+//codegen:generated
+func (p *ItemPatch) FromValue(v Item) {
+	//nolint
+	*p = ItemPatch{
+		Name:  sliceund.Defined(v.Name),
+		Count: v.Count,
+	}
+}
+
+//codegen:generated
+func (p ItemPatch) ToValue() Item {
+	//nolint
+	return Item{
+		Name:  p.Name.Value(),
+		Count: p.Count,
+	}
+}
+
+//codegen:generated
+func (p ItemPatch) Merge(r ItemPatch) ItemPatch {
+	//nolint
+	return ItemPatch{
+		Name:  sliceund.FromOption(r.Name.Unwrap().Or(p.Name.Unwrap())),
+		Count: und.FromOption(r.Count.Unwrap().Or(p.Count.Unwrap())),
+	}
+}
+
+//codegen:generated
+func (p ItemPatch) ApplyPatch(v Item) Item {
+	var orgP ItemPatch
+	orgP.FromValue(v)
+	merged := orgP.Merge(p)
+	return merged.ToValue()
+}
+
+//codegen:generated
+type OrderPatch struct {
+	Items sliceund.Und[[]Item] `json:"items,omitempty"`
+}
+
+//codegen:generated
+func (p *OrderPatch) FromValue(v Order) {
+	//nolint
+	*p = OrderPatch{
+		Items: sliceund.Defined(v.Items),
+	}
+}
+
+//codegen:generated
+func (p OrderPatch) ToValue() Order {
+	//nolint
+	return Order{
+		Items: p.Items.Value(),
+	}
+}
+
+//codegen:generated
+func (p OrderPatch) Merge(r OrderPatch) OrderPatch {
+	//nolint
+	return OrderPatch{
+		Items: sliceund.FromOption(r.Items.Unwrap().Or(p.Items.Unwrap())),
+	}
+}
+
+//codegen:generated
+func (p OrderPatch) ApplyPatch(v Order) Order {
+	var orgP OrderPatch
+	orgP.FromValue(v)
+	merged := orgP.Merge(p)
+	return merged.ToValue()
+}
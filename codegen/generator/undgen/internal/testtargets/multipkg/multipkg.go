@@ -0,0 +1,12 @@
+package multipkg
+
+import (
+	"github.com/ngicks/go-codegen/codegen/generator/undgen/internal/testtargets/multipkg/item"
+	"github.com/ngicks/und/option"
+)
+
+type Order struct {
+	Id   string                `json:"id"`
+	Item item.Item             `json:"item"`
+	Note option.Option[string] `json:"note"`
+}
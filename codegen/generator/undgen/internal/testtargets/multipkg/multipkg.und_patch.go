@@ -0,0 +1,56 @@
+// Code generated by github.com/ngicks/go-codegen/codegen DO NOT EDIT.
+// to regenerate the code, refer to help by invoking
+// go run github.com/ngicks/go-codegen/codegen undgen patch --help
+
+package multipkg
+
+import (
+	"github.com/ngicks/go-codegen/codegen/generator/undgen/internal/testtargets/multipkg/item"
+	"github.com/ngicks/und/option"
+	"github.com/ngicks/und/sliceund"
+)
+
+//codegen:generated
+type OrderPatch struct {
+	Id   sliceund.Und[string]    `json:"id,omitempty"`
+	Item sliceund.Und[item.Item] `json:"item,omitempty"`
+	Note sliceund.Und[string]    `json:"note,omitempty"`
+}
+
+//codegen:generated
+func (p *OrderPatch) FromValue(v Order) {
+	//nolint
+	*p = OrderPatch{
+		Id:   sliceund.Defined(v.Id),
+		Item: sliceund.Defined(v.Item),
+		Note: option.MapOr(v.Note, sliceund.Null[string](), sliceund.Defined[string]),
+	}
+}
+
+//codegen:generated
+func (p OrderPatch) ToValue() Order {
+	//nolint
+	return Order{
+		Id:   p.Id.Value(),
+		Item: p.Item.Value(),
+		Note: option.Flatten(p.Note.Unwrap()),
+	}
+}
+
+//codegen:generated
+func (p OrderPatch) Merge(r OrderPatch) OrderPatch {
+	//nolint
+	return OrderPatch{
+		Id:   sliceund.FromOption(r.Id.Unwrap().Or(p.Id.Unwrap())),
+		Item: sliceund.FromOption(r.Item.Unwrap().Or(p.Item.Unwrap())),
+		Note: sliceund.FromOption(r.Note.Unwrap().Or(p.Note.Unwrap())),
+	}
+}
+
+//codegen:generated
+func (p OrderPatch) ApplyPatch(v Order) Order {
+	var orgP OrderPatch
+	orgP.FromValue(v)
+	merged := orgP.Merge(p)
+	return merged.ToValue()
+}
@@ -5,6 +5,9 @@
 package patchtarget
 
 import (
+	"reflect"
+
+	"github.com/ngicks/go-codegen/pkg/undgen/runtime/jsonpatch"
 	"github.com/ngicks/und"
 	"github.com/ngicks/und/elastic"
 	"github.com/ngicks/und/option"
@@ -81,6 +84,115 @@ func (p AllPatch) ApplyPatch(v All) All {
 	return merged.ToValue()
 }
 
+//codegen:generated
+func DiffAll(before, after All) AllPatch {
+	var p, full AllPatch
+	full.FromValue(after)
+	if before.Foo != after.Foo {
+		p.Foo = full.Foo
+	}
+	if before.Bar != after.Bar {
+		p.Bar = full.Bar
+	}
+	if before.Baz != after.Baz {
+		p.Baz = full.Baz
+	}
+	if !reflect.DeepEqual(before.Qux, after.Qux) {
+		p.Qux = full.Qux
+	}
+	if before.Opt != after.Opt {
+		p.Opt = full.Opt
+	}
+	if before.Und != after.Und {
+		p.Und = full.Und
+	}
+	if !reflect.DeepEqual(before.Elastic, after.Elastic) {
+		p.Elastic = full.Elastic
+	}
+	if !reflect.DeepEqual(before.SliceUnd, after.SliceUnd) {
+		p.SliceUnd = full.SliceUnd
+	}
+	if !reflect.DeepEqual(before.SliceElastic, after.SliceElastic) {
+		p.SliceElastic = full.SliceElastic
+	}
+	return p
+}
+
+//codegen:generated
+func (p AllPatch) ToJSONPatch() ([]jsonpatch.Operation, error) {
+	var (
+		ops []jsonpatch.Operation
+		err error
+	)
+	if ops, err = jsonpatch.AppendField(ops, "Foo", p.Foo); err != nil {
+		return nil, err
+	}
+	if ops, err = jsonpatch.AppendField(ops, "Bar", p.Bar); err != nil {
+		return nil, err
+	}
+	if ops, err = jsonpatch.AppendField(ops, "baz", p.Baz); err != nil {
+		return nil, err
+	}
+	if ops, err = jsonpatch.AppendField(ops, "Qux", p.Qux); err != nil {
+		return nil, err
+	}
+	if ops, err = jsonpatch.AppendField(ops, "opt", p.Opt); err != nil {
+		return nil, err
+	}
+	if ops, err = jsonpatch.AppendField(ops, "und", p.Und); err != nil {
+		return nil, err
+	}
+	if ops, err = jsonpatch.AppendField(ops, "Elastic", p.Elastic); err != nil {
+		return nil, err
+	}
+	if ops, err = jsonpatch.AppendField(ops, "SliceUnd", p.SliceUnd); err != nil {
+		return nil, err
+	}
+	if ops, err = jsonpatch.AppendField(ops, "SliceElastic", p.SliceElastic); err != nil {
+		return nil, err
+	}
+	return ops, nil
+}
+
+//codegen:generated
+func (p *AllPatch) FromJSONPatch(ops []jsonpatch.Operation) error {
+	for _, op := range ops {
+		if err := jsonpatch.CheckOp(op); err != nil {
+			return err
+		}
+		name, rest, err := jsonpatch.Split(op.Path)
+		if err != nil {
+			return err
+		}
+		switch name {
+		case "Foo":
+			err = jsonpatch.DecodeField(op, rest, &p.Foo)
+		case "Bar":
+			err = jsonpatch.DecodeField(op, rest, &p.Bar)
+		case "baz":
+			err = jsonpatch.DecodeField(op, rest, &p.Baz)
+		case "Qux":
+			err = jsonpatch.DecodeField(op, rest, &p.Qux)
+		case "opt":
+			err = jsonpatch.DecodeField(op, rest, &p.Opt)
+		case "und":
+			err = jsonpatch.DecodeField(op, rest, &p.Und)
+		case "Elastic":
+			err = jsonpatch.DecodeField(op, rest, &p.Elastic)
+		case "SliceUnd":
+			err = jsonpatch.DecodeField(op, rest, &p.SliceUnd)
+		case "SliceElastic":
+			err = jsonpatch.DecodeField(op, rest, &p.SliceElastic)
+		default:
+			err = jsonpatch.PathNotFound(op)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 //codegen:generated
 type HmmPatch struct {
 	Ah sliceund.Und[Ignored] `json:",omitempty"`
@@ -117,3 +229,48 @@ func (p HmmPatch) ApplyPatch(v Hmm) Hmm {
 	merged := orgP.Merge(p)
 	return merged.ToValue()
 }
+
+//codegen:generated
+func DiffHmm(before, after Hmm) HmmPatch {
+	var p, full HmmPatch
+	full.FromValue(after)
+	if before.Ah != after.Ah {
+		p.Ah = full.Ah
+	}
+	return p
+}
+
+//codegen:generated
+func (p HmmPatch) ToJSONPatch() ([]jsonpatch.Operation, error) {
+	var (
+		ops []jsonpatch.Operation
+		err error
+	)
+	if ops, err = jsonpatch.AppendField(ops, "Ah", p.Ah); err != nil {
+		return nil, err
+	}
+	return ops, nil
+}
+
+//codegen:generated
+func (p *HmmPatch) FromJSONPatch(ops []jsonpatch.Operation) error {
+	for _, op := range ops {
+		if err := jsonpatch.CheckOp(op); err != nil {
+			return err
+		}
+		name, rest, err := jsonpatch.Split(op.Path)
+		if err != nil {
+			return err
+		}
+		switch name {
+		case "Ah":
+			err = jsonpatch.DecodeField(op, rest, &p.Ah)
+		default:
+			err = jsonpatch.PathNotFound(op)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
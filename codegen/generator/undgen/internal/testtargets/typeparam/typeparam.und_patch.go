@@ -5,6 +5,8 @@
 package typeparam
 
 import (
+	"reflect"
+
 	"github.com/ngicks/und/elastic"
 	"github.com/ngicks/und/option"
 	"github.com/ngicks/und/sliceund"
@@ -58,3 +60,22 @@ func (p WithTypeParamPatch[T]) ApplyPatch(v WithTypeParam[T]) WithTypeParam[T] {
 	merged := orgP.Merge(p)
 	return merged.ToValue()
 }
+
+//codegen:generated
+func DiffWithTypeParam[T any](before, after WithTypeParam[T]) WithTypeParamPatch[T] {
+	var p, full WithTypeParamPatch[T]
+	full.FromValue(after)
+	if before.Foo != after.Foo {
+		p.Foo = full.Foo
+	}
+	if !reflect.DeepEqual(before.Bar, after.Bar) {
+		p.Bar = full.Bar
+	}
+	if !reflect.DeepEqual(before.Baz, after.Baz) {
+		p.Baz = full.Baz
+	}
+	if !reflect.DeepEqual(before.Qux, after.Qux) {
+		p.Qux = full.Qux
+	}
+	return p
+}
@@ -0,0 +1,71 @@
+package undgen
+
+import (
+	"github.com/ngicks/go-codegen/codegen/pkg/gencache"
+	"github.com/ngicks/go-codegen/codegen/pkg/genreport"
+)
+
+// Option configures GeneratePlain, GenerateValidator and GeneratePatcher.
+type Option func(o *options)
+
+type options struct {
+	cache     *gencache.Cache
+	report    *genreport.Run
+	deep      bool
+	jsonPatch bool
+	diff      bool
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.jsonPatch {
+		o.diff = true
+	}
+	return o
+}
+
+// WithCache sets the cache to skip generation for files whose types are unchanged since the last run.
+// The cache must be created only for the generator it is passed to.
+func WithCache(cache *gencache.Cache) Option {
+	return func(o *options) {
+		o.cache = cache
+	}
+}
+
+// WithReport sets the report to record every type examined and why types are not targets.
+func WithReport(report *genreport.Run) Option {
+	return func(o *options) {
+		o.report = report
+	}
+}
+
+// WithDeepPatch enables deep patches. Only GeneratePatcher uses it.
+// Fields whose type is a struct type also targeted are patched by the patch type of the field type,
+// so that patches can change a part of nested structs.
+func WithDeepPatch(deep bool) Option {
+	return func(o *options) {
+		o.deep = deep
+	}
+}
+
+// WithJSONPatch enables generation of ToJSONPatch and FromJSONPatch methods,
+// which convert patch types from and to JSON Patch documents (RFC 6902).
+// It also enables [WithDiff], so that JSON Patch documents can be produced from 2 values by DiffX(before, after).ToJSONPatch().
+// Only GeneratePatcher uses it.
+func WithJSONPatch(jsonPatch bool) Option {
+	return func(o *options) {
+		o.jsonPatch = jsonPatch
+	}
+}
+
+// WithDiff enables generation of Diff<Type> functions,
+// which compute patches setting only fields that differ between 2 values.
+// Only GeneratePatcher uses it.
+func WithDiff(diff bool) Option {
+	return func(o *options) {
+		o.diff = diff
+	}
+}
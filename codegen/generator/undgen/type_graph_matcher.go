@@ -247,6 +247,21 @@ func _isUndTarget(named *types.Named, external bool, implementorOf func(named *t
 	return false, nil
 }
 
+// rejectionOf returns a function that describes why a type is not a target
+// of the generator looking for implementors of method.
+func rejectionOf(method string) func(node *typegraph.Node) []string {
+	return func(node *typegraph.Node) []string {
+		switch x := node.Type.Underlying().(type) {
+		case *types.Struct:
+			return []string{"no field tagged with `und` nor of a type implementing " + method}
+		case *types.Map, *types.Array, *types.Slice:
+			return []string{"element is not of a type implementing " + method}
+		default:
+			return []string{"unsupported underlying type " + types.TypeString(x, nil) + ": must be struct, array, slice or map"}
+		}
+	}
+}
+
 func namedTypeToTargetType(named *types.Named) imports.TargetType {
 	obj := named.Obj()
 	var pkgPath string
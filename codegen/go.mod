@@ -5,11 +5,14 @@ go 1.25.0
 require (
 	github.com/dave/dst v0.27.3
 	github.com/google/go-cmp v0.6.0
+	github.com/ngicks/go-codegen/pkg/cloner/runtime v0.1.0
+	github.com/ngicks/go-codegen/pkg/undgen/runtime v0.1.0
 	github.com/ngicks/go-iterator-helper v0.0.21
 	github.com/ngicks/und v1.0.0-alpha8
 	github.com/spf13/cobra v1.9.1
 	github.com/spf13/pflag v1.0.7
 	golang.org/x/tools v0.36.0
+	gopkg.in/yaml.v3 v3.0.1
 	gotest.tools/v3 v3.5.1
 )
 
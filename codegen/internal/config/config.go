@@ -0,0 +1,395 @@
+// Package config defines the project config file, codegen.yaml,
+// which declares generator jobs to be run by a single invocation of the codegen run command.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/ngicks/go-codegen/codegen/generator/cloner"
+	"github.com/ngicks/go-codegen/codegen/pkg/imports"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultFilename is the name of the config file looked up when none is specified.
+const DefaultFilename = "codegen.yaml"
+
+// Generator names a generator a job runs.
+type Generator string
+
+const (
+	GeneratorCloner          Generator = "cloner"
+	GeneratorUndgenPatch     Generator = "undgen-patch"
+	GeneratorUndgenPlain     Generator = "undgen-plain"
+	GeneratorUndgenValidator Generator = "undgen-validator"
+)
+
+// Generators lists all known generators.
+var Generators = []Generator{
+	GeneratorCloner,
+	GeneratorUndgenPatch,
+	GeneratorUndgenPlain,
+	GeneratorUndgenValidator,
+}
+
+// Config is the content of the config file.
+//
+// Example:
+//
+//	build-flags: ["-tags", "integration"]
+//	jobs:
+//	  - generator: cloner
+//	    pkg: ["./..."]
+//	    cloner:
+//	      chan: disallow
+//	  - generator: cloner
+//	    pkg: ["./internal/linux"]
+//	    build-flags: ["-tags", "linux"]
+//	  - generator: undgen-plain
+//	    pkg: ["./types/..."]
+//	  - generator: undgen-patch
+//	    pkg: ["./types"]
+//	    types: [User, Group]
+//	    patch:
+//	      deep: true
+//	      json-patch: true
+//	      diff: true
+type Config struct {
+	// BuildFlags is passed through to the build system's query tool
+	// for jobs which do not have their own build flags.
+	BuildFlags []string `yaml:"build-flags"`
+	Jobs       []Job    `yaml:"jobs"`
+}
+
+// Job is a unit of generation: a generator applied to packages.
+type Job struct {
+	Generator Generator `yaml:"generator"`
+	// Pkg is a list of package patterns relative to the directory where the config file is placed.
+	// Each must start with "./".
+	Pkg []string `yaml:"pkg"`
+	// BuildFlags, if set, overrides Config.BuildFlags for the job.
+	// An empty list, i.e. [], loads packages without build flags.
+	BuildFlags []string `yaml:"build-flags"`
+	// Types lists target type names. Only for undgen-patch. "..." means all types in matched packages.
+	// A name can be qualified by its package path, e.g. example.com/types.User, to match only in that package.
+	Types []string `yaml:"types"`
+	// Cloner configures the cloner. Only for cloner.
+	Cloner *Cloner `yaml:"cloner"`
+	// Patch configures the patch generator. Only for undgen-patch.
+	Patch *Patch `yaml:"patch"`
+}
+
+// Patch configures undgen-patch.
+type Patch struct {
+	// Deep makes struct fields whose type is also a target patched by the patch type of the field type.
+	Deep bool `yaml:"deep"`
+	// JSONPatch enables generation of methods converting patches from and to JSON Patch (RFC 6902) documents.
+	// It implies Diff.
+	JSONPatch bool `yaml:"json-patch"`
+	// Diff enables generation of functions computing patches between 2 values.
+	Diff bool `yaml:"diff"`
+}
+
+// GenerateDeep reports whether deep patches should be generated.
+// p can be nil.
+func (p *Patch) GenerateDeep() bool {
+	return p != nil && p.Deep
+}
+
+// GenerateJSONPatch reports whether ToJSONPatch and FromJSONPatch methods should be generated.
+// p can be nil.
+func (p *Patch) GenerateJSONPatch() bool {
+	return p != nil && p.JSONPatch
+}
+
+// GenerateDiff reports whether Diff<Type> functions should be generated.
+// p can be nil.
+func (p *Patch) GenerateDiff() bool {
+	return p != nil && p.Diff
+}
+
+// Cloner corresponds to cloner.MatcherConfig.
+// Each handle field is one of "ignore", "disallow", "copy", "make" or "clone".
+// Empty value leaves the cloner's default as is.
+type Cloner struct {
+	NoCopy    string `yaml:"no-copy"`
+	Chan      string `yaml:"chan"`
+	Func      string `yaml:"func"`
+	Interface string `yaml:"interface"`
+	Atomic    string `yaml:"atomic"`
+	// Reflect makes values which otherwise would be ignored cloned by reflection.
+	Reflect bool `yaml:"reflect"`
+	// Equal enables generation of Equal methods alongside clone methods.
+	Equal bool `yaml:"equal"`
+	// Memo enables generation of CloneWithMemo methods which preserve aliasing and cycles of pointers.
+	Memo bool `yaml:"memo"`
+	// Into enables generation of CloneInto methods which reuse memory of the destination.
+	Into bool `yaml:"into"`
+	// Test enables generation of test files which fuzz and benchmark clone methods.
+	Test bool `yaml:"test"`
+	// Handlers declares how values of specific types are cloned.
+	// They take precedence over the cloner's built-in handlers.
+	Handlers []Handler `yaml:"handlers"`
+}
+
+// Handler corresponds to cloner.HandlerRule.
+//
+// Example:
+//
+//	handlers:
+//	  - type: github.com/shopspring/decimal.Decimal
+//	    clone: assign
+//	  - type: "*github.com/jackc/pgx/v5/pgtype.Numeric"
+//	    clone: example.com/mypkg.CloneNumeric
+//	    equal: example.com/mypkg.EqualNumeric
+type Handler struct {
+	// Type is a package path and a type name joined by a dot.
+	// A leading "*" makes the handler apply to pointers to the type.
+	Type string `yaml:"type"`
+	// Clone is either "assign" or a package path and a function name joined by a dot.
+	Clone string `yaml:"clone"`
+	// Equal is optional, a package path and a function name joined by a dot.
+	// It is used only if equal is set.
+	Equal string `yaml:"equal"`
+}
+
+// Rule converts h into cloner.HandlerRule.
+func (h Handler) Rule() (cloner.HandlerRule, error) {
+	var (
+		rule cloner.HandlerRule
+		err  error
+	)
+	ty, pointer := strings.CutPrefix(h.Type, "*")
+	rule.Pointer = pointer
+	rule.Type, err = parseQualified(ty)
+	if err != nil {
+		return rule, fmt.Errorf("type: %w", err)
+	}
+	switch h.Clone {
+	case "":
+		return rule, fmt.Errorf("clone is empty: must be \"assign\" or a function")
+	case "assign":
+	default:
+		rule.Clone, err = parseQualified(h.Clone)
+		if err != nil {
+			return rule, fmt.Errorf("clone: %w", err)
+		}
+	}
+	if h.Equal != "" {
+		rule.Equal, err = parseQualified(h.Equal)
+		if err != nil {
+			return rule, fmt.Errorf("equal: %w", err)
+		}
+	}
+	return rule, nil
+}
+
+// parseQualified parses s, a package path and a name joined by a dot, e.g. example.com/foo.Bar.
+func parseQualified(s string) (imports.TargetType, error) {
+	i := strings.LastIndex(s, ".")
+	if i <= 0 || i == len(s)-1 || strings.Contains(s[i+1:], "/") {
+		return imports.TargetType{}, fmt.Errorf("%q: must be a package path and a name joined by a dot", s)
+	}
+	return imports.TargetType{ImportPath: s[:i], Name: s[i+1:]}, nil
+}
+
+// GenerateEqual reports whether Equal methods should be generated.
+// c can be nil.
+func (c *Cloner) GenerateEqual() bool {
+	return c != nil && c.Equal
+}
+
+// GenerateInto reports whether CloneInto methods should be generated.
+// c can be nil.
+func (c *Cloner) GenerateInto() bool {
+	return c != nil && c.Into
+}
+
+// GenerateTest reports whether test files for clone methods should be generated.
+// c can be nil.
+func (c *Cloner) GenerateTest() bool {
+	return c != nil && c.Test
+}
+
+// GenerateMemo reports whether CloneWithMemo methods should be generated.
+// c can be nil.
+func (c *Cloner) GenerateMemo() bool {
+	return c != nil && c.Memo
+}
+
+var copyHandles = map[string]cloner.CopyHandle{
+	"ignore":   cloner.CopyHandleIgnore,
+	"disallow": cloner.CopyHandleDisallow,
+	"copy":     cloner.CopyHandleCopyPointer,
+	"make":     cloner.CopyHandleMake,
+	"clone":    cloner.CopyHandleClone,
+}
+
+// MatcherConfig converts c into *cloner.MatcherConfig.
+// c can be nil.
+func (c *Cloner) MatcherConfig() (*cloner.MatcherConfig, error) {
+	mc := &cloner.MatcherConfig{}
+	if c == nil {
+		return mc, nil
+	}
+	mc.ReflectFallback = c.Reflect
+	for _, f := range []struct {
+		name    string
+		value   string
+		allowed []string
+		dst     *cloner.CopyHandle
+	}{
+		{"no-copy", c.NoCopy, []string{"ignore", "disallow", "copy"}, &mc.NoCopyHandle},
+		{"chan", c.Chan, []string{"ignore", "disallow", "copy", "make"}, &mc.ChannelHandle},
+		{"func", c.Func, []string{"ignore", "disallow", "copy"}, &mc.FuncHandle},
+		{"interface", c.Interface, []string{"ignore", "copy", "clone"}, &mc.InterfaceHandle},
+		{"atomic", c.Atomic, []string{"ignore", "disallow", "copy", "clone"}, &mc.AtomicHandle},
+	} {
+		if f.value == "" {
+			continue
+		}
+		if !slices.Contains(f.allowed, f.value) {
+			return nil, fmt.Errorf("cloner.%s: must be one of %v but is %q", f.name, f.allowed, f.value)
+		}
+		*f.dst = copyHandles[f.value]
+	}
+	for i, h := range c.Handlers {
+		rule, err := h.Rule()
+		if err != nil {
+			return nil, fmt.Errorf("cloner.handlers[%d]: %w", i, err)
+		}
+		mc.CustomHandlers = append(mc.CustomHandlers, rule.CustomHandler())
+	}
+	return mc, nil
+}
+
+// Load reads and validates the config file at path.
+func Load(path string) (*Config, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	cfg, err := Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Decode decodes and validates the config from r.
+// Unknown fields are rejected.
+func Decode(r io.Reader) (*Config, error) {
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	var cfg Config
+	if err := dec.Decode(&cfg); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("empty config")
+		}
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// Validate reports problems found in c.
+func (c *Config) Validate() error {
+	if len(c.Jobs) == 0 {
+		return fmt.Errorf("no job is configured")
+	}
+	var errs []error
+	for i, job := range c.Jobs {
+		if err := job.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("jobs[%d]: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (j Job) validate() error {
+	if !slices.Contains(Generators, j.Generator) {
+		return fmt.Errorf("unknown generator %q: must be one of %v", j.Generator, Generators)
+	}
+	if len(j.Pkg) == 0 {
+		return fmt.Errorf("pkg is empty")
+	}
+	for _, p := range j.Pkg {
+		if p != "." && !strings.HasPrefix(p, "./") {
+			return fmt.Errorf("pkg %q: must start with \"./\"", p)
+		}
+	}
+	if j.Generator == GeneratorUndgenPatch {
+		if len(j.Types) == 0 {
+			return fmt.Errorf("types is empty: undgen-patch requires target type names or \"...\"")
+		}
+	} else if len(j.Types) > 0 {
+		return fmt.Errorf("types is only allowed for undgen-patch")
+	} else if j.Patch != nil {
+		return fmt.Errorf("patch is only allowed for undgen-patch")
+	}
+	if j.Generator == GeneratorCloner {
+		if _, err := j.Cloner.MatcherConfig(); err != nil {
+			return err
+		}
+	} else if j.Cloner != nil {
+		return fmt.Errorf("cloner is only allowed for cloner")
+	}
+	return nil
+}
+
+// Patterns returns package patterns of all jobs, deduplicated and sorted.
+func (c *Config) Patterns() []string {
+	var patterns []string
+	for _, job := range c.Jobs {
+		patterns = append(patterns, job.Pkg...)
+	}
+	slices.Sort(patterns)
+	return slices.Compact(patterns)
+}
+
+// LoadGroup is a set of jobs whose packages are loaded together.
+type LoadGroup struct {
+	// BuildFlags is the build flags shared by the jobs.
+	BuildFlags []string
+	// Patterns is package patterns of the jobs, deduplicated and sorted.
+	Patterns []string
+	// Jobs is indices of the jobs in Config.Jobs, in ascending order.
+	Jobs []int
+}
+
+// LoadGroups groups jobs by their build flags so that packages are loaded once per distinct set of build flags.
+// Groups are ordered by the first job of each.
+func (c *Config) LoadGroups() []LoadGroup {
+	var (
+		groups []LoadGroup
+		index  = make(map[string]int)
+	)
+	for i, job := range c.Jobs {
+		flags := c.BuildFlags
+		if job.BuildFlags != nil {
+			flags = job.BuildFlags
+		}
+		// NUL never appears in command-line flags.
+		key := strings.Join(flags, "\x00")
+		g, ok := index[key]
+		if !ok {
+			g = len(groups)
+			index[key] = g
+			groups = append(groups, LoadGroup{BuildFlags: flags})
+		}
+		groups[g].Patterns = append(groups[g].Patterns, job.Pkg...)
+		groups[g].Jobs = append(groups[g].Jobs, i)
+	}
+	for i := range groups {
+		slices.Sort(groups[i].Patterns)
+		groups[i].Patterns = slices.Compact(groups[i].Patterns)
+	}
+	return groups
+}
@@ -0,0 +1,152 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ngicks/go-codegen/codegen/generator/cloner"
+	"github.com/ngicks/go-codegen/codegen/pkg/imports"
+	"gotest.tools/v3/assert"
+)
+
+func TestDecode(t *testing.T) {
+	cfg, err := Decode(strings.NewReader(`
+build-flags: ["-tags", "integration"]
+jobs:
+  - generator: cloner
+    pkg: ["./...", "./foo"]
+    cloner:
+      chan: disallow
+      no-copy: copy
+      interface: clone
+      atomic: clone
+      reflect: true
+  - generator: undgen-plain
+    pkg: ["./foo"]
+  - generator: undgen-patch
+    pkg: ["./bar"]
+    types: [Foo, Bar]
+    patch:
+      deep: true
+      json-patch: true
+      diff: true
+`))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, cfg.BuildFlags, []string{"-tags", "integration"})
+	assert.Equal(t, len(cfg.Jobs), 3)
+	assert.DeepEqual(t, cfg.Patterns(), []string{"./...", "./bar", "./foo"})
+
+	mc, err := cfg.Jobs[0].Cloner.MatcherConfig()
+	assert.NilError(t, err)
+	assert.Equal(t, mc.ChannelHandle, cloner.CopyHandleDisallow)
+	assert.Equal(t, mc.NoCopyHandle, cloner.CopyHandleCopyPointer)
+	assert.Equal(t, mc.FuncHandle, cloner.CopyHandle(0))
+	assert.Equal(t, mc.InterfaceHandle, cloner.CopyHandleClone)
+	assert.Equal(t, mc.AtomicHandle, cloner.CopyHandleClone)
+	assert.Assert(t, mc.ReflectFallback)
+
+	mc, err = cfg.Jobs[1].Cloner.MatcherConfig()
+	assert.NilError(t, err)
+	assert.Equal(t, mc.ChannelHandle, cloner.CopyHandle(0))
+
+	assert.Assert(t, !cfg.Jobs[1].Patch.GenerateDeep())
+	assert.Assert(t, cfg.Jobs[2].Patch.GenerateDeep())
+	assert.Assert(t, !cfg.Jobs[1].Patch.GenerateJSONPatch())
+	assert.Assert(t, cfg.Jobs[2].Patch.GenerateJSONPatch())
+	assert.Assert(t, !cfg.Jobs[1].Patch.GenerateDiff())
+	assert.Assert(t, cfg.Jobs[2].Patch.GenerateDiff())
+}
+
+func TestDecode_error(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		input  string
+		errMsg string
+	}{
+		{"empty", ``, "empty config"},
+		{"no job", `jobs: []`, "no job is configured"},
+		{"unknown field", "jobs:\n  - generator: cloner\n    pkgs: [./]", "field pkgs not found"},
+		{"unknown generator", "jobs:\n  - generator: foo\n    pkg: [./]", `unknown generator "foo"`},
+		{"no pkg", "jobs:\n  - generator: cloner", "pkg is empty"},
+		{"import path", "jobs:\n  - generator: cloner\n    pkg: [example.com/foo]", `must start with "./"`},
+		{"patch without types", "jobs:\n  - generator: undgen-patch\n    pkg: [./]", "types is empty"},
+		{"types for cloner", "jobs:\n  - generator: cloner\n    pkg: [./]\n    types: [Foo]", "types is only allowed for undgen-patch"},
+		{"patch for plain", "jobs:\n  - generator: undgen-plain\n    pkg: [./]\n    patch: {deep: true}", "patch is only allowed for undgen-patch"},
+		{"cloner for plain", "jobs:\n  - generator: undgen-plain\n    pkg: [./]\n    cloner: {chan: make}", "cloner is only allowed for cloner"},
+		{"wrong handle", "jobs:\n  - generator: cloner\n    pkg: [./]\n    cloner: {func: make}", "cloner.func: must be one of"},
+		{"make for atomic", "jobs:\n  - generator: cloner\n    pkg: [./]\n    cloner: {atomic: make}", "cloner.atomic: must be one of"},
+		{"clone for non interface", "jobs:\n  - generator: cloner\n    pkg: [./]\n    cloner: {chan: clone}", "cloner.chan: must be one of"},
+		{"handler without clone", "jobs:\n  - generator: cloner\n    pkg: [./]\n    cloner: {handlers: [{type: example.com/foo.Bar}]}", "cloner.handlers[0]: clone is empty"},
+		{"handler unqualified type", "jobs:\n  - generator: cloner\n    pkg: [./]\n    cloner: {handlers: [{type: Bar, clone: assign}]}", `cloner.handlers[0]: type: "Bar"`},
+		{"handler wrong func", "jobs:\n  - generator: cloner\n    pkg: [./]\n    cloner: {handlers: [{type: example.com/foo.Bar, clone: example.com/foo.}]}", `cloner.handlers[0]: clone: "example.com/foo."`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Decode(strings.NewReader(tc.input))
+			assert.ErrorContains(t, err, tc.errMsg)
+		})
+	}
+}
+
+func TestHandler_Rule(t *testing.T) {
+	for _, tc := range []struct {
+		handler Handler
+		rule    cloner.HandlerRule
+	}{
+		{
+			Handler{Type: "github.com/shopspring/decimal.Decimal", Clone: "assign"},
+			cloner.HandlerRule{Type: imports.TargetType{ImportPath: "github.com/shopspring/decimal", Name: "Decimal"}},
+		},
+		{
+			Handler{
+				Type:  "*github.com/jackc/pgx/v5/pgtype.Numeric",
+				Clone: "example.com/mypkg.CloneNumeric",
+				Equal: "example.com/mypkg.EqualNumeric",
+			},
+			cloner.HandlerRule{
+				Type:    imports.TargetType{ImportPath: "github.com/jackc/pgx/v5/pgtype", Name: "Numeric"},
+				Pointer: true,
+				Clone:   imports.TargetType{ImportPath: "example.com/mypkg", Name: "CloneNumeric"},
+				Equal:   imports.TargetType{ImportPath: "example.com/mypkg", Name: "EqualNumeric"},
+			},
+		},
+	} {
+		rule, err := tc.handler.Rule()
+		assert.NilError(t, err)
+		assert.DeepEqual(t, tc.rule, rule)
+	}
+
+	mc, err := (&Cloner{Handlers: []Handler{{Type: "example.com/foo.Bar", Clone: "assign"}}}).MatcherConfig()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []string{"example.com/foo.Bar clone=assign"}, mc.CustomHandlers.Names())
+}
+
+func TestConfig_LoadGroups(t *testing.T) {
+	cfg, err := Decode(strings.NewReader(`
+build-flags: ["-tags", "integration"]
+jobs:
+  - generator: cloner
+    pkg: ["./foo"]
+  - generator: undgen-plain
+    pkg: ["./linux"]
+    build-flags: ["-tags", "linux"]
+  - generator: undgen-validator
+    pkg: ["./bar", "./foo"]
+  - generator: undgen-patch
+    pkg: ["./plain"]
+    types: ["..."]
+    build-flags: []
+  - generator: cloner
+    pkg: ["./linux/..."]
+    build-flags: ["-tags", "linux"]
+`))
+	assert.NilError(t, err)
+	assert.DeepEqual(
+		t,
+		[]LoadGroup{
+			{BuildFlags: []string{"-tags", "integration"}, Patterns: []string{"./bar", "./foo"}, Jobs: []int{0, 2}},
+			{BuildFlags: []string{"-tags", "linux"}, Patterns: []string{"./linux", "./linux/..."}, Jobs: []int{1, 4}},
+			{BuildFlags: []string{}, Patterns: []string{"./plain"}, Jobs: []int{3}},
+		},
+		cfg.LoadGroups(),
+	)
+}
@@ -0,0 +1,177 @@
+// Package linediff computes line based differences and prints them in the unified format.
+package linediff
+
+import (
+	"fmt"
+	"strings"
+)
+
+type opKind int
+
+const (
+	opEqual opKind = iota
+	opDelete
+	opInsert
+)
+
+type op struct {
+	kind opKind
+	line string
+}
+
+// Unified returns the unified diff of old and new, labelled with oldName and newName.
+// context is the number of unchanged lines printed around each change.
+// It returns an empty string if old and new are identical.
+func Unified(oldName, newName string, old, new []byte, context int) string {
+	if string(old) == string(new) {
+		return ""
+	}
+	ops := diffLines(splitLines(string(old)), splitLines(string(new)))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", oldName, newName)
+
+	// line numbers (0-based) in old and new at the head of ops[i].
+	oldLine := make([]int, len(ops)+1)
+	newLine := make([]int, len(ops)+1)
+	for i, o := range ops {
+		oldLine[i+1], newLine[i+1] = oldLine[i], newLine[i]
+		if o.kind != opInsert {
+			oldLine[i+1]++
+		}
+		if o.kind != opDelete {
+			newLine[i+1]++
+		}
+	}
+
+	for i := 0; i < len(ops); {
+		if ops[i].kind == opEqual {
+			i++
+			continue
+		}
+		start := max(i-context, 0)
+		end := i
+		// extend the hunk while next change is close enough to be merged.
+		for {
+			for end < len(ops) && ops[end].kind != opEqual {
+				end++
+			}
+			next := end
+			for next < len(ops) && ops[next].kind == opEqual {
+				next++
+			}
+			if next == len(ops) || next-end > 2*context {
+				end = min(end+context, len(ops))
+				break
+			}
+			end = next
+		}
+
+		oldCount := oldLine[end] - oldLine[start]
+		newCount := newLine[end] - newLine[start]
+		fmt.Fprintf(
+			&b,
+			"@@ -%s +%s @@\n",
+			hunkRange(oldLine[start], oldCount),
+			hunkRange(newLine[start], newCount),
+		)
+		for _, o := range ops[start:end] {
+			switch o.kind {
+			case opEqual:
+				b.WriteByte(' ')
+			case opDelete:
+				b.WriteByte('-')
+			case opInsert:
+				b.WriteByte('+')
+			}
+			b.WriteString(o.line)
+			if !strings.HasSuffix(o.line, "\n") {
+				b.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		i = end
+	}
+	return b.String()
+}
+
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines computes edit operations turning old into new.
+// Common prefix and suffix are trimmed first then the rest is compared by the longest common subsequence.
+func diffLines(old, new []string) []op {
+	var prefix int
+	for prefix < len(old) && prefix < len(new) && old[prefix] == new[prefix] {
+		prefix++
+	}
+	var suffix int
+	for suffix < len(old)-prefix && suffix < len(new)-prefix &&
+		old[len(old)-1-suffix] == new[len(new)-1-suffix] {
+		suffix++
+	}
+
+	ops := make([]op, 0, len(old)+len(new))
+	for _, l := range old[:prefix] {
+		ops = append(ops, op{opEqual, l})
+	}
+
+	a, b := old[prefix:len(old)-suffix], new[prefix:len(new)-suffix]
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	lcs := make([][]int32, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, op{opEqual, a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, op{opDelete, a[i]})
+			i++
+		default:
+			ops = append(ops, op{opInsert, b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, op{opDelete, a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, op{opInsert, b[j]})
+	}
+
+	for _, l := range old[len(old)-suffix:] {
+		ops = append(ops, op{opEqual, l})
+	}
+	return ops
+}
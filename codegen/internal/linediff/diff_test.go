@@ -0,0 +1,44 @@
+package linediff
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestUnified(t *testing.T) {
+	assert.Equal(t, "", Unified("a", "b", []byte("foo\nbar\n"), []byte("foo\nbar\n"), 3))
+
+	assert.Equal(
+		t,
+		`--- a
++++ b
+@@ -1,4 +1,4 @@
+ 1
+-2
++two
+ 3
+ 4
+@@ -9,2 +9,3 @@
+ 9
+ 10
++11
+`,
+		Unified(
+			"a", "b",
+			[]byte("1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n"),
+			[]byte("1\ntwo\n3\n4\n5\n6\n7\n8\n9\n10\n11\n"),
+			2,
+		),
+	)
+
+	assert.Equal(
+		t,
+		`--- a
++++ b
+@@ -1 +0,0 @@
+-foo
+`,
+		Unified("a", "b", []byte("foo\n"), nil, 3),
+	)
+}
@@ -0,0 +1,45 @@
+// Package workpool runs functions concurrently with a bounded number of goroutines.
+package workpool
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+)
+
+// Run calls fn with each of items, running at most jobs calls at a time.
+// If jobs is less than 1, runtime.GOMAXPROCS(0) is used instead.
+//
+// Run waits for all calls to return, even if some of them fail,
+// then returns errors joined in the order of items so that the report is deterministic.
+func Run[T any](jobs int, items []T, fn func(item T) error) error {
+	if jobs < 1 {
+		jobs = runtime.GOMAXPROCS(0)
+	}
+	if jobs == 1 || len(items) <= 1 {
+		errs := make([]error, len(items))
+		for i, item := range items {
+			errs[i] = fn(item)
+		}
+		return errors.Join(errs...)
+	}
+
+	var (
+		wg   sync.WaitGroup
+		sem  = make(chan struct{}, jobs)
+		errs = make([]error, len(items))
+	)
+	for i, item := range items {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			errs[i] = fn(item)
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
@@ -0,0 +1,53 @@
+package workpool
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestRun(t *testing.T) {
+	items := make([]int, 100)
+	for i := range items {
+		items[i] = i
+	}
+
+	for _, jobs := range []int{0, 1, 3} {
+		t.Run(fmt.Sprintf("jobs=%d", jobs), func(t *testing.T) {
+			var (
+				running, maxRunning atomic.Int64
+				sum                 atomic.Int64
+			)
+			err := Run(jobs, items, func(i int) error {
+				cur := running.Add(1)
+				defer running.Add(-1)
+				for {
+					m := maxRunning.Load()
+					if cur <= m || maxRunning.CompareAndSwap(m, cur) {
+						break
+					}
+				}
+				sum.Add(int64(i))
+				if i%10 == 3 {
+					return fmt.Errorf("err %d", i)
+				}
+				return nil
+			})
+			assert.Equal(t, sum.Load(), int64(99*100/2))
+			if jobs > 0 {
+				assert.Assert(t, maxRunning.Load() <= int64(jobs))
+			}
+
+			var joined interface{ Unwrap() []error }
+			assert.Assert(t, errors.As(err, &joined))
+			errs := joined.Unwrap()
+			assert.Equal(t, len(errs), 10)
+			for i, err := range errs {
+				assert.Equal(t, err.Error(), fmt.Sprintf("err %d", i*10+3))
+			}
+		})
+	}
+}
@@ -95,6 +95,27 @@ func ParseFieldDirectiveCommentDst[T any](
 	return t, true, err
 }
 
+// ParseTypeDirectiveComment parses directive comments prefixed with prefix in the doc comment of ts.
+// The doc comment of genDecl, the declaration ts belongs to, is used instead if ts is not grouped.
+// genDecl can be nil.
+func ParseTypeDirectiveComment[T any](
+	prefix string,
+	genDecl *ast.GenDecl,
+	ts *ast.TypeSpec,
+	parser func(s []string) (T, error),
+) (T, bool, error) {
+	doc := ts.Doc
+	if doc == nil && genDecl != nil && !genDecl.Lparen.IsValid() {
+		doc = genDecl.Doc
+	}
+	lines := directiveComments(EnumerateCommentGroup(doc), prefix, true)
+	if len(lines) == 0 {
+		return *new(T), false, nil
+	}
+	t, err := parser(lines)
+	return t, true, err
+}
+
 func parseDirective(seq iter.Seq[string]) (Direction, bool, error) {
 	direction := directiveComments(seq, DirectivePrefix, true)
 
@@ -1,6 +1,7 @@
 package directive
 
 import (
+	"go/ast"
 	"go/parser"
 	"go/token"
 	"strconv"
@@ -250,4 +251,39 @@ L:
 
 	assert.DeepEqual(t, []string{"// 11"}, afterLastEmptyLine(c.Decs.Start))
 	assert.DeepEqual(t, []string(nil), clip1(c.Decs.End))
-}
\ No newline at end of file
+}
+func TestParseTypeDirectiveComment(t *testing.T) {
+	src := `package main
+
+// A is not grouped.
+//
+//foo:bar,baz
+type A struct{}
+
+type (
+	//foo:qux
+	B struct{}
+
+	C struct{}
+)
+
+// D has no directive.
+type D struct{}
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "hello.go", src, parser.ParseComments|parser.AllErrors)
+	assert.NilError(t, err)
+
+	parse := func(lines []string) ([]string, error) { return lines, nil }
+	var results [][]string
+	for _, decl := range f.Decls {
+		genDecl := decl.(*ast.GenDecl)
+		for _, spec := range genDecl.Specs {
+			lines, found, err := ParseTypeDirectiveComment("foo:", genDecl, spec.(*ast.TypeSpec), parse)
+			assert.NilError(t, err)
+			assert.Equal(t, found, len(lines) > 0)
+			results = append(results, lines)
+		}
+	}
+	assert.DeepEqual(t, results, [][]string{{"bar,baz"}, {"qux"}, nil, nil})
+}
@@ -0,0 +1,166 @@
+package gencache
+
+import (
+	"encoding/binary"
+	"fmt"
+	"go/ast"
+	"go/printer"
+	"go/types"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/ngicks/go-codegen/codegen/pkg/astutil"
+	"github.com/ngicks/go-codegen/codegen/pkg/typegraph"
+)
+
+// Fingerprint writes to w everything in data that affects generated code.
+//
+// That is,
+//   - the file header: comments before the package clause, the package clause and imports.
+//   - for each target node, its fingerprint. See [FingerprintNode].
+func Fingerprint(w io.Writer, data *typegraph.ReplaceData) error {
+	writeString(w, data.Filename)
+	writeInt(w, len(data.TargetNodes))
+	if len(data.TargetNodes) == 0 {
+		return nil
+	}
+
+	file := data.TargetNodes[0].File
+	if err := astutil.PrintFileHeader(w, file, data.TargetNodes[0].Pkg.Fset); err != nil {
+		return err
+	}
+
+	fp := &fingerprinter{visiting: make(map[*typegraph.Node]bool)}
+	for _, node := range data.TargetNodes {
+		if err := fp.node(w, node); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FingerprintNode writes to w everything about node that affects generated code.
+//
+// That is,
+//   - the type spec printed along its comments and doc comments of the enclosing declaration, which hold directives.
+//   - how the node is matched.
+//   - the type checked underlying type and method sets of named types found in it,
+//     to capture changes in types defined elsewhere, e.g. an external type implementing Clone.
+//   - fingerprints of its children, transitively.
+func FingerprintNode(w io.Writer, node *typegraph.Node) error {
+	return (&fingerprinter{visiting: make(map[*typegraph.Node]bool)}).node(w, node)
+}
+
+type fingerprinter struct {
+	visiting map[*typegraph.Node]bool
+}
+
+func (fp *fingerprinter) node(w io.Writer, node *typegraph.Node) error {
+	writeString(w, node.Type.Obj().Pkg().Path()+"."+node.Type.Obj().Name())
+	if fp.visiting[node] {
+		// cyclic reference. The node is being hashed by the caller.
+		return nil
+	}
+	fp.visiting[node] = true
+	defer delete(fp.visiting, node)
+
+	writeInt(w, int(node.Matched))
+
+	if node.Ts != nil && node.File != nil {
+		if doc := enclosingDoc(node.File, node.Ts); doc != nil {
+			writeString(w, doc.Text())
+			for _, c := range doc.List {
+				// Text drops directives, e.g. //cloner:ignore.
+				writeString(w, c.Text)
+			}
+		}
+		var sb strings.Builder
+		err := printer.Fprint(&sb, node.Pkg.Fset, &printer.CommentedNode{Node: node.Ts, Comments: node.File.Comments})
+		if err != nil {
+			return fmt.Errorf("printing %s: %w", node.Ts.Name.Name, err)
+		}
+		writeString(w, sb.String())
+	}
+
+	// node.Type may be any instance of a generic type, whichever the graph found first.
+	origin := node.Type.Origin()
+	writeString(w, types.TypeString(origin.Underlying(), nil))
+	writeNamedMethodSets(w, origin.Underlying(), make(map[types.Type]bool))
+
+	childIdents := slices.SortedFunc(maps.Keys(node.Children), func(i, j typegraph.Ident) int {
+		return strings.Compare(i.PkgPath+"."+i.TypeName, j.PkgPath+"."+j.TypeName)
+	})
+	for _, ident := range childIdents {
+		for _, edge := range node.Children[ident] {
+			writeString(w, types.TypeString(edge.ChildType, nil))
+			if err := fp.node(w, edge.ChildNode); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func enclosingDoc(f *ast.File, ts *ast.TypeSpec) *ast.CommentGroup {
+	for _, decl := range f.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || ts.Pos() < genDecl.Pos() || genDecl.End() < ts.End() {
+			continue
+		}
+		return genDecl.Doc
+	}
+	return nil
+}
+
+// writeNamedMethodSets writes method sets of named types found in ty, without descending into their underlying types.
+func writeNamedMethodSets(w io.Writer, ty types.Type, seen map[types.Type]bool) {
+	if seen[ty] {
+		return
+	}
+	seen[ty] = true
+
+	switch x := ty.(type) {
+	case *types.Named:
+		for _, t := range []types.Type{x, types.NewPointer(x)} {
+			mset := types.NewMethodSet(t)
+			for i := range mset.Len() {
+				writeString(w, types.ObjectString(mset.At(i).Obj(), nil))
+			}
+		}
+		if args := x.TypeArgs(); args != nil {
+			for t := range args.Types() {
+				writeNamedMethodSets(w, t, seen)
+			}
+		}
+	case *types.Alias:
+		writeNamedMethodSets(w, types.Unalias(x), seen)
+	case *types.Pointer:
+		writeNamedMethodSets(w, x.Elem(), seen)
+	case *types.Slice:
+		writeNamedMethodSets(w, x.Elem(), seen)
+	case *types.Array:
+		writeNamedMethodSets(w, x.Elem(), seen)
+	case *types.Chan:
+		writeNamedMethodSets(w, x.Elem(), seen)
+	case *types.Map:
+		writeNamedMethodSets(w, x.Key(), seen)
+		writeNamedMethodSets(w, x.Elem(), seen)
+	case *types.Struct:
+		for i := range x.NumFields() {
+			writeNamedMethodSets(w, x.Field(i).Type(), seen)
+		}
+	}
+}
+
+// writeString writes s with its length so that concatenation of different values never collides.
+func writeString(w io.Writer, s string) {
+	writeInt(w, len(s))
+	_, _ = io.WriteString(w, s)
+}
+
+func writeInt(w io.Writer, i int) {
+	var buf [binary.MaxVarintLen64]byte
+	_, _ = w.Write(buf[:binary.PutVarint(buf[:], int64(i))])
+}
@@ -0,0 +1,217 @@
+// Package gencache implements the incremental generation cache.
+//
+// Outputs of generators are stored per source file, keyed by a fingerprint of
+// the generator, its configuration and every type the output depends on.
+// Generators consult the cache before generating code for a file;
+// on a hit they skip both generation and formatting and write the cached output as it is.
+package gencache
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sync"
+
+	"github.com/ngicks/go-codegen/codegen/internal/bufpool"
+	"github.com/ngicks/go-codegen/codegen/pkg/suffixwriter"
+	"github.com/ngicks/go-codegen/codegen/pkg/typegraph"
+)
+
+// Cache stores generated outputs under a directory.
+//
+// A nil *Cache is valid and disables caching.
+type Cache struct {
+	dir       string
+	namespace [sha256.Size]byte
+}
+
+// DefaultDir returns the default cache directory, $XDG_CACHE_HOME/go-codegen on Linux.
+// See [os.UserCacheDir] for other platforms.
+func DefaultDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "go-codegen"), nil
+}
+
+// New returns a Cache storing entries under dir.
+//
+// generator must identify the generator and config must describe every configuration affecting its output.
+// Entries are also keyed by the running executable,
+// so that a rebuilt binary never reuses outputs of an older one.
+func New(dir string, generator string, config string) (*Cache, error) {
+	tool, err := toolID()
+	if err != nil {
+		return nil, err
+	}
+	h := sha256.New()
+	writeString(h, tool)
+	writeString(h, generator)
+	writeString(h, config)
+	c := &Cache{dir: dir}
+	h.Sum(c.namespace[:0])
+	return c, nil
+}
+
+// Derive returns a Cache sharing the directory of c whose entries are also keyed by extra.
+// It is for inputs affecting outputs of some files which fingerprints of types in them do not capture.
+// Derive on nil returns nil.
+func (c *Cache) Derive(extra string) *Cache {
+	if c == nil {
+		return nil
+	}
+	h := sha256.New()
+	h.Write(c.namespace[:])
+	writeString(h, extra)
+	d := &Cache{dir: c.dir}
+	h.Sum(d.namespace[:0])
+	return d
+}
+
+var toolID = sync.OnceValues(func() (string, error) {
+	exe, err := os.Executable()
+	if err == nil {
+		var f *os.File
+		f, err = os.Open(exe)
+		if err == nil {
+			defer f.Close()
+			h := sha256.New()
+			if _, err = io.Copy(h, f); err == nil {
+				return hex.EncodeToString(h.Sum(nil)), nil
+			}
+		}
+	}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "", fmt.Errorf("identifying executable: %w", err)
+	}
+	return info.String(), nil
+})
+
+// Key is a fingerprint of a source file for a generator.
+type Key [sha256.Size]byte
+
+func (k Key) String() string {
+	return hex.EncodeToString(k[:])
+}
+
+// Key computes the fingerprint of data.
+// See [Fingerprint] for what is hashed.
+func (c *Cache) Key(data *typegraph.ReplaceData) (Key, error) {
+	var k Key
+	if c == nil {
+		return k, nil
+	}
+	h := sha256.New()
+	h.Write(c.namespace[:])
+	if err := Fingerprint(h, data); err != nil {
+		return k, err
+	}
+	h.Sum(k[:0])
+	return k, nil
+}
+
+func (c *Cache) path(k Key) string {
+	s := k.String()
+	return filepath.Join(c.dir, s[:2], s)
+}
+
+// Get returns the output stored for k.
+// ok is false if there is no entry.
+// An empty output means the generator generated nothing for the file.
+func (c *Cache) Get(k Key) (output []byte, ok bool) {
+	if c == nil {
+		return nil, false
+	}
+	b, err := os.ReadFile(c.path(k))
+	if err != nil {
+		return nil, false
+	}
+	// The first byte marks the entry as completely written.
+	if len(b) == 0 || b[0] != 1 {
+		return nil, false
+	}
+	return b[1:], true
+}
+
+// Put stores output for k.
+func (c *Cache) Put(k Key, output []byte) error {
+	if c == nil {
+		return nil
+	}
+	name := c.path(k)
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append([]byte{1}, output...))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), name)
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return fmt.Errorf("storing cache entry: %w", err)
+	}
+	return nil
+}
+
+// Write generates code for data through w, consulting c.
+//
+// On a cache hit generate is not called and the cached output is written by [suffixwriter.Writer.WriteProcessed].
+// Otherwise generate is called with an empty buffer to write unformatted code into;
+// it reports whether it has written anything.
+// The output is formatted by [suffixwriter.Writer.Format], written to w and stored to c.
+//
+// Errors from the cache itself are ignored; c only makes generation faster.
+func (c *Cache) Write(
+	ctx context.Context,
+	w *suffixwriter.Writer,
+	data *typegraph.ReplaceData,
+	generate func(buf *bytes.Buffer) (written bool, err error),
+) error {
+	key, keyErr := c.Key(data)
+	if keyErr == nil {
+		if cached, ok := c.Get(key); ok {
+			if len(cached) == 0 {
+				return nil
+			}
+			return w.WriteProcessed(ctx, data.Filename, cached)
+		}
+	}
+
+	buf := bufpool.GetBuf()
+	defer bufpool.PutBuf(buf)
+
+	written, err := generate(buf)
+	if err != nil {
+		return err
+	}
+	var formatted []byte
+	if written {
+		formatted, err = w.Format(ctx, data.Filename, buf.Bytes())
+		if err != nil {
+			return err
+		}
+		err = w.WriteProcessed(ctx, data.Filename, formatted)
+		if err != nil {
+			return err
+		}
+	}
+	if c != nil && keyErr == nil {
+		_ = c.Put(key, formatted)
+	}
+	return nil
+}
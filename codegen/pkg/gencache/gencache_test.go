@@ -0,0 +1,173 @@
+package gencache_test
+
+import (
+	"bytes"
+	"context"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ngicks/go-codegen/codegen/generator/cloner"
+	"github.com/ngicks/go-codegen/codegen/pkg/gencache"
+	"github.com/ngicks/go-codegen/codegen/pkg/suffixwriter"
+	"github.com/ngicks/go-codegen/codegen/pkg/typegraph"
+	"golang.org/x/tools/go/packages"
+	"gotest.tools/v3/assert"
+)
+
+func TestCache_nil(t *testing.T) {
+	var c *gencache.Cache
+	k, err := c.Key(&typegraph.ReplaceData{Filename: "a.go"})
+	assert.NilError(t, err)
+	assert.NilError(t, c.Put(k, []byte("foo")))
+	_, ok := c.Get(k)
+	assert.Assert(t, !ok)
+}
+
+func TestCache_Write(t *testing.T) {
+	dir := t.TempDir()
+	c, err := gencache.New(dir, "test", "")
+	assert.NilError(t, err)
+
+	src := t.TempDir()
+	var called int
+	write := func(data *typegraph.ReplaceData, out string) map[string][]byte {
+		w := suffixwriter.NewTestWriter(".test", suffixwriter.WithCwd(src))
+		err := c.Write(context.Background(), w.Writer, data, func(buf *bytes.Buffer) (bool, error) {
+			called++
+			buf.WriteString(out)
+			return out != "", nil
+		})
+		assert.NilError(t, err)
+		return w.Results()
+	}
+
+	a := &typegraph.ReplaceData{Filename: filepath.Join(src, "a.go")}
+	first := write(a, "package a\n")
+	assert.Equal(t, called, 1)
+	second := write(a, "package b\n")
+	assert.Equal(t, called, 1)
+	assert.DeepEqual(t, first, second)
+	assert.Equal(t, string(second[filepath.Join(src, "a.test.go")]), "package a\n")
+
+	// nothing generated is also cached.
+	b := &typegraph.ReplaceData{Filename: filepath.Join(src, "b.go")}
+	assert.Equal(t, len(write(b, "")), 0)
+	assert.Equal(t, called, 2)
+	assert.Equal(t, len(write(b, "package b\n")), 0)
+	assert.Equal(t, called, 2)
+
+	// other generators and configurations have their own entries.
+	c, err = gencache.New(dir, "test", "config")
+	assert.NilError(t, err)
+	assert.Equal(t, string(write(a, "package c\n")[filepath.Join(src, "a.test.go")]), "package c\n")
+	assert.Equal(t, called, 3)
+}
+
+func TestCache_dependency(t *testing.T) {
+	dir := t.TempDir()
+	c, err := gencache.New(dir, "cloner", "")
+	assert.NilError(t, err)
+
+	generate := func(overlay map[string][]byte) map[string][]byte {
+		cfg := &packages.Config{
+			Mode: packages.NeedName |
+				packages.NeedImports |
+				packages.NeedDeps |
+				packages.NeedTypes |
+				packages.NeedSyntax |
+				packages.NeedTypesInfo |
+				packages.NeedTypesSizes,
+			Dir:     "./testdata",
+			Overlay: overlay,
+		}
+		pkgs, err := packages.Load(cfg, "./deps")
+		assert.NilError(t, err)
+
+		w := suffixwriter.NewTestWriter(".clone", suffixwriter.WithCwd("./testdata"))
+		err = (&cloner.Config{Cache: c}).Generate(context.Background(), w.Writer, pkgs)
+		assert.NilError(t, err)
+		return w.Results()
+	}
+	countEntries := func() int {
+		var n int
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err == nil && !d.IsDir() {
+				n++
+			}
+			return err
+		})
+		assert.NilError(t, err)
+		return n
+	}
+
+	deps, err := filepath.Abs("./testdata/deps")
+	assert.NilError(t, err)
+
+	first := generate(nil)
+	assert.DeepEqual(
+		t,
+		slices.Sorted(maps.Keys(first)),
+		[]string{filepath.Join(deps, "a.clone.go"), filepath.Join(deps, "b.clone.go"), filepath.Join(deps, "c.clone.go")},
+	)
+	assert.Equal(t, countEntries(), 3)
+
+	assert.DeepEqual(t, generate(nil), first)
+	assert.Equal(t, countEntries(), 3)
+
+	// a.go depends on b.go; both must be regenerated.
+	b := filepath.Join(deps, "b.go")
+	src, err := os.ReadFile(b)
+	assert.NilError(t, err)
+	edited := generate(map[string][]byte{b: bytes.Replace(src, []byte("P *int"), []byte("P *int\n\tQ []int"), 1)})
+	assert.Equal(t, countEntries(), 5)
+	assert.Equal(t, string(edited[filepath.Join(deps, "c.clone.go")]), string(first[filepath.Join(deps, "c.clone.go")]))
+	assert.Assert(t, string(edited[filepath.Join(deps, "b.clone.go")]) != string(first[filepath.Join(deps, "b.clone.go")]))
+}
+
+func TestCache_interfaceImplementors(t *testing.T) {
+	dir := t.TempDir()
+	c, err := gencache.New(dir, "cloner", "")
+	assert.NilError(t, err)
+
+	ifaceDir, err := filepath.Abs("./testdata/iface")
+	assert.NilError(t, err)
+	holder := filepath.Join(ifaceDir, "a.clone.go")
+
+	generate := func(overlay map[string][]byte) string {
+		cfg := &packages.Config{
+			Mode: packages.NeedName |
+				packages.NeedImports |
+				packages.NeedDeps |
+				packages.NeedTypes |
+				packages.NeedSyntax |
+				packages.NeedTypesInfo |
+				packages.NeedTypesSizes,
+			Dir:     "./testdata",
+			Overlay: overlay,
+		}
+		pkgs, err := packages.Load(cfg, "./iface")
+		assert.NilError(t, err)
+
+		matcherConfig := cloner.NewMatcherConfig()
+		matcherConfig.InterfaceHandle = cloner.CopyHandleClone
+		w := suffixwriter.NewTestWriter(".clone", suffixwriter.WithCwd("./testdata"))
+		err = (&cloner.Config{MatcherConfig: matcherConfig, Cache: c}).Generate(context.Background(), w.Writer, pkgs)
+		assert.NilError(t, err)
+		return string(w.Results()[holder])
+	}
+
+	first := generate(nil)
+	assert.Assert(t, strings.Contains(first, "case Square:"))
+	assert.Equal(t, generate(nil), first)
+
+	// an implementor added in another file changes the output for a.go, whose types are unchanged.
+	added := generate(map[string][]byte{
+		filepath.Join(ifaceDir, "c.go"): []byte("package iface\n\ntype Circle struct {\n\tR int\n}\n\nfunc (c Circle) Area() int { return 3 * c.R * c.R }\n"),
+	})
+	assert.Assert(t, strings.Contains(added, "case Circle:"))
+}
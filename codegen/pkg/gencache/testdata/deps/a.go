@@ -0,0 +1,6 @@
+package deps
+
+type A struct {
+	B B
+	M map[string]int
+}
@@ -0,0 +1,5 @@
+package deps
+
+type B struct {
+	P *int
+}
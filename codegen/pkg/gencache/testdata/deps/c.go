@@ -0,0 +1,5 @@
+package deps
+
+type C struct {
+	S []string
+}
@@ -0,0 +1,9 @@
+package iface
+
+type Shape interface {
+	Area() int
+}
+
+type Holder struct {
+	S Shape
+}
@@ -0,0 +1,7 @@
+package iface
+
+type Square struct {
+	L int
+}
+
+func (s Square) Area() int { return s.L * s.L }
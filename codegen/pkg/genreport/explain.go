@@ -0,0 +1,154 @@
+package genreport
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/ngicks/go-codegen/codegen/pkg/typegraph"
+)
+
+// Explanation describes how a generator decided whether it generates code for a type.
+type Explanation struct {
+	Type
+	// Fields lists decisions the generator made on each field,
+	// or on the element type if the type is an array, slice or map.
+	Fields []Field `json:"fields"`
+	// References lists references from the type to other target types
+	// and whether the generator follows them to mark the type as dependant.
+	References []Reference `json:"references"`
+}
+
+// Field is a decision on a field.
+type Field struct {
+	// Index is the index of the field. It is -1 for the element of an array, slice or map.
+	Index int    `json:"index"`
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	// Directive is the directive attached to the field, if any.
+	Directive string `json:"directive,omitempty"`
+	// Route is kinds of types wrapping the type that decided the handling, e.g. ["slice", "pointer"] for []*T.
+	Route []string `json:"route"`
+	// Decision describes how the generator handles the field.
+	Decision string `json:"decision"`
+	// Rejection describes why the field disallows the type, if it does.
+	Rejection string `json:"rejection,omitempty"`
+}
+
+// Reference is an edge from the explained type to another target type.
+type Reference struct {
+	Type  string   `json:"type"`
+	Match []string `json:"match"`
+	// Via describes the field and route through which the type is referred.
+	Via     string `json:"via"`
+	Allowed bool   `json:"allowed"`
+}
+
+// Explain explains how the node for ident in g is matched.
+//
+// edgeFilter and reason are same as ones for [Run.AddGraph].
+// fields returns decisions on fields of node. It is called only for nodes found in g.
+//
+// If g has no node for ident, Explain returns an error.
+func Explain(
+	g *typegraph.Graph,
+	ident typegraph.Ident,
+	edgeFilter func(edge typegraph.Edge) bool,
+	reason func(node *typegraph.Node) []string,
+	fields func(node *typegraph.Node) []Field,
+) (*Explanation, error) {
+	node, ok := g.Get(ident)
+	if !ok {
+		return nil, fmt.Errorf("%s.%s is not in the type graph", ident.PkgPath, ident.TypeName)
+	}
+
+	e := &Explanation{
+		Type: Type{
+			PkgPath: ident.PkgPath,
+			Name:    ident.TypeName,
+			Match:   node.Matched.Strings(),
+		},
+		Fields: fields(node),
+	}
+	if !node.Matched.IsMatched() && !node.Matched.IsDependant() {
+		if reason != nil {
+			e.Reasons = append(e.Reasons, reason(node)...)
+		}
+		if edgeFilter != nil {
+			e.Reasons = append(e.Reasons, rejectedEdges(node, edgeFilter)...)
+		}
+	}
+
+	for _, edges := range node.Children {
+		for _, edge := range edges {
+			if edge.ChildNode.Matched == 0 {
+				continue
+			}
+			e.References = append(e.References, Reference{
+				Type:    edge.ChildType.String(),
+				Match:   edge.ChildNode.Matched.Strings(),
+				Via:     describeRoute(node, edge.Stack),
+				Allowed: edgeFilter == nil || edgeFilter(edge),
+			})
+		}
+	}
+	slices.SortFunc(e.References, func(i, j Reference) int {
+		return strings.Compare(i.Via+i.Type, j.Via+j.Type)
+	})
+
+	return e, nil
+}
+
+// WriteText writes e to w in a human readable form.
+func (e *Explanation) WriteText(w io.Writer) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s.%s: ", e.PkgPath, e.Name)
+	if len(e.Match) == 0 {
+		sb.WriteString("not a target\n")
+	} else {
+		sb.WriteString(strings.Join(e.Match, ", ") + "\n")
+	}
+
+	if len(e.Reasons) > 0 {
+		sb.WriteString("  reasons:\n")
+		for _, r := range e.Reasons {
+			fmt.Fprintf(&sb, "    - %s\n", r)
+		}
+	}
+
+	if len(e.Fields) > 0 {
+		sb.WriteString("  fields:\n")
+		for _, f := range e.Fields {
+			if f.Index < 0 {
+				fmt.Fprintf(&sb, "    element %s\n", f.Type)
+			} else {
+				fmt.Fprintf(&sb, "    %d: %s %s\n", f.Index, f.Name, f.Type)
+			}
+			if f.Directive != "" {
+				fmt.Fprintf(&sb, "        directive: %s\n", f.Directive)
+			}
+			if len(f.Route) > 0 {
+				fmt.Fprintf(&sb, "        route: %s\n", strings.Join(f.Route, " -> "))
+			}
+			fmt.Fprintf(&sb, "        decision: %s\n", f.Decision)
+			if f.Rejection != "" {
+				fmt.Fprintf(&sb, "        rejected: %s\n", f.Rejection)
+			}
+		}
+	}
+
+	if len(e.References) > 0 {
+		sb.WriteString("  references:\n")
+		for _, r := range e.References {
+			allowed := "followed"
+			if !r.Allowed {
+				allowed = "not followed"
+			}
+			fmt.Fprintf(&sb, "    - %s (%s) via %s: %s\n", r.Type, strings.Join(r.Match, ", "), r.Via, allowed)
+		}
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
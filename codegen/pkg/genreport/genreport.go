@@ -0,0 +1,260 @@
+// Package genreport builds machine-readable reports of what generators did.
+//
+// A [Run] describes a single generator run: the packages it loaded, every type found in them
+// along with how it is matched or why it is not, and files it wrote, skipped or removed.
+// Generators record into a *Run given by the caller; a nil *Run records nothing.
+package genreport
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"go/types"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/ngicks/go-codegen/codegen/pkg/typegraph"
+	"golang.org/x/tools/go/packages"
+)
+
+// Report is a list of runs, in the order they are run.
+type Report struct {
+	mu sync.Mutex
+	// Dry is true if runs are in dry run mode, where nothing is actually written or removed.
+	Dry  bool   `json:"dry"`
+	Runs []*Run `json:"runs"`
+}
+
+// NewRun adds a run of generator to r and returns it.
+// A nil r returns a nil *Run.
+func (r *Report) NewRun(generator string) *Run {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	run := &Run{Generator: generator}
+	r.Runs = append(r.Runs, run)
+	return run
+}
+
+// WriteJSON writes r to w as indented JSON.
+// Types and files of each run are sorted so that the output is stable.
+func (r *Report) WriteJSON(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, run := range r.Runs {
+		run.sort()
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// Run describes what a generator did in a run.
+type Run struct {
+	mu        sync.Mutex
+	Generator string   `json:"generator"`
+	Packages  []string `json:"packages"`
+	Types     []Type   `json:"types"`
+	Files     []File   `json:"files"`
+	// Warnings lists problems found in generated code, e.g. fields which can not be cloned completely.
+	Warnings []Warning `json:"warnings,omitempty"`
+}
+
+// Type describes a type found in the type graph and how the generator matched it.
+type Type struct {
+	PkgPath string `json:"pkgPath"`
+	Name    string `json:"name"`
+	// Match lists kinds of match: "matched", "dependant" and/or "external".
+	// The generator generates code for the type only if it is "matched" or "dependant".
+	// An empty Match means the type is not a target.
+	Match []string `json:"match"`
+	// Reasons explains why the type is not a target, if it is not.
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// FileStatus describes what happened to a file.
+type FileStatus string
+
+const (
+	// FileWritten is for a file written by the generator, or would be written in dry run.
+	FileWritten FileStatus = "written"
+	// FileSkipped is for a source file of target packages for which nothing is generated.
+	FileSkipped FileStatus = "skipped"
+	// FileRemoved is for an orphaned generated file removed by the run, or would be removed in dry run.
+	FileRemoved FileStatus = "removed"
+)
+
+// File describes a file the generator wrote, skipped or removed.
+type File struct {
+	// Source is the source file. It is empty if the file is removed since the source is also gone.
+	Source string `json:"source,omitempty"`
+	// Output is the generated file. It is empty if the file is skipped.
+	Output string     `json:"output,omitempty"`
+	Status FileStatus `json:"status"`
+}
+
+// Warning describes a problem the generator found in code generated for a type.
+type Warning struct {
+	PkgPath string `json:"pkgPath"`
+	Name    string `json:"name"`
+	// Field is the name of the field the warning is about. It is empty for the element of an array, slice or map.
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// AddPackages records pkgs as packages loaded for the run.
+func (r *Run) AddPackages(pkgs []*packages.Package) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, pkg := range pkgs {
+		r.Packages = append(r.Packages, pkg.PkgPath)
+	}
+}
+
+// AddGraph records every type in g including external types referred by them.
+//
+// edgeFilter must be the one given to [typegraph.Graph.MarkDependant], or nil if it is not called.
+// Edges to target types rejected by edgeFilter are reported as reasons why the parent type is not a target.
+// reason, if non nil, returns generator specific reasons why node is not a target.
+// It is only called for nodes neither matched nor dependant.
+func (r *Run) AddGraph(
+	g *typegraph.Graph,
+	edgeFilter func(edge typegraph.Edge) bool,
+	reason func(node *typegraph.Node) []string,
+) {
+	if r == nil {
+		return
+	}
+
+	var found []Type
+	externals := make(map[typegraph.Ident]*typegraph.Node)
+	for ident, node := range g.EnumerateTypes() {
+		t := Type{
+			PkgPath: ident.PkgPath,
+			Name:    ident.TypeName,
+			Match:   node.Matched.Strings(),
+		}
+		if !node.Matched.IsMatched() && !node.Matched.IsDependant() {
+			if reason != nil {
+				t.Reasons = append(t.Reasons, reason(node)...)
+			}
+			if edgeFilter != nil {
+				t.Reasons = append(t.Reasons, rejectedEdges(node, edgeFilter)...)
+			}
+		}
+		found = append(found, t)
+		for childIdent, edges := range node.Children {
+			if edges[0].ChildNode.Matched.IsExternal() {
+				externals[childIdent] = edges[0].ChildNode
+			}
+		}
+	}
+	for ident, node := range externals {
+		found = append(found, Type{
+			PkgPath: ident.PkgPath,
+			Name:    ident.TypeName,
+			Match:   node.Matched.Strings(),
+		})
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Types = append(r.Types, found...)
+}
+
+// AddFile records f.
+func (r *Run) AddFile(f File) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Files = append(r.Files, f)
+}
+
+// AddWarning records w.
+func (r *Run) AddWarning(w Warning) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Warnings = append(r.Warnings, w)
+}
+
+func (r *Run) sort() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	slices.Sort(r.Packages)
+	r.Packages = slices.Compact(r.Packages)
+	slices.SortFunc(r.Types, func(i, j Type) int {
+		return cmp.Or(cmp.Compare(i.PkgPath, j.PkgPath), cmp.Compare(i.Name, j.Name))
+	})
+	r.Types = slices.CompactFunc(r.Types, func(i, j Type) bool {
+		return i.PkgPath == j.PkgPath && i.Name == j.Name
+	})
+	slices.SortFunc(r.Files, func(i, j File) int {
+		return cmp.Or(
+			cmp.Compare(i.Source, j.Source),
+			cmp.Compare(i.Output, j.Output),
+			cmp.Compare(i.Status, j.Status),
+		)
+	})
+	slices.SortFunc(r.Warnings, func(i, j Warning) int {
+		return cmp.Or(
+			cmp.Compare(i.PkgPath, j.PkgPath),
+			cmp.Compare(i.Name, j.Name),
+			cmp.Compare(i.Field, j.Field),
+			cmp.Compare(i.Message, j.Message),
+		)
+	})
+}
+
+// rejectedEdges describes edges from node to target types that edgeFilter rejects.
+// Those are the reason node is not marked as dependant.
+func rejectedEdges(node *typegraph.Node, edgeFilter func(edge typegraph.Edge) bool) []string {
+	var reasons []string
+	for _, edges := range node.Children {
+		for _, edge := range edges {
+			if edge.ChildNode.Matched == 0 || edgeFilter(edge) {
+				continue
+			}
+			reasons = append(
+				reasons,
+				fmt.Sprintf("reference to %s is not supported: %s", edge.ChildType.String(), describeRoute(node, edge.Stack)),
+			)
+		}
+	}
+	slices.Sort(reasons)
+	return reasons
+}
+
+func describeRoute(node *typegraph.Node, stack []typegraph.EdgeRouteNode) string {
+	var (
+		sb    strings.Builder
+		kinds []string
+	)
+	for _, r := range stack {
+		if r.Kind == typegraph.EdgeKindStruct && r.Pos.IsSome() && len(kinds) == 0 && sb.Len() == 0 {
+			if st, ok := node.Type.Underlying().(*types.Struct); ok {
+				sb.WriteString("field " + st.Field(r.Pos.Value()).Name())
+				continue
+			}
+		}
+		kinds = append(kinds, r.Kind.String())
+	}
+	if len(kinds) > 0 {
+		if sb.Len() > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString("route " + strings.Join(kinds, " -> "))
+	}
+	return sb.String()
+}
@@ -0,0 +1,163 @@
+package genreport_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ngicks/go-codegen/codegen/generator/cloner"
+	"github.com/ngicks/go-codegen/codegen/pkg/genreport"
+	"github.com/ngicks/go-codegen/codegen/pkg/suffixwriter"
+	"github.com/ngicks/go-codegen/codegen/pkg/typegraph"
+	"golang.org/x/tools/go/packages"
+	"gotest.tools/v3/assert"
+)
+
+func TestReport_cloner(t *testing.T) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName |
+			packages.NeedImports |
+			packages.NeedDeps |
+			packages.NeedTypes |
+			packages.NeedSyntax |
+			packages.NeedTypesInfo |
+			packages.NeedTypesSizes,
+		Dir: "./testdata",
+	}
+	pkgs, err := packages.Load(cfg, "./rejected")
+	assert.NilError(t, err)
+
+	report := &genreport.Report{Dry: true}
+	run := report.NewRun("cloner")
+	run.AddPackages(pkgs)
+
+	w := suffixwriter.NewTestWriter(".clone", suffixwriter.WithCwd("./testdata"))
+	err = (&cloner.Config{
+		MatcherConfig: &cloner.MatcherConfig{ChannelHandle: cloner.CopyHandleDisallow},
+		Report:        run,
+	}).Generate(context.Background(), w.Writer, pkgs)
+	assert.NilError(t, err)
+
+	var buf bytes.Buffer
+	assert.NilError(t, report.WriteJSON(&buf))
+	var decoded genreport.Report
+	assert.NilError(t, json.Unmarshal(buf.Bytes(), &decoded))
+
+	const pkgPath = "github.com/ngicks/go-codegen/codegen/pkg/genreport/testdata/rejected"
+	assert.Assert(t, decoded.Dry)
+	assert.Equal(t, len(decoded.Runs), 1)
+	assert.Equal(t, decoded.Runs[0].Generator, "cloner")
+	assert.DeepEqual(t, decoded.Runs[0].Packages, []string{pkgPath})
+	assert.DeepEqual(
+		t,
+		decoded.Runs[0].Types,
+		[]genreport.Type{
+			{
+				PkgPath: pkgPath,
+				Name:    "Disallowed",
+				Match:   []string{},
+				Reasons: []string{"field C: contains channel: ChannelHandle is CopyHandleDisallow"},
+			},
+			{
+				PkgPath: pkgPath,
+				Name:    "Func",
+				Match:   []string{},
+				Reasons: []string{"unsupported underlying type func(): must be struct, array, slice or map"},
+			},
+			{
+				PkgPath: pkgPath,
+				Name:    "Matched",
+				Match:   []string{"matched"},
+			},
+			{
+				PkgPath: pkgPath,
+				Name:    "NoField",
+				Match:   []string{},
+				Reasons: []string{"no field to clone: every field is ignored or assignable"},
+			},
+			{
+				PkgPath: pkgPath,
+				Name:    "ViaChan",
+				Match:   []string{},
+				Reasons: []string{
+					"field C: contains channel: ChannelHandle is CopyHandleDisallow",
+					"reference to " + pkgPath + ".Matched is not supported: field C, route chan",
+				},
+			},
+		},
+	)
+	assert.Assert(t, decoded.Runs[0].Warnings == nil)
+}
+
+func TestExplain_cloner(t *testing.T) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName |
+			packages.NeedImports |
+			packages.NeedDeps |
+			packages.NeedTypes |
+			packages.NeedSyntax |
+			packages.NeedTypesInfo |
+			packages.NeedTypesSizes,
+		Dir: "./testdata",
+	}
+	pkgs, err := packages.Load(cfg, "./rejected")
+	assert.NilError(t, err)
+
+	const pkgPath = "github.com/ngicks/go-codegen/codegen/pkg/genreport/testdata/rejected"
+	c := &cloner.Config{MatcherConfig: &cloner.MatcherConfig{ChannelHandle: cloner.CopyHandleDisallow}}
+
+	e, err := c.Explain(pkgs, typegraph.Ident{PkgPath: pkgPath, TypeName: "ViaChan"})
+	assert.NilError(t, err)
+	assert.DeepEqual(
+		t,
+		*e,
+		genreport.Explanation{
+			Type: genreport.Type{
+				PkgPath: pkgPath,
+				Name:    "ViaChan",
+				Match:   []string{},
+				Reasons: []string{
+					"field C: contains channel: ChannelHandle is CopyHandleDisallow",
+					"reference to " + pkgPath + ".Matched is not supported: field C, route chan",
+				},
+			},
+			Fields: []genreport.Field{
+				{
+					Index:     0,
+					Name:      "C",
+					Type:      "chan Matched",
+					Route:     []string{"chan"},
+					Decision:  "disallowed",
+					Rejection: "contains channel: ChannelHandle is CopyHandleDisallow",
+				},
+			},
+			References: []genreport.Reference{
+				{
+					Type:    pkgPath + ".Matched",
+					Match:   []string{"matched"},
+					Via:     "field C, route chan",
+					Allowed: false,
+				},
+			},
+		},
+	)
+
+	var buf bytes.Buffer
+	assert.NilError(t, e.WriteText(&buf))
+	assert.Assert(t, strings.HasPrefix(buf.String(), pkgPath+".ViaChan: not a target\n"))
+
+	_, err = c.Explain(pkgs, typegraph.Ident{PkgPath: pkgPath, TypeName: "Unknown"})
+	assert.ErrorContains(t, err, "is not in the type graph")
+}
+
+func TestRun_nil(t *testing.T) {
+	var report *genreport.Report
+	run := report.NewRun("cloner")
+	assert.Assert(t, run == nil)
+	run.AddPackages([]*packages.Package{{PkgPath: "foo"}})
+	run.AddGraph(nil, nil, nil)
+	run.AddFile(genreport.File{Source: "foo.go", Status: genreport.FileSkipped})
+	run.AddWarning(genreport.Warning{PkgPath: "foo", Name: "Foo", Message: "bar"})
+}
@@ -0,0 +1,17 @@
+package rejected
+
+type Matched struct {
+	P *int
+}
+
+type Disallowed struct {
+	C chan int
+}
+
+type ViaChan struct {
+	C chan Matched
+}
+
+type Func func()
+
+type NoField struct{}
@@ -0,0 +1,51 @@
+package pkgsutil
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// MatchPattern reports whether pkgDir is matched by the relative package pattern, e.g. "./", "./foo" or "./foo/...".
+// pattern is evaluated under dir as the go command does.
+//
+// Patterns that are not relative to dir, e.g. import paths, never match.
+func MatchPattern(dir string, pattern string, pkgDir string) bool {
+	if pattern != "." && !strings.HasPrefix(pattern, "./") {
+		return false
+	}
+	rel, err := filepath.Rel(dir, pkgDir)
+	if err != nil {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+	if rel == ".." || strings.HasPrefix(rel, "../") {
+		return false
+	}
+
+	p := path.Clean(pattern)
+	if prefix, ok := strings.CutSuffix(p, "..."); ok {
+		if dirPrefix, ok := strings.CutSuffix(prefix, "/"); ok {
+			return rel == dirPrefix || strings.HasPrefix(rel, prefix)
+		}
+		return prefix == "" || strings.HasPrefix(rel, prefix)
+	}
+	return rel == p
+}
+
+// FilterByPattern returns packages in pkgs whose directory is matched by any of patterns.
+// See [MatchPattern] for details.
+func FilterByPattern(pkgs []*packages.Package, dir string, patterns ...string) []*packages.Package {
+	var filtered []*packages.Package
+	for _, pkg := range pkgs {
+		for _, pattern := range patterns {
+			if MatchPattern(dir, pattern, pkg.Dir) {
+				filtered = append(filtered, pkg)
+				break
+			}
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,37 @@
+package pkgsutil
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestMatchPattern(t *testing.T) {
+	for _, tc := range []struct {
+		pattern string
+		pkgDir  string
+		matched bool
+	}{
+		{"./", "/root", true},
+		{".", "/root", true},
+		{"./", "/root/foo", false},
+		{"./...", "/root", true},
+		{"./...", "/root/foo/bar", true},
+		{"./foo", "/root/foo", true},
+		{"./foo/", "/root/foo", true},
+		{"./foo", "/root/foo/bar", false},
+		{"./foo/...", "/root/foo", true},
+		{"./foo/...", "/root/foo/bar", true},
+		{"./foo/...", "/root/foobar", false},
+		{"./foo...", "/root/foobar", true},
+		{"./...", "/other", false},
+		{"github.com/foo/bar", "/root/foo/bar", false},
+	} {
+		assert.Equal(
+			t,
+			MatchPattern("/root", tc.pattern, tc.pkgDir),
+			tc.matched,
+			"pattern = %q, pkgDir = %q", tc.pattern, tc.pkgDir,
+		)
+	}
+}
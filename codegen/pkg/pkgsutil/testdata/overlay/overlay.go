@@ -0,0 +1,5 @@
+package overlay
+
+type Foo struct {
+	Bar string
+}
@@ -0,0 +1,173 @@
+package pkgsutil
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"maps"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// TypeCheckError is a type error reported for code held in an overlay.
+type TypeCheckError struct {
+	// Filename is the name of the overlaid file where the error is found.
+	Filename string
+	// Decl describes the top level declaration enclosing the error position,
+	// e.g. "Foo.Clone" for a method or "FooPatch" for a type.
+	// It is empty if the error position is not inside any declaration.
+	Decl string
+	// Type is the name of the type the enclosing declaration belongs to.
+	// That is the receiver type for methods and the type itself for type declarations.
+	Type string
+	Err  types.Error
+}
+
+func (e TypeCheckError) Error() string {
+	if e.Decl == "" {
+		return fmt.Sprintf("%s: %s", e.Err.Fset.Position(e.Err.Pos), e.Err.Msg)
+	}
+	return fmt.Sprintf("%s: in %s (generated for type %s): %s", e.Err.Fset.Position(e.Err.Pos), e.Decl, e.Type, e.Err.Msg)
+}
+
+func (e TypeCheckError) Unwrap() error {
+	return e.Err
+}
+
+// TypeCheckOverlay loads packages matched to patterns with overlay injected through cfg.Overlay,
+// then returns type errors found in overlaid files.
+// Keys of overlay must be absolute file paths.
+//
+// cfg.Mode is always extended so that syntax and type information are loaded,
+// and packages are always loaded with tests so that overlaid test files are also checked.
+// Errors other than type errors, e.g. parse errors in overlaid files, are returned as err.
+func TypeCheckOverlay(cfg *packages.Config, overlay map[string][]byte, patterns ...string) (typeErrs []TypeCheckError, err error) {
+	if len(overlay) == 0 {
+		return nil, nil
+	}
+
+	c := *cfg
+	c.Mode |= packages.NeedName |
+		packages.NeedImports |
+		packages.NeedDeps |
+		packages.NeedTypes |
+		packages.NeedSyntax |
+		packages.NeedTypesInfo |
+		packages.NeedTypesSizes
+	c.Tests = true
+	c.Overlay = maps.Clone(cfg.Overlay)
+	if c.Overlay == nil {
+		c.Overlay = make(map[string][]byte, len(overlay))
+	}
+	maps.Copy(c.Overlay, overlay)
+
+	pkgs, err := packages.Load(&c, patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	var loadErrs []error
+	// Non-test files are loaded twice, for the package and for the package compiled with its tests.
+	seen := make(map[string]bool)
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		for _, e := range pkg.Errors {
+			if e.Kind == packages.TypeError {
+				continue
+			}
+			if _, ok := overlay[errorFilename(e.Pos)]; ok && !seen[e.Error()] {
+				seen[e.Error()] = true
+				loadErrs = append(loadErrs, e)
+			}
+		}
+		for _, e := range pkg.TypeErrors {
+			filename := e.Fset.Position(e.Pos).Filename
+			if _, ok := overlay[filename]; !ok || seen[e.Error()] {
+				continue
+			}
+			seen[e.Error()] = true
+			typeErr := TypeCheckError{Filename: filename, Err: e}
+			if f := fileByName(pkg, filename); f != nil {
+				typeErr.Decl, typeErr.Type = enclosingDecl(f, e.Pos)
+			}
+			typeErrs = append(typeErrs, typeErr)
+		}
+	})
+	if len(loadErrs) > 0 {
+		format, _ := strings.CutSuffix(strings.Repeat("%w,\n", len(loadErrs)), ",\n")
+		args := make([]any, len(loadErrs))
+		for i, e := range loadErrs {
+			args[i] = e
+		}
+		return typeErrs, fmt.Errorf("overlay load error: "+format, args...)
+	}
+	return typeErrs, nil
+}
+
+// errorFilename extracts filename from packages.Error.Pos, which is formatted as "file:line:col", "file:line" or "file".
+func errorFilename(pos string) string {
+	for range 2 {
+		i := strings.LastIndexByte(pos, ':')
+		if i < 0 || strings.IndexFunc(pos[i+1:], func(r rune) bool { return r < '0' || '9' < r }) >= 0 {
+			break
+		}
+		pos = pos[:i]
+	}
+	return filepath.Clean(pos)
+}
+
+func fileByName(pkg *packages.Package, filename string) *ast.File {
+	for _, f := range pkg.Syntax {
+		if pkg.Fset.Position(f.FileStart).Filename == filename {
+			return f
+		}
+	}
+	return nil
+}
+
+func enclosingDecl(f *ast.File, pos token.Pos) (decl string, typeName string) {
+	for _, d := range f.Decls {
+		if pos < d.Pos() || d.End() < pos {
+			continue
+		}
+		switch x := d.(type) {
+		case *ast.FuncDecl:
+			if x.Recv == nil || len(x.Recv.List) == 0 {
+				return x.Name.Name, ""
+			}
+			typeName = receiverTypeName(x.Recv.List[0].Type)
+			return typeName + "." + x.Name.Name, typeName
+		case *ast.GenDecl:
+			for _, spec := range x.Specs {
+				if pos < spec.Pos() || spec.End() < pos {
+					continue
+				}
+				if ts, ok := spec.(*ast.TypeSpec); ok {
+					return ts.Name.Name, ts.Name.Name
+				}
+			}
+		}
+	}
+	return "", ""
+}
+
+func receiverTypeName(expr ast.Expr) string {
+	for {
+		switch x := expr.(type) {
+		case *ast.StarExpr:
+			expr = x.X
+		case *ast.IndexExpr:
+			expr = x.X
+		case *ast.IndexListExpr:
+			expr = x.X
+		case *ast.ParenExpr:
+			expr = x.X
+		case *ast.Ident:
+			return x.Name
+		default:
+			return ""
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package pkgsutil
+
+import (
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+	"gotest.tools/v3/assert"
+)
+
+func TestTypeCheckOverlay(t *testing.T) {
+	dir, err := filepath.Abs("./testdata/overlay")
+	assert.NilError(t, err)
+	generated := filepath.Join(dir, "overlay.gen.go")
+
+	cfg := &packages.Config{Dir: dir}
+
+	typeErrs, err := TypeCheckOverlay(
+		cfg,
+		map[string][]byte{
+			generated: []byte(`package overlay
+
+func (v Foo) Clone() Foo {
+	return Foo{Bar: v.Bar}
+}
+`),
+		},
+		"./",
+	)
+	assert.NilError(t, err)
+	assert.Equal(t, len(typeErrs), 0)
+
+	typeErrs, err = TypeCheckOverlay(
+		cfg,
+		map[string][]byte{
+			generated: []byte(`package overlay
+
+func (v *Foo) Clone() Foo {
+	return Foo{Baz: v.Bar}
+}
+`),
+		},
+		"./",
+	)
+	assert.NilError(t, err)
+	assert.Equal(t, len(typeErrs), 1)
+	assert.Equal(t, typeErrs[0].Filename, generated)
+	assert.Equal(t, typeErrs[0].Decl, "Foo.Clone")
+	assert.Equal(t, typeErrs[0].Type, "Foo")
+}
+
+func TestTypeCheckOverlay_tests(t *testing.T) {
+	dir, err := filepath.Abs("./testdata/overlay")
+	assert.NilError(t, err)
+	generated := filepath.Join(dir, "overlay.gen.go")
+	generatedTest := filepath.Join(dir, "overlay.gen_test.go")
+
+	typeErrs, err := TypeCheckOverlay(
+		&packages.Config{Dir: dir},
+		map[string][]byte{
+			generated: []byte(`package overlay
+
+func (v Foo) Clone() Foo {
+	return Foo{Baz: v.Bar}
+}
+`),
+			generatedTest: []byte(`package overlay
+
+import "testing"
+
+func TestClone(t *testing.T) {
+	_ = Foo{}.Clone().Qux
+}
+`),
+		},
+		"./",
+	)
+	assert.NilError(t, err)
+	// errors in non-test files are reported only once even though they are also loaded with tests.
+	assert.Equal(t, len(typeErrs), 2)
+	assert.Equal(t, typeErrs[0].Filename, generated)
+	assert.Equal(t, typeErrs[1].Filename, generatedTest)
+	assert.Equal(t, typeErrs[1].Decl, "TestClone")
+}
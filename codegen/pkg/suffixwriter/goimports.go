@@ -5,8 +5,53 @@ import (
 	"context"
 	"fmt"
 	"os/exec"
+
+	"golang.org/x/tools/imports"
+)
+
+// Formatter selects how the Writer formats generated code.
+type Formatter int
+
+const (
+	// FormatterInProcess formats code by "golang.org/x/tools/imports".Process in the process.
+	// No external binary is needed.
+	FormatterInProcess Formatter = iota
+	// FormatterGoimports formats code by executing the goimports binary found in PATH.
+	FormatterGoimports
 )
 
+// WithFormatter sets pre and post process of the Writer to ones that correspond to f.
+// [FormatterInProcess] is the default.
+func WithFormatter(f Formatter) Option {
+	return func(p *Writer) {
+		switch f {
+		case FormatterGoimports:
+			p.preProcess = func(name string) error { return checkGoimportsOnce() }
+			p.postProcess = ApplyGoimports
+		default:
+			p.preProcess = func(name string) error { return nil }
+			p.postProcess = ApplyImports
+		}
+	}
+}
+
+// ApplyImports formats buf and fixes its imports as goimports does, but in the process.
+func ApplyImports(ctx context.Context, buf []byte) ([]byte, error) {
+	formatted, err := imports.Process(
+		"",
+		buf,
+		&imports.Options{
+			Comments:  true,
+			TabIndent: true,
+			TabWidth:  8,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("imports.Process failed: input = %s\nerr = %w", buf, err)
+	}
+	return formatted, nil
+}
+
 func CheckGoimports() error {
 	_, err := exec.LookPath("goimports")
 	if err != nil {
@@ -0,0 +1,37 @@
+package suffixwriter
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestApplyImports(t *testing.T) {
+	formatted, err := ApplyImports(
+		context.Background(),
+		[]byte(`package foo
+import (
+"fmt"
+"strings"
+)
+func Foo() string { return strings.Repeat("a",  2) }
+`),
+	)
+	assert.NilError(t, err)
+	assert.Equal(
+		t,
+		string(formatted),
+		`package foo
+
+import (
+	"strings"
+)
+
+func Foo() string { return strings.Repeat("a", 2) }
+`,
+	)
+
+	_, err = ApplyImports(context.Background(), []byte("package foo\nfunc {"))
+	assert.ErrorContains(t, err, "imports.Process failed")
+}
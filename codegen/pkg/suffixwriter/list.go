@@ -0,0 +1,58 @@
+package suffixwriter
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// ListSuffixed lists files directly under dir whose names are suffixed with suffix
+// and whose contents start with prefix.
+// Only files with ".go" extension are listed.
+// Returned paths are joined with dir and sorted.
+func ListSuffixed(dir, suffix string, prefix []byte) ([]string, error) {
+	dirents, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var found []string
+	for _, dirent := range dirents {
+		name := dirent.Name()
+		if !dirent.Type().IsRegular() || !strings.HasSuffix(name, ".go") || !IsSuffixed(name, suffix) {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		ok, err := hasPrefix(path, prefix)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			found = append(found, path)
+		}
+	}
+	slices.Sort(found)
+	return found, nil
+}
+
+func hasPrefix(name string, prefix []byte) (bool, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	head := make([]byte, len(prefix))
+	_, err = io.ReadFull(f, head)
+	switch err {
+	case nil:
+	case io.EOF, io.ErrUnexpectedEOF:
+		return false, nil
+	default:
+		return false, err
+	}
+	return bytes.Equal(head, prefix), nil
+}
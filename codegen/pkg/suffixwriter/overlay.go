@@ -0,0 +1,85 @@
+package suffixwriter
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"sync"
+)
+
+// OverlayWriter is a Writer that keeps every output in memory instead of writing them to files.
+//
+// Outputs can be passed to "golang.org/x/tools/go/packages".Config.Overlay via [OverlayWriter.Overlay]
+// so that generated code is type-checked before anything touches disk.
+// Call [OverlayWriter.Commit] to actually write them to files.
+type OverlayWriter struct {
+	*Writer
+	mu      sync.Mutex
+	overlay map[string][]byte
+}
+
+func NewOverlayWriter(suffix string, opts ...Option) *OverlayWriter {
+	w := &OverlayWriter{
+		overlay: make(map[string][]byte),
+	}
+	opts = append(
+		opts,
+		WithFileFactory(func(name string) (io.WriteCloser, error) {
+			return &overlayFile{
+				w:    w,
+				name: name,
+				buf:  new(bytes.Buffer),
+			}, nil
+		}),
+		WithFileRemover(func(name string) error {
+			w.mu.Lock()
+			defer w.mu.Unlock()
+			delete(w.overlay, name)
+			return nil
+		}),
+	)
+	w.Writer = New(suffix, opts...)
+	return w
+}
+
+type overlayFile struct {
+	w    *OverlayWriter
+	name string
+	buf  *bytes.Buffer
+}
+
+func (f *overlayFile) Write(p []byte) (int, error) {
+	return f.buf.Write(p)
+}
+
+func (f *overlayFile) Close() error {
+	f.w.mu.Lock()
+	defer f.w.mu.Unlock()
+	f.w.overlay[f.name] = f.buf.Bytes()
+	return nil
+}
+
+// Overlay returns a map of absolute filename to the content written so far.
+func (w *OverlayWriter) Overlay() map[string][]byte {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return maps.Clone(w.overlay)
+}
+
+// Commit writes all contents held in memory to files.
+// Files are written in lexical order of their names.
+func (w *OverlayWriter) Commit() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, name := range slices.Sorted(maps.Keys(w.overlay)) {
+		w.logf("write: %s\n", name)
+		err := os.WriteFile(name, w.overlay[name], 0o666)
+		if err != nil {
+			return fmt.Errorf("writing %q: %w", name, err)
+		}
+	}
+	return nil
+}
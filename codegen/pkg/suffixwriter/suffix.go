@@ -170,3 +170,15 @@ func stripBuildConstrains(f string) (base, sufFormer, sufLatter, sufTest, ext st
 
 	return
 }
+
+// SourceFilename is the inverse of [SuffixFilename].
+// It returns the name of the source file from which f is generated.
+// ok is false if f is not suffixed with suffix.
+func SourceFilename(f, suffix string) (source string, ok bool) {
+	base, sufFormer, sufLatter, sufTest, ext := stripBuildConstrains(f)
+	base, ok = strings.CutSuffix(base, suffix)
+	if !ok {
+		return "", false
+	}
+	return base + sufFormer + sufLatter + sufTest + ext, true
+}
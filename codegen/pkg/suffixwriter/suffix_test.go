@@ -47,3 +47,21 @@ func TestIsSuffixed(t *testing.T) {
 		assert.Assert(t, !IsSuffixed(name, ".suffix"))
 	}
 }
+
+func TestSourceFilename(t *testing.T) {
+	for _, set := range [][2]string{
+		{"foo.go", "foo.suffix.go"},
+		{"foo_test.go", "foo.suffix_test.go"},
+		{"foo_linux.go", "foo.suffix_linux.go"},
+		{"foo_linux_amd64_test.go", "foo.suffix_linux_amd64_test.go"},
+		{"foo_bar.go", "foo_bar.suffix.go"},
+		{"/path/to/foo_bar_linux.go", "/path/to/foo_bar.suffix_linux.go"},
+	} {
+		source, ok := SourceFilename(set[1], ".suffix")
+		assert.Assert(t, ok)
+		assert.Equal(t, set[0], source)
+	}
+
+	_, ok := SourceFilename("foo.go", ".suffix")
+	assert.Assert(t, !ok)
+}
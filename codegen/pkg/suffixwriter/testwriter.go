@@ -26,6 +26,12 @@ func NewTestWriter(suffix string, opts ...Option) *TestWriter {
 				buf:  new(bytes.Buffer),
 			}, nil
 		}),
+		WithFileRemover(func(name string) error {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			delete(p.results, name)
+			return nil
+		}),
 	)
 	p.Writer = New(
 		suffix,
@@ -7,6 +7,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"sync"
 )
@@ -16,10 +17,12 @@ type Writer struct {
 	cwd         string
 	suffix      string
 	fileFactory func(name string) (io.WriteCloser, error)
+	fileRemover func(name string) error
 	preProcess  PreProcess
 	postProcess PostProcess
 	logf        func(format string, args ...any)
 	prefix      []byte
+	jobs        int
 }
 
 type PreProcess func(name string) error
@@ -35,8 +38,9 @@ func New(suffix string, opts ...Option) *Writer {
 		fileFactory: func(name string) (io.WriteCloser, error) {
 			return os.Create(name)
 		},
-		preProcess:  func(name string) error { return checkGoimportsOnce() },
-		postProcess: ApplyGoimports,
+		fileRemover: os.Remove,
+		preProcess:  func(name string) error { return nil },
+		postProcess: ApplyImports,
 		logf:        func(format string, args ...any) {},
 	}
 	for _, opt := range opts {
@@ -59,6 +63,14 @@ func WithFileFactory(fileFactory func(name string) (io.WriteCloser, error)) Opti
 	}
 }
 
+// WithFileRemover sets a function that removes the file created by the file factory.
+// It is called when Write fails after the file is opened.
+func WithFileRemover(fileRemover func(name string) error) Option {
+	return func(p *Writer) {
+		p.fileRemover = fileRemover
+	}
+}
+
 func WithPreProcess(preProcess PreProcess) Option {
 	return func(p *Writer) {
 		p.preProcess = preProcess
@@ -83,21 +95,40 @@ func WithPrefix(prefix []byte) Option {
 	}
 }
 
+// WithJobs sets the maximum number of files generators may generate and write concurrently through the Writer.
+// If jobs is less than 1, the number is runtime.GOMAXPROCS(0), which is also the default.
+func WithJobs(jobs int) Option {
+	return func(p *Writer) {
+		p.jobs = jobs
+	}
+}
+
+// Jobs returns the maximum number of files to be generated concurrently.
+// See [WithJobs].
+func (p *Writer) Jobs() int {
+	if p.jobs < 1 {
+		return runtime.GOMAXPROCS(0)
+	}
+	return p.jobs
+}
+
 func (p *Writer) suffixFilename(name string) (string, error) {
+	// Write may be called concurrently; do not store the resolved cwd to p.
 	var err error
-	if p.cwd == "" {
-		p.cwd, err = os.Getwd()
+	cwd := p.cwd
+	if cwd == "" {
+		cwd, err = os.Getwd()
 		if err != nil {
 			return "", fmt.Errorf("getting cwd: %w", err)
 		}
 	}
-	if !filepath.IsAbs(p.cwd) {
-		p.cwd, err = filepath.Abs(p.cwd)
+	if !filepath.IsAbs(cwd) {
+		cwd, err = filepath.Abs(cwd)
 		if err != nil {
 			return "", fmt.Errorf("filepath.Abs: %w", err)
 		}
 	}
-	rel, err := filepath.Rel(p.cwd, name)
+	rel, err := filepath.Rel(cwd, name)
 	if err != nil {
 		return "", err
 	}
@@ -123,30 +154,46 @@ func (p *Writer) openFile(name string) (w io.WriteCloser, filename string, err e
 
 // Write write b into name but suffixed.
 func (p *Writer) Write(ctx context.Context, name string, b []byte) error {
+	processed, err := p.Format(ctx, name, b)
+	if err != nil {
+		return err
+	}
+	return p.WriteProcessed(ctx, name, processed)
+}
+
+// Format applies pre and post process of p to b, which will be written for name.
+// The result can be later written by [Writer.WriteProcessed].
+func (p *Writer) Format(ctx context.Context, name string, b []byte) ([]byte, error) {
 	err := p.preProcess(name)
 	if err != nil {
-		return fmt.Errorf("preprocessing %q: %w", name, err)
+		return nil, fmt.Errorf("preprocessing %q: %w", name, err)
+	}
+	processed, err := p.postProcess(ctx, b)
+	if err != nil {
+		return nil, fmt.Errorf("postprocessing input for %q: %w", SuffixFilename(name, p.suffix), err)
 	}
+	return processed, nil
+}
+
+// WriteProcessed is like [Writer.Write] but writes b as it is, without pre and post process.
+// b is expected to be a result of [Writer.Format], possibly cached from a previous run.
+func (p *Writer) WriteProcessed(ctx context.Context, name string, b []byte) (err error) {
 	w, filename, err := p.openFile(name)
 	defer func() {
 		if filename != "" && err != nil {
-			_ = os.Remove(filename)
+			_ = p.fileRemover(filename)
 		}
 	}()
 	if err != nil {
 		return fmt.Errorf("opening %q(for %q): %w", filename, name, err)
 	}
-	processed, err := p.postProcess(ctx, b)
-	if err != nil {
-		return fmt.Errorf("postprocessing input for %q: %w", filename, err)
-	}
 
 	// write
 	if len(p.prefix) > 0 {
 		_, err = w.Write(p.prefix)
 	}
 	if err == nil {
-		_, err = w.Write(processed)
+		_, err = w.Write(b)
 	}
 	if err != nil {
 		return fmt.Errorf("writing to %q: %w", filename, err)
@@ -0,0 +1,299 @@
+package typegraph
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"go/types"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Strings returns names of kinds set in k, in the order of matched, dependant and external.
+// It returns an empty slice if k is zero.
+func (k MatchKind) Strings() []string {
+	kinds := []string{}
+	if k.IsMatched() {
+		kinds = append(kinds, "matched")
+	}
+	if k.IsDependant() {
+		kinds = append(kinds, "dependant")
+	}
+	if k.IsExternal() {
+		kinds = append(kinds, "external")
+	}
+	return kinds
+}
+
+// Subgraph returns a graph that only contains nodes reachable from from by following children edges,
+// including nodes for from themselves.
+// Idents not in g are ignored.
+//
+// Nodes are shared with g. Edges of a node may still point to nodes outside the returned graph;
+// [Graph.WriteDOT] and [Graph.MarshalJSON] drop those edges.
+func (g *Graph) Subgraph(from ...Ident) *Graph {
+	sub := &Graph{
+		types:      make(map[Ident]*Node),
+		matched:    make(map[Ident]*Node),
+		external:   make(map[Ident]*Node),
+		privParser: g.privParser,
+	}
+	var visit func(i Ident, n *Node)
+	visit = func(i Ident, n *Node) {
+		if n.Matched.IsExternal() {
+			if _, ok := sub.external[i]; ok {
+				return
+			}
+			sub.external[i] = n
+		} else {
+			if _, ok := sub.types[i]; ok {
+				return
+			}
+			sub.types[i] = n
+			if n.Matched.IsMatched() {
+				sub.matched[i] = n
+			}
+		}
+		for childIdent, edges := range n.Children {
+			visit(childIdent, edges[0].ChildNode)
+		}
+	}
+	for _, i := range from {
+		if n, ok := g.types[i]; ok {
+			visit(i, n)
+		} else if n, ok := g.external[i]; ok {
+			visit(i, n)
+		}
+	}
+	return sub
+}
+
+// exportNode is a node of the graph as it is exported.
+type exportNode struct {
+	Ident
+	node *Node
+}
+
+// exportEdge is an edge of the graph as it is exported.
+type exportEdge struct {
+	From, To Ident
+	edge     Edge
+}
+
+// exported lists nodes, including external ones, and edges between them in a stable order.
+func (g *Graph) exported() ([]exportNode, []exportEdge) {
+	all := maps.Clone(g.types)
+	maps.Copy(all, g.external)
+
+	idents := slices.SortedFunc(maps.Keys(all), compareIdent)
+	nodes := make([]exportNode, 0, len(idents))
+	var edges []exportEdge
+	for _, from := range idents {
+		n := all[from]
+		nodes = append(nodes, exportNode{from, n})
+		for _, to := range slices.SortedFunc(maps.Keys(n.Children), compareIdent) {
+			if _, ok := all[to]; !ok {
+				continue
+			}
+			for _, e := range n.Children[to] {
+				edges = append(edges, exportEdge{from, to, e})
+			}
+		}
+	}
+	return nodes, edges
+}
+
+func compareIdent(i, j Ident) int {
+	if c := cmp.Compare(i.PkgPath, j.PkgPath); c != 0 {
+		return c
+	}
+	return cmp.Compare(i.TypeName, j.TypeName)
+}
+
+// RouteLabel describes e.Stack as Go type syntax, prefixed with the field selector if the route goes through struct fields.
+// For example, the edge from
+//
+//	type A struct { B []*map[string]C }
+//
+// to C is labelled "B []*map".
+func (e Edge) RouteLabel() string {
+	var (
+		ty     = e.ParentNode.Type.Underlying()
+		fields []string
+		route  strings.Builder
+	)
+	for _, r := range e.Stack {
+		switch x := ty.(type) {
+		case *types.Alias:
+			ty = x.Rhs()
+			continue
+		case *types.Array:
+			route.WriteString("[" + strconv.FormatInt(x.Len(), 10) + "]")
+			ty = x.Elem()
+			continue
+		case *types.Chan:
+			route.WriteString("chan ")
+			ty = x.Elem()
+			continue
+		case *types.Map:
+			route.WriteString("map")
+			ty = x.Elem()
+			continue
+		case *types.Pointer:
+			route.WriteString("*")
+			ty = x.Elem()
+			continue
+		case *types.Slice:
+			route.WriteString("[]")
+			ty = x.Elem()
+			continue
+		case *types.Struct:
+			if r.Kind == EdgeKindStruct && r.Pos.IsSome() {
+				f := x.Field(r.Pos.Value())
+				if route.Len() > 0 {
+					// a field of a struct literal nested in other types.
+					fields = append(fields, route.String()+"struct{}")
+					route.Reset()
+				}
+				fields = append(fields, f.Name())
+				ty = f.Type()
+				continue
+			}
+		}
+		// Fallback to the kind names if the type does not follow the route.
+		route.WriteString(r.Kind.String() + " ")
+	}
+
+	label := strings.Join(fields, ".")
+	if route.Len() > 0 {
+		if label != "" {
+			label += " "
+		}
+		label += strings.TrimSpace(route.String())
+	}
+	return label
+}
+
+// dotColors are fill colors of nodes in the DOT output for each [MatchKind].
+var dotColors = []struct {
+	kind  MatchKind
+	color string
+}{
+	{MatchKindMatched, "palegreen"},
+	{MatchKindDependant, "lightblue"},
+	{MatchKindExternal, "lightgrey"},
+}
+
+// WriteDOT writes g to w in the Graphviz DOT language.
+//
+// Nodes are filled with colors based on their [MatchKind]:
+// palegreen for matched, lightblue for dependant, lightgrey for external and white otherwise.
+// Edges are directed from a type to types it refers to,
+// and labelled with [Edge.RouteLabel].
+func (g *Graph) WriteDOT(w io.Writer) error {
+	bufw := bufio.NewWriter(w)
+	nodes, edges := g.exported()
+
+	fmt.Fprintln(bufw, "digraph typegraph {")
+	fmt.Fprintln(bufw, "\tnode [shape=box, style=filled, fillcolor=white];")
+	for _, n := range nodes {
+		attrs := []string{"label=" + strconv.Quote(shortName(n.node.Type))}
+		for _, c := range dotColors {
+			if n.node.Matched&c.kind > 0 {
+				attrs = append(attrs, "fillcolor="+c.color)
+				break
+			}
+		}
+		if kinds := n.node.Matched.Strings(); len(kinds) > 0 {
+			attrs = append(attrs, "tooltip="+strconv.Quote(strings.Join(kinds, ", ")))
+		}
+		fmt.Fprintf(bufw, "\t%s [%s];\n", dotID(n.Ident), strings.Join(attrs, ", "))
+	}
+	for _, e := range edges {
+		fmt.Fprintf(
+			bufw,
+			"\t%s -> %s [label=%s];\n",
+			dotID(e.From), dotID(e.To), strconv.Quote(e.edge.RouteLabel()),
+		)
+	}
+	fmt.Fprintln(bufw, "}")
+
+	return bufw.Flush()
+}
+
+func dotID(i Ident) string {
+	return strconv.Quote(i.PkgPath + "." + i.TypeName)
+}
+
+// shortName returns the name of ty qualified by its package name.
+func shortName(ty *types.Named) string {
+	if pkg := ty.Obj().Pkg(); pkg != nil {
+		return pkg.Name() + "." + ty.Obj().Name()
+	}
+	return ty.Obj().Name()
+}
+
+type jsonGraph struct {
+	Nodes []jsonNode `json:"nodes"`
+	Edges []jsonEdge `json:"edges"`
+}
+
+type jsonNode struct {
+	PkgPath  string   `json:"pkgPath"`
+	TypeName string   `json:"typeName"`
+	Match    []string `json:"match"`
+}
+
+type jsonIdent struct {
+	PkgPath  string `json:"pkgPath"`
+	TypeName string `json:"typeName"`
+}
+
+type jsonEdge struct {
+	From jsonIdent `json:"from"`
+	To   jsonIdent `json:"to"`
+	// ChildType is the instantiated type the edge points to, e.g. foo.Bar[int].
+	ChildType string `json:"childType"`
+	// Route lists kinds of the route, e.g. ["struct", "slice", "pointer"].
+	Route []string `json:"route"`
+	Label string   `json:"label"`
+}
+
+// MarshalJSON implements json.Marshaler.
+//
+// g is encoded as an object which has "nodes" and "edges".
+// Each node has its package path, type name and match kinds as strings returned from [MatchKind.Strings].
+// Each edge has idents of both ends, the instantiated child type, route kinds and [Edge.RouteLabel].
+func (g *Graph) MarshalJSON() ([]byte, error) {
+	nodes, edges := g.exported()
+
+	out := jsonGraph{
+		Nodes: make([]jsonNode, 0, len(nodes)),
+		Edges: make([]jsonEdge, 0, len(edges)),
+	}
+	for _, n := range nodes {
+		out.Nodes = append(out.Nodes, jsonNode{
+			PkgPath:  n.PkgPath,
+			TypeName: n.TypeName,
+			Match:    n.node.Matched.Strings(),
+		})
+	}
+	for _, e := range edges {
+		route := make([]string, len(e.edge.Stack))
+		for i, r := range e.edge.Stack {
+			route[i] = r.Kind.String()
+		}
+		out.Edges = append(out.Edges, jsonEdge{
+			From:      jsonIdent(e.From),
+			To:        jsonIdent(e.To),
+			ChildType: e.edge.ChildType.String(),
+			Route:     route,
+			Label:     e.edge.RouteLabel(),
+		})
+	}
+	return json.Marshal(out)
+}
@@ -0,0 +1,118 @@
+package typegraph
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func Test_export(t *testing.T) {
+	pkgs := pkgsMap["edges"]
+	graph, err := New(
+		pkgs,
+		func(node *Node, external bool) (bool, error) {
+			return isFakeTargetType(node.Type), nil
+		},
+		nil,
+		nil,
+	)
+	assert.NilError(t, err)
+	graph.MarkDependant(nil)
+
+	edgesIdent := func(name string) Ident {
+		return Ident{"github.com/ngicks/go-codegen/codegen/pkg/typegraph/testdata/edges", name}
+	}
+	fakeIdent := func(name string) Ident {
+		return Ident{"github.com/ngicks/go-codegen/codegen/pkg/typegraph/testdata/faketarget", name}
+	}
+
+	t.Run("RouteLabel", func(t *testing.T) {
+		for _, tc := range []struct {
+			parent, child Ident
+			label         string
+		}{
+			{edgesIdent("MereArray"), fakeIdent("FakeTarget2"), "[5]"},
+			{edgesIdent("MereMap"), fakeIdent("FakeTarget2"), "map"},
+			{edgesIdent("MereChan"), fakeIdent("FakeTarget"), "chan"},
+			{edgesIdent("MereStruct"), fakeIdent("FakeTarget"), "A *"},
+			{edgesIdent("MereStruct"), fakeIdent("FakeTarget2"), "B"},
+			{edgesIdent("Complex"), edgesIdent("MereArray"), "A *map[]*[3]map"},
+		} {
+			edge := graph.types[tc.parent].Children[tc.child][0]
+			assert.Equal(t, tc.label, edge.RouteLabel(), "%s -> %s", tc.parent.TypeName, tc.child.TypeName)
+		}
+	})
+
+	t.Run("Subgraph", func(t *testing.T) {
+		sub := graph.Subgraph(edgesIdent("Complex"), edgesIdent("Unknown"))
+		nodes, edges := sub.exported()
+		var idents []Ident
+		for _, n := range nodes {
+			idents = append(idents, n.Ident)
+		}
+		assert.DeepEqual(
+			t,
+			[]Ident{edgesIdent("Complex"), edgesIdent("MereArray"), fakeIdent("FakeTarget2")},
+			idents,
+		)
+		assert.Equal(t, 2, len(edges))
+	})
+
+	t.Run("WriteDOT", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NilError(t, graph.Subgraph(edgesIdent("MereStruct")).WriteDOT(&buf))
+		const prefix = `"github.com/ngicks/go-codegen/codegen/pkg/typegraph/testdata/`
+		assert.Equal(
+			t,
+			strings.Join([]string{
+				"digraph typegraph {",
+				"\tnode [shape=box, style=filled, fillcolor=white];",
+				"\t" + prefix + `edges.MereStruct" [label="edges.MereStruct", fillcolor=lightblue, tooltip="dependant"];`,
+				"\t" + prefix + `faketarget.FakeTarget" [label="faketarget.FakeTarget", fillcolor=lightgrey, tooltip="external"];`,
+				"\t" + prefix + `faketarget.FakeTarget2" [label="faketarget.FakeTarget2", fillcolor=lightgrey, tooltip="external"];`,
+				"\t" + prefix + `edges.MereStruct" -> ` + prefix + `faketarget.FakeTarget" [label="A *"];`,
+				"\t" + prefix + `edges.MereStruct" -> ` + prefix + `faketarget.FakeTarget2" [label="B"];`,
+				"}",
+				"",
+			}, "\n"),
+			buf.String(),
+		)
+	})
+
+	t.Run("MarshalJSON", func(t *testing.T) {
+		bin, err := json.Marshal(graph.Subgraph(edgesIdent("MereStruct")))
+		assert.NilError(t, err)
+		var decoded jsonGraph
+		assert.NilError(t, json.Unmarshal(bin, &decoded))
+		assert.DeepEqual(
+			t,
+			jsonGraph{
+				Nodes: []jsonNode{
+					{PkgPath: edgesIdent("MereStruct").PkgPath, TypeName: "MereStruct", Match: []string{"dependant"}},
+					{PkgPath: fakeIdent("FakeTarget").PkgPath, TypeName: "FakeTarget", Match: []string{"external"}},
+					{PkgPath: fakeIdent("FakeTarget2").PkgPath, TypeName: "FakeTarget2", Match: []string{"external"}},
+				},
+				Edges: []jsonEdge{
+					{
+						From:      jsonIdent(edgesIdent("MereStruct")),
+						To:        jsonIdent(fakeIdent("FakeTarget")),
+						ChildType: fakeIdent("FakeTarget").PkgPath + ".FakeTarget",
+						Route:     []string{"struct", "pointer"},
+						Label:     "A *",
+					},
+					{
+						From:      jsonIdent(edgesIdent("MereStruct")),
+						To:        jsonIdent(fakeIdent("FakeTarget2")),
+						ChildType: fakeIdent("FakeTarget2").PkgPath + ".FakeTarget2[int, int]",
+						Route:     []string{"struct"},
+						Label:     "B",
+					},
+				},
+			},
+			decoded,
+		)
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"maps"
 	"reflect"
 	"slices"
+	"strconv"
 
 	"github.com/ngicks/go-codegen/codegen/pkg/astutil"
 	"github.com/ngicks/go-codegen/codegen/pkg/imports"
@@ -215,6 +216,30 @@ const (
 	EdgeKindStruct
 )
 
+func (k EdgeKind) String() string {
+	switch k {
+	case EdgeKindAlias:
+		return "alias"
+	case EdgeKindArray:
+		return "array"
+	case EdgeKindChan:
+		return "chan"
+	case EdgeKindInterface:
+		return "interface"
+	case EdgeKindMap:
+		return "map"
+	case EdgeKindNamed:
+		return "named"
+	case EdgeKindPointer:
+		return "pointer"
+	case EdgeKindSlice:
+		return "slice"
+	case EdgeKindStruct:
+		return "struct"
+	}
+	return "EdgeKind(" + strconv.FormatUint(uint64(k), 10) + ")"
+}
+
 func FirstTypeIdent(m map[Ident][]Edge) (Ident, Edge) {
 	for k, e := range m {
 		return k, e[0]
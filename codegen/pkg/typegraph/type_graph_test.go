@@ -33,6 +33,7 @@ func init() {
 		Mode: packages.NeedName |
 			packages.NeedFiles |
 			packages.NeedImports |
+			packages.NeedDeps |
 			packages.NeedTypes |
 			packages.NeedSyntax |
 			packages.NeedTypesInfo |
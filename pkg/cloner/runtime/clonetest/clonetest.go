@@ -0,0 +1,354 @@
+// Package clonetest provides property tests for clone functions, used by test files the cloner generator writes.
+//
+// [Check] builds random values of a type, clones them, and reports
+//   - cloned values which are not deeply equal to originals.
+//   - originals changed by mutating cloned values, which means clones share memory with originals.
+//
+// Random values leave channels, functions, interfaces and no-copy objects (types with the Lock method) zero value
+// since clone methods copy or ignore them by design.
+// Values of types defined in other packages which have unexported fields are left zero value, or nil behind pointers,
+// since only their zero values are known to be valid.
+// Fields which clone methods leave zero value must be registered by [Skip].
+package clonetest
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+	"unsafe"
+)
+
+// Seeds is the number of seeds [Check] and [Fuzz] try.
+var Seeds = 100
+
+var skips sync.Map // reflect.Type -> map[string]bool
+
+// Skip registers fields of T which clone methods leave zero value, e.g. ones ignored by directives.
+// Random values leave them zero value as well.
+// Generated test files call it in init functions.
+func Skip[T any](fields ...string) {
+	set := map[string]bool{}
+	for _, f := range fields {
+		set[f] = true
+	}
+	skips.Store(reflect.TypeFor[T](), set)
+}
+
+func skipped(ty reflect.Type, field string) bool {
+	set, ok := skips.Load(ty)
+	return ok && set.(map[string]bool)[field]
+}
+
+// Check checks clone with random values of T built from seeds 0 to Seeds-1.
+func Check[T any](t *testing.T, clone func(T) T) {
+	t.Helper()
+	for seed := range uint64(Seeds) {
+		CheckSeed(t, clone, seed)
+	}
+}
+
+// Fuzz adds seeds 0 to Seeds-1 to the corpus of f then fuzzes clone by [CheckSeed].
+func Fuzz[T any](f *testing.F, clone func(T) T) {
+	for seed := range uint64(Seeds) {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, seed uint64) {
+		CheckSeed(t, clone, seed)
+	})
+}
+
+// Benchmark measures clone with a random value of T built from a fixed seed.
+func Benchmark[T any](b *testing.B, clone func(T) T) {
+	v := Rand[T](rand.New(rand.NewPCG(0, 0)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		_ = clone(v)
+	}
+}
+
+// CheckSeed checks clone with a random value of T built from seed.
+func CheckSeed[T any](t *testing.T, clone func(T) T, seed uint64) {
+	t.Helper()
+	if err := check(clone, seed); err != nil {
+		t.Error(err)
+	}
+}
+
+func check[T any](clone func(T) T, seed uint64) error {
+	org := Rand[T](rand.New(rand.NewPCG(seed, 0)))
+	// want is built in the same way as org but shares nothing with it.
+	want := Rand[T](rand.New(rand.NewPCG(seed, 0)))
+
+	cloned := clone(org)
+	// clone methods may make new channels, which are never deeply equal to originals.
+	clearChans(reflect.ValueOf(&cloned).Elem(), map[visitKey]bool{})
+	if !reflect.DeepEqual(cloned, want) {
+		return fmt.Errorf("seed %d: cloned value is not equal to the original:\noriginal = %#v\ncloned   = %#v", seed, want, cloned)
+	}
+	Mutate(&cloned, rand.New(rand.NewPCG(seed, 1)))
+	if !reflect.DeepEqual(org, want) {
+		return fmt.Errorf("seed %d: mutating cloned value changed the original:\nbefore = %#v\nafter  = %#v", seed, want, org)
+	}
+	return nil
+}
+
+// maxDepth limits nesting of pointers, slices and maps of random values so that recursive types terminate.
+const maxDepth = 4
+
+// Rand returns a random value of T built from r.
+// The same sequence from r gives values deeply equal to each other but sharing no memory.
+func Rand[T any](r *rand.Rand) T {
+	var v T
+	ty := reflect.TypeFor[T]()
+	for ty.Name() == "" && slices.Contains([]reflect.Kind{reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map}, ty.Kind()) {
+		ty = ty.Elem()
+	}
+	g := generator{r: r, pkgPath: ty.PkgPath()}
+	g.fill(reflect.ValueOf(&v).Elem(), 0)
+	return v
+}
+
+type generator struct {
+	r       *rand.Rand
+	pkgPath string
+}
+
+func (g generator) fill(v reflect.Value, depth int) {
+	ty := v.Type()
+	if isNoCopy(ty) || g.opaque(ty) {
+		return
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(g.r.IntN(2) == 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(g.r.IntN(256) - 128))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v.SetUint(uint64(g.r.IntN(256)))
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(float64(g.r.IntN(2048)-1024) / 8)
+	case reflect.Complex64, reflect.Complex128:
+		v.SetComplex(complex(float64(g.r.IntN(256)), float64(g.r.IntN(256))))
+	case reflect.String:
+		b := make([]byte, g.r.IntN(8))
+		for i := range b {
+			b[i] = byte('a' + g.r.IntN(26))
+		}
+		v.SetString(string(b))
+	case reflect.Array:
+		for i := range v.Len() {
+			g.fill(v.Index(i), depth)
+		}
+	case reflect.Struct:
+		for i := range v.NumField() {
+			f := ty.Field(i)
+			if skipped(ty, f.Name) || (!f.IsExported() && ty.PkgPath() != g.pkgPath) {
+				continue
+			}
+			g.fill(exposed(v.Field(i)), depth)
+		}
+	case reflect.Pointer:
+		if depth >= maxDepth || isNoCopy(ty.Elem()) || g.opaque(ty.Elem()) || g.r.IntN(4) == 0 {
+			return
+		}
+		p := reflect.New(ty.Elem())
+		g.fill(p.Elem(), depth+1)
+		v.Set(p)
+	case reflect.Slice:
+		if depth >= maxDepth || g.r.IntN(5) == 0 {
+			return
+		}
+		n := g.r.IntN(4)
+		v.Set(reflect.MakeSlice(ty, n, n+g.r.IntN(3)))
+		for i := range n {
+			g.fill(v.Index(i), depth+1)
+		}
+	case reflect.Map:
+		// keys holding pointers are not deeply equal to ones built again.
+		if depth >= maxDepth || !plainKey(ty.Key()) || g.r.IntN(5) == 0 {
+			return
+		}
+		m := reflect.MakeMap(ty)
+		for range g.r.IntN(4) {
+			k := reflect.New(ty.Key()).Elem()
+			g.fill(k, depth+1)
+			e := reflect.New(ty.Elem()).Elem()
+			g.fill(e, depth+1)
+			m.SetMapIndex(k, e)
+		}
+		v.Set(m)
+	}
+	// channels, functions, interfaces and unsafe.Pointer are left zero value.
+}
+
+// opaque reports whether ty is a struct type of other packages which has unexported fields.
+// Their zero values are the only ones known to be valid.
+func (g generator) opaque(ty reflect.Type) bool {
+	if ty.Kind() != reflect.Struct || ty.PkgPath() == "" || ty.PkgPath() == g.pkgPath {
+		return false
+	}
+	for i := range ty.NumField() {
+		if !ty.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+// Mutate changes every value reachable from v, which must be a non-nil pointer,
+// except no-copy objects and values behind channels, functions and interfaces.
+func Mutate(v any, r *rand.Rand) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		panic(fmt.Errorf("clonetest.Mutate: non-nil pointer is expected but is %T", v))
+	}
+	m := mutator{r: r, visited: map[visitKey]bool{}}
+	m.mutate(rv.Elem())
+}
+
+type visitKey struct {
+	ptr uintptr
+	ty  reflect.Type
+}
+
+type mutator struct {
+	r       *rand.Rand
+	visited map[visitKey]bool
+}
+
+func (m mutator) mutate(v reflect.Value) {
+	ty := v.Type()
+	if isNoCopy(ty) {
+		return
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(!v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(v.Int() + 1 + int64(m.r.IntN(8)))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v.SetUint(v.Uint() + 1 + uint64(m.r.IntN(8)))
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(v.Float() + 1)
+	case reflect.Complex64, reflect.Complex128:
+		v.SetComplex(v.Complex() + 1)
+	case reflect.String:
+		v.SetString(v.String() + "!")
+	case reflect.Array:
+		for i := range v.Len() {
+			m.mutate(v.Index(i))
+		}
+	case reflect.Struct:
+		for i := range v.NumField() {
+			m.mutate(exposed(v.Field(i)))
+		}
+	case reflect.Pointer:
+		key := visitKey{v.Pointer(), ty}
+		if v.IsNil() || m.visited[key] {
+			return
+		}
+		m.visited[key] = true
+		m.mutate(v.Elem())
+	case reflect.Slice:
+		// elements beyond len may be shared but are not observable.
+		for i := range v.Len() {
+			m.mutate(v.Index(i))
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			e := reflect.New(ty.Elem()).Elem()
+			e.Set(iter.Value())
+			m.mutate(e)
+			v.SetMapIndex(iter.Key(), e)
+		}
+	}
+}
+
+// clearChans sets channels reachable from v to nil.
+// Random values leave channels nil, thus clone methods which make new channels only differ in them.
+func clearChans(v reflect.Value, visited map[visitKey]bool) {
+	ty := v.Type()
+	switch v.Kind() {
+	case reflect.Chan:
+		v.SetZero()
+	case reflect.Array:
+		for i := range v.Len() {
+			clearChans(v.Index(i), visited)
+		}
+	case reflect.Struct:
+		for i := range v.NumField() {
+			clearChans(exposed(v.Field(i)), visited)
+		}
+	case reflect.Pointer:
+		key := visitKey{v.Pointer(), ty}
+		if v.IsNil() || visited[key] {
+			return
+		}
+		visited[key] = true
+		clearChans(v.Elem(), visited)
+	case reflect.Slice:
+		for i := range v.Len() {
+			clearChans(v.Index(i), visited)
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			e := reflect.New(ty.Elem()).Elem()
+			e.Set(iter.Value())
+			clearChans(e, visited)
+			v.SetMapIndex(iter.Key(), e)
+		}
+	}
+}
+
+// plainKey reports whether values of ty are compared only by their contents.
+func plainKey(ty reflect.Type) bool {
+	switch ty.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	case reflect.Array:
+		return plainKey(ty.Elem())
+	case reflect.Struct:
+		for i := range ty.NumField() {
+			if !plainKey(ty.Field(i).Type) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// isNoCopy reports whether ty has the Lock method or directly contains such a type.
+func isNoCopy(ty reflect.Type) bool {
+	if m, ok := reflect.PointerTo(ty).MethodByName("Lock"); ok && m.Type.NumOut() == 0 {
+		return true
+	}
+	switch ty.Kind() {
+	case reflect.Struct:
+		for i := range ty.NumField() {
+			if isNoCopy(ty.Field(i).Type) {
+				return true
+			}
+		}
+	case reflect.Array:
+		return isNoCopy(ty.Elem())
+	}
+	return false
+}
+
+// exposed returns v itself if it can be set, otherwise the value at the same address which can be read and set.
+func exposed(v reflect.Value) reflect.Value {
+	if v.CanSet() {
+		return v
+	}
+	return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
+}
@@ -0,0 +1,117 @@
+package clonetest
+
+import (
+	"maps"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type sample struct {
+	Num    int
+	Ptr    *string
+	Nums   []int
+	M      map[string][]int
+	Nested *sample
+	Fn     func()
+	Any    any
+	Mu     *sync.Mutex
+	hidden []byte
+}
+
+func (s *sample) clone() *sample {
+	if s == nil {
+		return nil
+	}
+	out := &sample{
+		Num:    s.Num,
+		Nums:   slices.Clone(s.Nums),
+		Nested: s.Nested.clone(),
+		hidden: slices.Clone(s.hidden),
+	}
+	if s.Ptr != nil {
+		p := *s.Ptr
+		out.Ptr = &p
+	}
+	if s.M != nil {
+		out.M = make(map[string][]int, len(s.M))
+		for k, v := range s.M {
+			out.M[k] = slices.Clone(v)
+		}
+	}
+	return out
+}
+
+func TestCheck(t *testing.T) {
+	deep := func(s *sample) *sample { return s.clone() }
+	for seed := range uint64(Seeds) {
+		if err := check(deep, seed); err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+	}
+
+	shallowMap := func(s *sample) *sample {
+		out := s.clone()
+		if out != nil {
+			out.M = maps.Clone(s.M)
+		}
+		return out
+	}
+	assertDetected(t, shallowMap, "mutating cloned value changed the original")
+
+	lost := func(s *sample) *sample {
+		out := s.clone()
+		if out != nil {
+			out.hidden = nil
+		}
+		return out
+	}
+	assertDetected(t, lost, "cloned value is not equal to the original")
+}
+
+func assertDetected(t *testing.T, clone func(*sample) *sample, msg string) {
+	t.Helper()
+	for seed := range uint64(Seeds) {
+		if err := check(clone, seed); err != nil {
+			if !strings.Contains(err.Error(), msg) {
+				t.Fatalf("err = %v", err)
+			}
+			return
+		}
+	}
+	t.Fatalf("no seed detected %q", msg)
+}
+
+type skipping struct {
+	Kept    []int
+	Ignored []int
+}
+
+func TestSkip(t *testing.T) {
+	Skip[skipping]("Ignored")
+	defer skips.Delete(reflect.TypeFor[skipping]())
+
+	for seed := range uint64(Seeds) {
+		v := Rand[skipping](rand.New(rand.NewPCG(seed, 0)))
+		if v.Ignored != nil {
+			t.Fatalf("skipped field must be left zero: %#v", v)
+		}
+	}
+	if err := check(func(v skipping) skipping { return skipping{Kept: slices.Clone(v.Kept)} }, 0); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRand_leavesZero(t *testing.T) {
+	for seed := range uint64(Seeds) {
+		v := Rand[*sample](rand.New(rand.NewPCG(seed, 0)))
+		for ; v != nil; v = v.Nested {
+			if v.Fn != nil || v.Any != nil || v.Mu != nil {
+				t.Fatalf("func, interface and no-copy fields must be left zero: %#v", v)
+			}
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package cloneruntime
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// DeepClone returns a deep copy of v by reflection.
+// Generated code calls it for values the cloner can not clone statically,
+// e.g. types of other packages having unexported fields but no Clone method.
+//
+// Values are cloned in the following order of precedence:
+//   - nil pointers and nil interfaces are cloned as nil.
+//   - by the cloner registered to this package for the type.
+//   - by the Clone method of the type if it has the signature func() T.
+//   - values of no-copy types, e.g. sync.Mutex, sync.WaitGroup and atomic.Int64, are left zero
+//     as the generator ignores them by default. Pointers to them are copied as is.
+//   - structs and arrays are cloned field by field or element by element, including unexported fields.
+//   - pointers, slices and maps are cloned to newly allocated ones.
+//     Pointers visited more than once are cloned only once, thus aliasing and cycles of pointers are preserved.
+//     Map keys are not cloned.
+//   - values of interfaces are cloned according to their dynamic types.
+//   - others, e.g. channels, functions and unsafe.Pointer, are copied as is.
+func DeepClone[T any](v T) T {
+	var out T
+	c := deepCloner{memo: make(map[memoKey]reflect.Value)}
+	c.clone(reflect.ValueOf(&out).Elem(), reflect.ValueOf(&v).Elem())
+	return out
+}
+
+type memoKey struct {
+	ptr uintptr
+	ty  reflect.Type
+}
+
+type deepCloner struct {
+	memo map[memoKey]reflect.Value
+}
+
+// clone clones src into dst. Both must be addressable.
+func (c deepCloner) clone(dst, src reflect.Value) {
+	dst, src = exposed(dst), exposed(src)
+
+	ty := src.Type()
+	switch src.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+	}
+	if cloner, ok := registry.Load(ty); ok {
+		if cloned := reflect.ValueOf(cloner.(func(any) any)(src.Interface())); cloned.IsValid() {
+			dst.Set(cloned)
+		}
+		return
+	}
+	if m, ok := ty.MethodByName("Clone"); ok && ty.Kind() != reflect.Interface && isCloneMethod(m, ty) {
+		dst.Set(src.Method(m.Index).Call(nil)[0])
+		return
+	}
+	if isNoCopy(ty) {
+		return
+	}
+
+	switch src.Kind() {
+	default:
+		dst.Set(src)
+	case reflect.Pointer:
+		if isNoCopy(ty.Elem()) {
+			dst.Set(src)
+			return
+		}
+		key := memoKey{src.Pointer(), ty}
+		if p, ok := c.memo[key]; ok {
+			dst.Set(p)
+			return
+		}
+		p := reflect.New(ty.Elem())
+		c.memo[key] = p
+		dst.Set(p)
+		c.clone(p.Elem(), src.Elem())
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		dst.Set(reflect.MakeSlice(ty, src.Len(), src.Cap()))
+		for i := range src.Len() {
+			c.clone(dst.Index(i), src.Index(i))
+		}
+	case reflect.Array:
+		for i := range src.Len() {
+			c.clone(dst.Index(i), src.Index(i))
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		dst.Set(reflect.MakeMapWithSize(ty, src.Len()))
+		iter := src.MapRange()
+		for iter.Next() {
+			dst.SetMapIndex(iter.Key(), c.cloneValue(iter.Value()))
+		}
+	case reflect.Struct:
+		for i := range src.NumField() {
+			c.clone(dst.Field(i), src.Field(i))
+		}
+	case reflect.Interface:
+		dst.Set(c.cloneValue(src.Elem()))
+	}
+}
+
+// cloneValue clones v, which may not be addressable.
+func (c deepCloner) cloneValue(v reflect.Value) reflect.Value {
+	src := reflect.New(v.Type()).Elem()
+	src.Set(v)
+	dst := reflect.New(v.Type()).Elem()
+	c.clone(dst, src)
+	return dst
+}
+
+// exposed returns v itself if it is obtained without going through unexported fields,
+// otherwise the value at the same address which can be read and set.
+func exposed(v reflect.Value) reflect.Value {
+	if v.CanSet() {
+		return v
+	}
+	return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
+}
+
+func isCloneMethod(m reflect.Method, ty reflect.Type) bool {
+	return m.Type.NumIn() == 1 && m.Type.NumOut() == 1 && m.Type.Out(0) == ty
+}
+
+// isNoCopy reports whether values of ty must not be copied:
+// ty has Lock method, e.g. sync.Mutex, or ty is a struct type of sync or sync/atomic, e.g. sync.WaitGroup and atomic.Int64.
+// Other structs are cloned field by field, thus only their no-copy fields are left zero.
+func isNoCopy(ty reflect.Type) bool {
+	if ty.Kind() == reflect.Interface {
+		return false
+	}
+	if ty.Kind() == reflect.Struct && (ty.PkgPath() == "sync" || ty.PkgPath() == "sync/atomic") {
+		return true
+	}
+	m, ok := reflect.PointerTo(ty).MethodByName("Lock")
+	return ok && m.Type.NumIn() == 1 && m.Type.NumOut() == 0
+}
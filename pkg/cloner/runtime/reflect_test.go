@@ -0,0 +1,163 @@
+package cloneruntime
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+type opaque struct {
+	name  string
+	vals  []int
+	m     map[string]*int
+	arr   [2]*int
+	inner any
+	ch    chan int
+	mu    sync.Mutex
+}
+
+type node struct {
+	Val  int
+	Next *node
+}
+
+type withClone struct {
+	N int
+}
+
+func (w withClone) Clone() withClone {
+	return withClone{N: w.N + 1}
+}
+
+func TestDeepClone(t *testing.T) {
+	one, two := 1, 2
+	org := &opaque{
+		name:  "foo",
+		vals:  []int{1, 2, 3},
+		m:     map[string]*int{"one": &one, "nil": nil},
+		arr:   [2]*int{&two, nil},
+		inner: []string{"a"},
+		ch:    make(chan int),
+	}
+
+	cloned := DeepClone(org)
+	if cloned == org {
+		t.Fatalf("pointer is not cloned")
+	}
+	if cloned.name != "foo" || !reflect.DeepEqual(cloned.vals, org.vals) {
+		t.Fatalf("wrong clone: %#v", cloned)
+	}
+	if cloned.m["one"] == org.m["one"] || *cloned.m["one"] != 1 || cloned.m["nil"] != nil {
+		t.Fatalf("wrong clone of map: %#v", cloned.m)
+	}
+	if cloned.arr[0] == org.arr[0] || *cloned.arr[0] != 2 {
+		t.Fatalf("wrong clone of array: %#v", cloned.arr)
+	}
+	if cloned.ch != org.ch {
+		t.Fatalf("channel must be copied as is")
+	}
+
+	cloned.vals[0] = 5
+	cloned.inner.([]string)[0] = "b"
+	if org.vals[0] != 1 || org.inner.([]string)[0] != "a" {
+		t.Fatalf("clone shares memory with the original")
+	}
+
+	if DeepClone([]int(nil)) != nil {
+		t.Fatalf("nil slice must be cloned as nil")
+	}
+	if DeepClone[any](nil) != nil {
+		t.Fatalf("nil interface must be cloned as nil")
+	}
+}
+
+func TestDeepClone_cycle(t *testing.T) {
+	n1 := &node{Val: 1}
+	n2 := &node{Val: 2, Next: n1}
+	n1.Next = n2
+
+	cloned := DeepClone(n1)
+	if cloned == n1 || cloned.Next == n2 {
+		t.Fatalf("pointers are not cloned")
+	}
+	if cloned.Next.Val != 2 || cloned.Next.Next != cloned {
+		t.Fatalf("cycle is not preserved")
+	}
+}
+
+func TestDeepClone_precedence(t *testing.T) {
+	if cloned := DeepClone(withClone{N: 1}); cloned != (withClone{N: 2}) {
+		t.Fatalf("Clone method is not used: %#v", cloned)
+	}
+	if cloned := DeepClone([]withClone{{N: 1}}); !reflect.DeepEqual(cloned, []withClone{{N: 2}}) {
+		t.Fatalf("Clone method is not used: %#v", cloned)
+	}
+
+	Register(func(w withClone) withClone { return withClone{N: w.N * 10} })
+	defer Unregister[withClone]()
+	if cloned := DeepClone(withClone{N: 1}); cloned != (withClone{N: 10}) {
+		t.Fatalf("registered cloner is not used: %#v", cloned)
+	}
+}
+
+type ptrClone struct {
+	N int
+}
+
+func (p *ptrClone) Clone() *ptrClone {
+	return &ptrClone{N: p.N + 1}
+}
+
+type withNilPtr struct {
+	P     *ptrClone
+	Inner any
+}
+
+func TestDeepClone_nil(t *testing.T) {
+	if DeepClone[*ptrClone](nil) != nil {
+		t.Fatalf("nil pointer must be cloned as nil")
+	}
+	cloned := DeepClone(withNilPtr{Inner: (*ptrClone)(nil)})
+	if cloned.P != nil {
+		t.Fatalf("nil pointer field must be cloned as nil")
+	}
+	if p, ok := cloned.Inner.(*ptrClone); !ok || p != nil {
+		t.Fatalf("typed nil in interface must be cloned as typed nil: %#v", cloned.Inner)
+	}
+	if cloned := DeepClone(&ptrClone{N: 1}); cloned.N != 2 {
+		t.Fatalf("Clone method is not used: %#v", cloned)
+	}
+}
+
+type noCopy struct {
+	mu     sync.Mutex
+	rw     [2]sync.RWMutex
+	wg     sync.WaitGroup
+	n      atomic.Int64
+	shared *sync.Mutex
+	name   string
+}
+
+func TestDeepClone_noCopy(t *testing.T) {
+	org := &noCopy{shared: new(sync.Mutex), name: "foo"}
+	org.mu.Lock()
+	org.rw[1].RLock()
+	org.wg.Add(1)
+	org.n.Store(5)
+
+	cloned := DeepClone(org)
+	if cloned == org || cloned.name != "foo" {
+		t.Fatalf("wrong clone: %#v", cloned)
+	}
+	if !cloned.mu.TryLock() || !cloned.rw[1].TryLock() {
+		t.Fatalf("locked mutex is cloned as locked")
+	}
+	cloned.wg.Wait()
+	if cloned.n.Load() != 0 {
+		t.Fatalf("no-copy value must be left zero")
+	}
+	if cloned.shared != org.shared {
+		t.Fatalf("pointer to no-copy value must be copied as is")
+	}
+}
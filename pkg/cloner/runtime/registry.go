@@ -0,0 +1,61 @@
+package cloneruntime
+
+import (
+	"reflect"
+	"sync"
+)
+
+var registry sync.Map // reflect.Type -> func(any) any
+
+// Register registers clone as the cloner for values of T.
+// T should be a concrete type; values stored in interfaces never have interface dynamic types.
+// Registering a cloner for the same type again replaces the previous one.
+func Register[T any](clone func(T) T) {
+	registry.Store(
+		reflect.TypeFor[T](),
+		func(v any) any { return clone(v.(T)) },
+	)
+}
+
+// RegisterCloner registers the Clone method of T as the cloner for values of T and *T.
+// A nil *T is cloned as nil.
+func RegisterCloner[T interface{ Clone() T }]() {
+	Register(func(v T) T { return v.Clone() })
+	Register(func(v *T) *T {
+		if v == nil {
+			return nil
+		}
+		c := (*v).Clone()
+		return &c
+	})
+}
+
+// Unregister removes the cloner for values of T.
+func Unregister[T any]() {
+	registry.Delete(reflect.TypeFor[T]())
+}
+
+// Clone clones v by the cloner registered for the dynamic type of v.
+// ok is false if v is nil or no cloner is registered for its type.
+//
+// Clone methods the cloner generator writes with interface fields cloned by type switch
+// handle concrete types found in packages loaded at generation time.
+// Values of other types, e.g. ones defined in packages depending on the generated package,
+// are cloned by the cloner registered to this package, or copied as is if none is registered.
+//
+// Cloners are usually registered in init functions.
+//
+//	func init() {
+//		cloneruntime.RegisterCloner[Foo]()
+//		cloneruntime.Register(func(v *Bar) *Bar { return v.Clone() })
+//	}
+func Clone(v any) (cloned any, ok bool) {
+	if v == nil {
+		return nil, false
+	}
+	clone, ok := registry.Load(reflect.TypeOf(v))
+	if !ok {
+		return nil, false
+	}
+	return clone.(func(any) any)(v), true
+}
@@ -0,0 +1,58 @@
+package cloneruntime
+
+import (
+	"reflect"
+	"testing"
+)
+
+type cloneable struct {
+	S []int
+}
+
+func (c cloneable) Clone() cloneable {
+	return cloneable{S: append([]int(nil), c.S...)}
+}
+
+type plain struct {
+	N int
+}
+
+func TestRegistry(t *testing.T) {
+	RegisterCloner[cloneable]()
+	defer Unregister[cloneable]()
+	defer Unregister[*cloneable]()
+
+	org := cloneable{S: []int{1, 2}}
+	cloned, ok := Clone(org)
+	if !ok || !reflect.DeepEqual(org, cloned) {
+		t.Fatalf("wrong clone: ok = %t, cloned = %#v", ok, cloned)
+	}
+	cloned.(cloneable).S[0] = 5
+	if org.S[0] != 1 {
+		t.Fatalf("clone shares memory with the original")
+	}
+
+	clonedPtr, ok := Clone(&org)
+	if !ok || clonedPtr.(*cloneable) == &org || !reflect.DeepEqual(org, *clonedPtr.(*cloneable)) {
+		t.Fatalf("wrong clone: ok = %t, cloned = %#v", ok, clonedPtr)
+	}
+
+	clonedPtr, ok = Clone((*cloneable)(nil))
+	if !ok || clonedPtr.(*cloneable) != nil {
+		t.Fatalf("nil pointer must be cloned as nil: ok = %t, cloned = %#v", ok, clonedPtr)
+	}
+
+	if _, ok := Clone(plain{N: 1}); ok {
+		t.Fatalf("cloned by unregistered type")
+	}
+	if _, ok := Clone(nil); ok {
+		t.Fatalf("cloned nil")
+	}
+
+	Register(func(p plain) plain { return plain{N: p.N + 1} })
+	defer Unregister[plain]()
+	cloned, ok = Clone(plain{N: 1})
+	if !ok || cloned != (plain{N: 2}) {
+		t.Fatalf("wrong clone: ok = %t, cloned = %#v", ok, cloned)
+	}
+}
@@ -0,0 +1,5 @@
+module github.com/ngicks/go-codegen/pkg/undgen/runtime
+
+go 1.23.0
+
+require github.com/ngicks/und v1.0.0-alpha8
@@ -0,0 +1,191 @@
+// Package jsonpatch converts patch types generated by undgen patch from and to
+// JSON Patch documents defined in RFC 6902.
+//
+// Generated ToJSONPatch and FromJSONPatch methods call functions of this package.
+// Only add, remove and replace operations on members of objects are supported.
+// ToJSONPatch emits only add operations, which create members or replace existing ones.
+//
+// Patch types are also JSON Merge Patch documents defined in RFC 7396 as they are,
+// when they are marshaled or unmarshaled by encoding/json.
+// Nested objects are merged only if deep patches are generated.
+package jsonpatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ngicks/und/sliceund"
+)
+
+const (
+	// ContentType is the media type of JSON Patch documents.
+	ContentType = "application/json-patch+json"
+	// MergePatchContentType is the media type of JSON Merge Patch documents.
+	MergePatchContentType = "application/merge-patch+json"
+)
+
+const (
+	OpAdd     = "add"
+	OpRemove  = "remove"
+	OpReplace = "replace"
+	OpMove    = "move"
+	OpCopy    = "copy"
+	OpTest    = "test"
+)
+
+var (
+	// ErrUnsupported is returned if an operation can not be expressed by patch types.
+	ErrUnsupported = errors.New("unsupported operation")
+	// ErrPathNotFound is returned if a path of an operation points to no field.
+	ErrPathNotFound = errors.New("path not found")
+)
+
+// Operation is an operation of JSON Patch documents.
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Escape escapes a reference token of JSON Pointer defined in RFC 6901.
+func Escape(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+// Unescape unescapes a reference token of JSON Pointer defined in RFC 6901.
+func Unescape(token string) string {
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+}
+
+// Split splits path into its first reference token, which is unescaped, and the rest of path.
+// The rest is empty or starts with "/".
+func Split(path string) (token string, rest string, err error) {
+	p, ok := strings.CutPrefix(path, "/")
+	if !ok {
+		return "", "", fmt.Errorf("%w: %q: operations on the whole document", ErrUnsupported, path)
+	}
+	token, rest, found := strings.Cut(p, "/")
+	if found {
+		rest = "/" + rest
+	}
+	return Unescape(token), rest, nil
+}
+
+// CheckOp returns an error wrapping ErrUnsupported if op is not an add, remove or replace operation.
+// move, copy and test operations are not supported since patch types can not express them.
+func CheckOp(op Operation) error {
+	switch op.Op {
+	case OpAdd, OpRemove, OpReplace:
+		return nil
+	}
+	return fmt.Errorf("%w: %q: %q", ErrUnsupported, op.Op, op.Path)
+}
+
+// PathNotFound returns an error which reports that no field is found for op.
+func PathNotFound(op Operation) error {
+	return fmt.Errorf("%w: %q", ErrPathNotFound, op.Path)
+}
+
+type undLike interface {
+	IsDefined() bool
+	IsNull() bool
+}
+
+// AppendField appends to ops an operation for the field named name whose value is v.
+// An add operation is appended if v is defined or null, whose value is null in the latter case.
+// Nothing is appended if v is undefined.
+func AppendField[T undLike](ops []Operation, name string, v T) ([]Operation, error) {
+	if !v.IsDefined() && !v.IsNull() {
+		return ops, nil
+	}
+	path := "/" + Escape(name)
+	bin, err := json.Marshal(v)
+	if err != nil {
+		return ops, fmt.Errorf("%q: %w", path, err)
+	}
+	return append(ops, Operation{Op: OpAdd, Path: path, Value: bin}), nil
+}
+
+// AppendNested is like AppendField but for fields of deep patches.
+// If v is defined, operations of the nested patch are appended with paths prefixed by name.
+func AppendNested[P interface {
+	ToJSONPatch() ([]Operation, error)
+}](ops []Operation, name string, v sliceund.Und[P]) ([]Operation, error) {
+	if !v.IsDefined() {
+		return AppendField(ops, name, v)
+	}
+	path := "/" + Escape(name)
+	nested, err := v.Value().ToJSONPatch()
+	if err != nil {
+		return ops, fmt.Errorf("%q: %w", path, err)
+	}
+	for _, op := range nested {
+		op.Path = path + op.Path
+		ops = append(ops, op)
+	}
+	return ops, nil
+}
+
+// DecodeField applies op to the field v.
+// rest is the rest of the path of op after the field name, which must be empty.
+// add and replace operations set the value to v, and remove operations set null to v
+// as the patch type can not express removal otherwise.
+func DecodeField[T any](op Operation, rest string, v *T) error {
+	if rest != "" {
+		return fmt.Errorf("%w: %q: points inside of a field", ErrUnsupported, op.Path)
+	}
+	switch op.Op {
+	case OpAdd, OpReplace:
+		if len(op.Value) == 0 {
+			return fmt.Errorf("%q: value is missing", op.Path)
+		}
+		if err := json.Unmarshal(op.Value, v); err != nil {
+			return fmt.Errorf("%q: %w", op.Path, err)
+		}
+		return nil
+	case OpRemove:
+		return json.Unmarshal([]byte(`null`), v)
+	}
+	return fmt.Errorf("%w: %q: %q", ErrUnsupported, op.Op, op.Path)
+}
+
+// DecodeNested is like DecodeField but for fields of deep patches.
+// V is the type of the field in the original type.
+//
+// If op removes the whole field or sets null to it, the field is set to null.
+// If op replaces the whole field, its value is decoded as V and converted by FromValue,
+// so fields missing in the value are set to zero value,
+// except for fields of und types, e.g. und.Und[T], which are left unchanged when undefined.
+// Otherwise the rest of the path is applied to the nested patch.
+func DecodeNested[V any, P any, PP interface {
+	*P
+	FromValue(v V)
+	FromJSONPatch(ops []Operation) error
+}](op Operation, rest string, v *sliceund.Und[P]) error {
+	if rest == "" {
+		if op.Op == OpRemove || string(bytes.TrimSpace(op.Value)) == "null" {
+			*v = sliceund.Null[P]()
+			return nil
+		}
+		var org V
+		if err := DecodeField(op, rest, &org); err != nil {
+			return err
+		}
+		var p P
+		PP(&p).FromValue(org)
+		*v = sliceund.Defined(p)
+		return nil
+	}
+	p := v.Value()
+	nested := op
+	nested.Path = rest
+	if err := PP(&p).FromJSONPatch([]Operation{nested}); err != nil {
+		return fmt.Errorf("%q: %w", strings.TrimSuffix(op.Path, rest), err)
+	}
+	*v = sliceund.Defined(p)
+	return nil
+}
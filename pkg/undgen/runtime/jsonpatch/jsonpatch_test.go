@@ -0,0 +1,128 @@
+package jsonpatch_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ngicks/go-codegen/pkg/undgen/runtime/jsonpatch"
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/sliceund"
+)
+
+func TestSplit(t *testing.T) {
+	for _, tc := range []struct {
+		path  string
+		token string
+		rest  string
+	}{
+		{"/foo", "foo", ""},
+		{"/foo/bar/baz", "foo", "/bar/baz"},
+		{"/a~1b/c", "a/b", "/c"},
+		{"/m~0n", "m~n", ""},
+		{"/", "", ""},
+	} {
+		token, rest, err := jsonpatch.Split(tc.path)
+		if err != nil {
+			t.Fatalf("Split(%q) failed: %v", tc.path, err)
+		}
+		if token != tc.token || rest != tc.rest {
+			t.Errorf("Split(%q) = (%q, %q), want (%q, %q)", tc.path, token, rest, tc.token, tc.rest)
+		}
+		if escaped := "/" + jsonpatch.Escape(token) + rest; escaped != tc.path {
+			t.Errorf("escaped path = %q, want %q", escaped, tc.path)
+		}
+	}
+
+	if _, _, err := jsonpatch.Split(""); !errors.Is(err, jsonpatch.ErrUnsupported) {
+		t.Errorf("Split(\"\") must fail with ErrUnsupported but got %v", err)
+	}
+}
+
+func TestAppendField(t *testing.T) {
+	var (
+		ops []jsonpatch.Operation
+		err error
+	)
+	ops, err = jsonpatch.AppendField(ops, "a", sliceund.Defined(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ops, err = jsonpatch.AppendField(ops, "b/c", und.Null[string]())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ops, err = jsonpatch.AppendField(ops, "d", sliceund.Undefined[int]())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bin, err := json.Marshal(ops)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `[{"op":"add","path":"/a","value":1},{"op":"add","path":"/b~1c","value":null}]`; string(bin) != want {
+		t.Errorf("marshaled = %s, want %s", bin, want)
+	}
+}
+
+func TestDecodeField(t *testing.T) {
+	var v sliceund.Und[int]
+	if err := jsonpatch.DecodeField(jsonpatch.Operation{Op: jsonpatch.OpAdd, Path: "/a", Value: []byte(`5`)}, "", &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Value() != 5 {
+		t.Errorf("decoded = %d, want 5", v.Value())
+	}
+	if err := jsonpatch.DecodeField(jsonpatch.Operation{Op: jsonpatch.OpRemove, Path: "/a"}, "", &v); err != nil {
+		t.Fatal(err)
+	}
+	if !v.IsNull() {
+		t.Errorf("removed field must be null")
+	}
+	v = sliceund.Defined(5)
+	var op jsonpatch.Operation
+	if err := json.Unmarshal([]byte(`{"op":"add","path":"/a","value":null}`), &op); err != nil {
+		t.Fatal(err)
+	}
+	if err := jsonpatch.DecodeField(op, "", &v); err != nil {
+		t.Fatal(err)
+	}
+	if !v.IsNull() {
+		t.Errorf("field added with null must be null")
+	}
+
+	for _, tc := range []struct {
+		op   jsonpatch.Operation
+		rest string
+		err  error
+		msg  string
+	}{
+		{jsonpatch.Operation{Op: jsonpatch.OpReplace, Path: "/a/b", Value: []byte(`5`)}, "/b", jsonpatch.ErrUnsupported, ""},
+		{jsonpatch.Operation{Op: jsonpatch.OpTest, Path: "/a", Value: []byte(`5`)}, "", jsonpatch.ErrUnsupported, ""},
+		{jsonpatch.Operation{Op: jsonpatch.OpReplace, Path: "/a"}, "", nil, "value is missing"},
+		{jsonpatch.Operation{Op: jsonpatch.OpReplace, Path: "/a", Value: []byte(`"foo"`)}, "", nil, "cannot unmarshal"},
+	} {
+		err := jsonpatch.DecodeField(tc.op, tc.rest, &v)
+		if tc.err != nil && !errors.Is(err, tc.err) {
+			t.Errorf("DecodeField(%+v) must fail with %v but got %v", tc.op, tc.err, err)
+		}
+		if tc.err == nil && (err == nil || !strings.Contains(err.Error(), tc.msg)) {
+			t.Errorf("DecodeField(%+v) must fail with %q but got %v", tc.op, tc.msg, err)
+		}
+	}
+}
+
+func TestCheckOp(t *testing.T) {
+	for _, op := range []string{jsonpatch.OpAdd, jsonpatch.OpRemove, jsonpatch.OpReplace} {
+		if err := jsonpatch.CheckOp(jsonpatch.Operation{Op: op, Path: "/a"}); err != nil {
+			t.Errorf("CheckOp(%q) must succeed but got %v", op, err)
+		}
+	}
+	for _, op := range []string{jsonpatch.OpMove, jsonpatch.OpCopy, jsonpatch.OpTest, "unknown"} {
+		if err := jsonpatch.CheckOp(jsonpatch.Operation{Op: op, Path: "/a"}); !errors.Is(err, jsonpatch.ErrUnsupported) {
+			t.Errorf("CheckOp(%q) must fail with ErrUnsupported but got %v", op, err)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+// Package patchpolicy defines errors reported by patch types generated by undgen patch
+// for fields tagged with field-level patch policies, e.g. patch:"readonly".
+package patchpolicy
+
+import "errors"
+
+// ErrReadOnly is returned by generated ApplyPatchChecked and CheckReadOnly methods
+// if a patch sets a field tagged with patch:"readonly",
+// or nulls a nested field which has read-only fields.
+var ErrReadOnly = errors.New("read-only field")